@@ -0,0 +1,211 @@
+// Command dlq-replay re-injects dead-lettered messages into the topic they
+// failed on, once the cause has been fixed. Progress is committed under its
+// own consumer group, so running it again only replays newer messages.
+package main
+
+import (
+	"flag"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/IBM/sarama"
+	"github.com/bitstream/backend-go/internal/config"
+	"github.com/bitstream/backend-go/internal/kafka"
+	"github.com/bitstream/backend-go/internal/kafka/consumer"
+	"github.com/bitstream/backend-go/internal/kafka/producer"
+	"github.com/bitstream/backend-go/internal/kafka/topics"
+)
+
+type options struct {
+	topic  string
+	target string
+	group  string
+	limit  int
+	dryRun bool
+}
+
+func main() {
+	cfgPath := flag.String("config", "", "config file path")
+	opts := options{}
+	flag.StringVar(&opts.topic, "topic", topics.STREAM_ON_PUBLISH_DLQ, "dead letter topic to replay")
+	flag.StringVar(&opts.target, "to", "", "topic to replay into (default: the topic each message failed on)")
+	flag.StringVar(&opts.group, "group", "", "consumer group tracking replay progress (default: <groupId>-dlq-replay)")
+	flag.IntVar(&opts.limit, "limit", 0, "replay at most this many messages (0: all)")
+	flag.BoolVar(&opts.dryRun, "dry-run", false, "list the messages without replaying or committing")
+	flag.Parse()
+
+	path := *cfgPath
+	if path == "" {
+		path = os.Getenv("APP_CONFIG")
+	}
+	if path == "" {
+		path = "configs/app.yaml"
+	}
+
+	env, err := config.Load(path)
+	if err != nil {
+		slog.Error("failed to load config", "err", err)
+		os.Exit(1)
+	}
+
+	if opts.group == "" {
+		opts.group = env.Kafka.Consumer.GroupID + "-dlq-replay"
+	}
+
+	replayed, err := replay(env.Kafka, opts)
+	if err != nil {
+		slog.Error("replay failed", "replayed", replayed, "err", err)
+		os.Exit(1)
+	}
+
+	slog.Info("replay finished", "topic", opts.topic, "replayed", replayed, "dryRun", opts.dryRun)
+}
+
+// replay reads every partition of the dead letter topic from the group's
+// committed offset up to the end it had when the replay started.
+func replay(cfg config.KafkaConfig, opts options) (int, error) {
+	saramaCfg := kafka.NewSaramaConfig(cfg)
+	saramaCfg.Consumer.Offsets.Initial = sarama.OffsetOldest
+
+	client, err := sarama.NewClient(cfg.Brokers, saramaCfg)
+	if err != nil {
+		return 0, err
+	}
+	defer client.Close()
+
+	offsets, err := sarama.NewOffsetManagerFromClient(opts.group, client)
+	if err != nil {
+		return 0, err
+	}
+	defer offsets.Close()
+
+	reader, err := sarama.NewConsumerFromClient(client)
+	if err != nil {
+		return 0, err
+	}
+	defer reader.Close()
+
+	var out *producer.Producer
+	if !opts.dryRun {
+		out, err = producer.NewProducer(cfg.Brokers)
+		if err != nil {
+			return 0, err
+		}
+		defer out.Close()
+	}
+
+	partitions, err := client.Partitions(opts.topic)
+	if err != nil {
+		return 0, err
+	}
+
+	replayed := 0
+	for _, partition := range partitions {
+		if opts.limit > 0 && replayed >= opts.limit {
+			break
+		}
+
+		n, err := replayPartition(client, offsets, reader, out, partition, opts, opts.limit-replayed)
+		replayed += n
+		if err != nil {
+			return replayed, err
+		}
+	}
+
+	return replayed, nil
+}
+
+func replayPartition(
+	client sarama.Client,
+	offsets sarama.OffsetManager,
+	reader sarama.Consumer,
+	out *producer.Producer,
+	partition int32,
+	opts options,
+	limit int,
+) (int, error) {
+	end, err := client.GetOffset(opts.topic, partition, sarama.OffsetNewest)
+	if err != nil {
+		return 0, err
+	}
+
+	pom, err := offsets.ManagePartition(opts.topic, partition)
+	if err != nil {
+		return 0, err
+	}
+	defer pom.Close()
+
+	next, _ := pom.NextOffset()
+	if next < 0 {
+		if next, err = client.GetOffset(opts.topic, partition, sarama.OffsetOldest); err != nil {
+			return 0, err
+		}
+	}
+	if next >= end {
+		return 0, nil
+	}
+
+	pc, err := reader.ConsumePartition(opts.topic, partition, next)
+	if err != nil {
+		return 0, err
+	}
+	defer pc.Close()
+
+	replayed := 0
+	for msg := range pc.Messages() {
+		target := opts.target
+		if target == "" {
+			target = originalTopic(msg, opts.topic)
+		}
+
+		reason, _ := consumer.HeaderValue(msg.Headers, consumer.HeaderError)
+		slog.Info("replaying message",
+			"partition", msg.Partition,
+			"offset", msg.Offset,
+			"to", target,
+			"reason", reason,
+		)
+
+		if !opts.dryRun {
+			err := out.PublishMessage(&sarama.ProducerMessage{
+				Topic:   target,
+				Key:     sarama.ByteEncoder(msg.Key),
+				Value:   sarama.ByteEncoder(msg.Value),
+				Headers: originalHeaders(msg.Headers),
+			})
+			if err != nil {
+				return replayed, err
+			}
+			pom.MarkOffset(msg.Offset+1, "")
+		}
+
+		replayed++
+		if msg.Offset+1 >= end || (limit > 0 && replayed >= limit) {
+			break
+		}
+	}
+
+	return replayed, nil
+}
+
+// originalTopic is the topic a message failed on, from its headers or else
+// from the dead letter topic's name.
+func originalTopic(msg *sarama.ConsumerMessage, dlqTopic string) string {
+	if topic, ok := consumer.HeaderValue(msg.Headers, consumer.HeaderOriginalTopic); ok && topic != "" {
+		return topic
+	}
+	return strings.TrimSuffix(dlqTopic, ".dlq")
+}
+
+// originalHeaders drops the headers added when the message was dead-lettered.
+func originalHeaders(headers []*sarama.RecordHeader) []sarama.RecordHeader {
+	kept := make([]sarama.RecordHeader, 0, len(headers))
+	for _, h := range headers {
+		if h == nil || strings.HasPrefix(string(h.Key), "x-dlq-") {
+			continue
+		}
+		kept = append(kept, *h)
+	}
+	return kept
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/bitstream/backend-go/internal/deps"
 	"github.com/bitstream/backend-go/internal/domain"
 	"github.com/bitstream/backend-go/internal/kafka"
+	"github.com/bitstream/backend-go/internal/kafka/producer"
 	"github.com/bitstream/backend-go/internal/kafka/service"
 	"github.com/bitstream/backend-go/internal/logger"
 	"github.com/bitstream/backend-go/internal/storage/minio"
@@ -58,10 +59,18 @@ func main() {
 		os.Exit(1)
 	}
 
+	kafkaProducer, err := producer.NewProducer(env.Kafka.Brokers)
+	if err != nil {
+		slog.Error("failed to init kafka producer", "err", err)
+		os.Exit(1)
+	}
+	defer kafkaProducer.Close()
+
 	appDeps := &deps.Deps{
-		DB:      database,
-		Config:  env,
-		Storage: storageService,
+		DB:       database,
+		Config:   env,
+		Storage:  storageService,
+		Producer: kafkaProducer,
 	}
 
 	if err := storageService.EnsureBucket(context.Background()); err != nil {
@@ -76,7 +85,7 @@ func main() {
 
 	domain.RegisterAll(appDeps)
 
-	kafkaService := service.NewKafkaService(env.Kafka, saramaCfg)
+	kafkaService := service.NewKafkaService(env.Kafka, saramaCfg, kafkaProducer)
 	if err := kafkaService.Start(ctx); err != nil {
 		log.Error("Kafka service failed", "error", err)
 		panic(err)
@@ -89,6 +98,21 @@ func main() {
 	<-sig
 
 	log.Info("Shutting down worker...")
-	domain.Shutdown()
+
+	timeout := env.ShutdownTimeout
+	if timeout <= 0 {
+		timeout = config.DefaultShutdownTimeout
+	}
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), timeout)
+	defer shutdownCancel()
+
+	// consumers first, so nothing is committed that the manager will not
+	// handle anymore
+	if err := kafkaService.Shutdown(shutdownCtx); err != nil {
+		log.Error("Kafka consumers did not stop cleanly", "error", err)
+	}
+	cancel()
+
+	domain.Shutdown(shutdownCtx)
 	log.Info("Worker stopped.")
 }
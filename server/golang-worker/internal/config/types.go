@@ -5,14 +5,42 @@ import (
 	"time"
 )
 
+const DefaultShutdownTimeout = 90 * time.Second
+
 type AppConfig struct {
-	Env    string       `mapstructure:"env"`
+	Env string `mapstructure:"env"`
+
+	// WorkerID names this worker in the stream leases and handoffs it
+	// shares with other workers. When empty one is generated and kept in
+	// the output directory. It must be stable, so a restarted worker
+	// recognises its own leases and recovers the streams it was running,
+	// and unique to the worker.
+	WorkerID string `mapstructure:"workerId"`
+
+	// ShutdownTimeout bounds how long the worker drains on SIGTERM;
+	// DefaultShutdownTimeout when zero.
+	ShutdownTimeout time.Duration `mapstructure:"shutdownTimeout"`
+
 	Server ServerConfig `mapstructure:"server"`
 	Log    LogConfig    `mapstructure:"log"`
 	Kafka  KafkaConfig  `mapstructure:"kafka"`
 	FFmpeg FFmpegConfig `mapstructure:"ffmpeg"`
 	Db     DbConfig     `mapstructure:"db"`
 	MinIO  MinIOConfig  `mapstructure:"minio"`
+	Upload UploadConfig `mapstructure:"upload"`
+
+	Handoff HandoffConfig `mapstructure:"handoff"`
+}
+
+// HandoffConfig controls how a worker that shuts down passes its live
+// streams to another worker instead of ending them.
+type HandoffConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+
+	// Timeout bounds how long the worker handing a stream over waits for
+	// the new one to write its first segment. The new one waits for the old
+	// one to stop for as long as its shutdown may take.
+	Timeout time.Duration `mapstructure:"timeout"`
 }
 
 type MinIOConfig struct {
@@ -23,12 +51,35 @@ type MinIOConfig struct {
 	UseSSL          bool   `mapstructure:"useSSL"`
 }
 
+type UploadConfig struct {
+	Workers           int           `mapstructure:"workers"`
+	QueueSize         int           `mapstructure:"queueSize"`
+	PerStreamInFlight int           `mapstructure:"perStreamInFlight"`
+	MaxAttempts       int           `mapstructure:"maxAttempts"`
+	RetryBaseDelay    time.Duration `mapstructure:"retryBaseDelay"`
+	RetryMaxDelay     time.Duration `mapstructure:"retryMaxDelay"`
+}
+
 type DbConfig struct {
 	Url string `mapstructure:"url"`
 }
 
 type FFmpegConfig struct {
-	OutputDir string `mapstructure:"outputDir"`
+	OutputDir  string            `mapstructure:"outputDir"`
+	OutputMode string            `mapstructure:"outputMode"`
+	PartTarget time.Duration     `mapstructure:"partTarget"`
+	Renditions []RenditionConfig `mapstructure:"renditions"`
+}
+
+type RenditionConfig struct {
+	Name         string `mapstructure:"name"`
+	Width        int    `mapstructure:"width"`
+	Height       int    `mapstructure:"height"`
+	VideoBitrate string `mapstructure:"videoBitrate"`
+	MaxRate      string `mapstructure:"maxRate"`
+	BufSize      string `mapstructure:"bufSize"`
+	Profile      string `mapstructure:"profile"`
+	Level        string `mapstructure:"level"`
 }
 
 type ServerConfig struct {
@@ -57,6 +108,11 @@ type ConsumerConfig struct {
 	MaxWaitTime      time.Duration `mapstructure:"maxWaitTime"`
 	SessionTimeout   time.Duration `mapstructure:"sessionTimeout"`
 	RebalanceTimeout time.Duration `mapstructure:"rebalanceTimeout"`
+
+	// MaxAttempts is how often a message is handled before it is moved to
+	// the dead letter topic of its registration.
+	MaxAttempts  int           `mapstructure:"maxAttempts"`
+	RetryBackoff time.Duration `mapstructure:"retryBackoff"`
 }
 
 type ProducerConfig struct {
@@ -6,11 +6,31 @@ package stream
 
 import (
 	"database/sql"
+	"encoding/json"
 	"time"
 
 	"github.com/sqlc-dev/pqtype"
 )
 
+type Outbox struct {
+	ID        string
+	Topic     string
+	Key       string
+	Payload   json.RawMessage
+	Attempts  int32
+	LastError sql.NullString
+	CreatedAt time.Time
+	SentAt    sql.NullTime
+}
+
+type ProcessedEvent struct {
+	EventId     string
+	RetryCount  int32
+	StreamId    string
+	Action      string
+	ProcessedAt time.Time
+}
+
 type Recording struct {
 	ID        string
 	StreamId  string
@@ -41,6 +61,17 @@ type StreamEvent struct {
 	CreatedAt time.Time
 }
 
+type StreamHandoff struct {
+	ID         string
+	StreamId   string
+	FromWorker string
+	ToWorker   sql.NullString
+	Status     string
+	ResumeSeq  sql.NullInt32
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
 type StreamKey struct {
 	ID         string
 	StreamId   string
@@ -51,6 +82,15 @@ type StreamKey struct {
 	CreatedAt  time.Time
 }
 
+type StreamLease struct {
+	StreamId    string
+	OwnerId     string
+	Command     json.RawMessage
+	AcquiredAt  time.Time
+	HeartbeatAt time.Time
+	ExpiresAt   time.Time
+}
+
 type StreamMetum struct {
 	ID              string
 	StreamId        string
@@ -62,6 +102,8 @@ type StreamMetum struct {
 	VideoRepId      string
 	AudioRepId      string
 	BasePath        sql.NullString
+	VodManifestPath sql.NullString
+	Renditions      json.RawMessage
 	CreatedAt       time.Time
 	UpdatedAt       time.Time
 }
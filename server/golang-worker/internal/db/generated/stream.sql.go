@@ -8,11 +8,177 @@ package stream
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"time"
 
 	"github.com/sqlc-dev/pqtype"
 )
 
+const abortStreamHandoff = `-- name: AbortStreamHandoff :execrows
+UPDATE "StreamHandoff"
+SET status = 'ABORTED',
+    "updatedAt" = now()
+WHERE id = $1
+  AND status = 'REQUESTED'
+`
+
+func (q *Queries) AbortStreamHandoff(ctx context.Context, id string) (int64, error) {
+	result, err := q.db.ExecContext(ctx, abortStreamHandoff, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const acquireStreamLease = `-- name: AcquireStreamLease :execrows
+
+INSERT INTO "StreamLease" (
+  "streamId", "ownerId", command, "expiresAt"
+) VALUES (
+  $1, $2, $3, $4
+)
+ON CONFLICT ("streamId") DO UPDATE
+SET "ownerId" = EXCLUDED."ownerId",
+    command = EXCLUDED.command,
+    "acquiredAt" = CASE
+      WHEN "StreamLease"."ownerId" = EXCLUDED."ownerId" THEN "StreamLease"."acquiredAt"
+      ELSE now()
+    END,
+    "heartbeatAt" = now(),
+    "expiresAt" = EXCLUDED."expiresAt"
+WHERE "StreamLease"."ownerId" = EXCLUDED."ownerId"
+   OR "StreamLease"."expiresAt" < now()
+`
+
+type AcquireStreamLeaseParams struct {
+	StreamId  string
+	OwnerId   string
+	Command   json.RawMessage
+	ExpiresAt time.Time
+}
+
+// =========================
+// STREAM LEASE
+// =========================
+func (q *Queries) AcquireStreamLease(ctx context.Context, arg AcquireStreamLeaseParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, acquireStreamLease,
+		arg.StreamId,
+		arg.OwnerId,
+		arg.Command,
+		arg.ExpiresAt,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const claimOutboxEvents = `-- name: ClaimOutboxEvents :many
+SELECT id, topic, key, payload, attempts, "lastError", "createdAt", "sentAt"
+FROM "Outbox"
+WHERE "sentAt" IS NULL
+  AND attempts < $1
+ORDER BY "createdAt", id
+LIMIT $2
+FOR UPDATE
+`
+
+type ClaimOutboxEventsParams struct {
+	Attempts int32
+	Limit    int32
+}
+
+func (q *Queries) ClaimOutboxEvents(ctx context.Context, arg ClaimOutboxEventsParams) ([]Outbox, error) {
+	rows, err := q.db.QueryContext(ctx, claimOutboxEvents, arg.Attempts, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Outbox
+	for rows.Next() {
+		var i Outbox
+		if err := rows.Scan(
+			&i.ID,
+			&i.Topic,
+			&i.Key,
+			&i.Payload,
+			&i.Attempts,
+			&i.LastError,
+			&i.CreatedAt,
+			&i.SentAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const createOutboxEvent = `-- name: CreateOutboxEvent :exec
+
+INSERT INTO "Outbox" (
+  id, topic, key, payload
+) VALUES (
+  $1, $2, $3, $4
+)
+`
+
+type CreateOutboxEventParams struct {
+	ID      string
+	Topic   string
+	Key     string
+	Payload json.RawMessage
+}
+
+// =========================
+// OUTBOX
+// =========================
+func (q *Queries) CreateOutboxEvent(ctx context.Context, arg CreateOutboxEventParams) error {
+	_, err := q.db.ExecContext(ctx, createOutboxEvent,
+		arg.ID,
+		arg.Topic,
+		arg.Key,
+		arg.Payload,
+	)
+	return err
+}
+
+const createProcessedEvent = `-- name: CreateProcessedEvent :exec
+
+INSERT INTO "ProcessedEvent" (
+  "eventId", "retryCount", "streamId", action
+) VALUES (
+  $1, $2, $3, $4
+)
+ON CONFLICT ("eventId", "retryCount") DO NOTHING
+`
+
+type CreateProcessedEventParams struct {
+	EventId    string
+	RetryCount int32
+	StreamId   string
+	Action     string
+}
+
+// =========================
+// PROCESSED EVENT
+// =========================
+func (q *Queries) CreateProcessedEvent(ctx context.Context, arg CreateProcessedEventParams) error {
+	_, err := q.db.ExecContext(ctx, createProcessedEvent,
+		arg.EventId,
+		arg.RetryCount,
+		arg.StreamId,
+		arg.Action,
+	)
+	return err
+}
+
 const createRecording = `-- name: CreateRecording :exec
 
 INSERT INTO "Recording" (
@@ -104,6 +270,29 @@ func (q *Queries) CreateStreamEvent(ctx context.Context, arg CreateStreamEventPa
 	return err
 }
 
+const createStreamHandoff = `-- name: CreateStreamHandoff :exec
+
+INSERT INTO "StreamHandoff" (
+  id, "streamId", "fromWorker"
+) VALUES (
+  $1, $2, $3
+)
+`
+
+type CreateStreamHandoffParams struct {
+	ID         string
+	StreamId   string
+	FromWorker string
+}
+
+// =========================
+// STREAM HANDOFF
+// =========================
+func (q *Queries) CreateStreamHandoff(ctx context.Context, arg CreateStreamHandoffParams) error {
+	_, err := q.db.ExecContext(ctx, createStreamHandoff, arg.ID, arg.StreamId, arg.FromWorker)
+	return err
+}
+
 const createStreamMeta = `-- name: CreateStreamMeta :exec
 INSERT INTO "StreamMeta" (
   id, "streamId", "totalDuration", "segmentDuration", "timescale", "videoRepId", "audioRepId", "basePath"
@@ -135,6 +324,27 @@ func (q *Queries) CreateStreamMeta(ctx context.Context, arg CreateStreamMetaPara
 	return err
 }
 
+const deleteProcessedEventsBefore = `-- name: DeleteProcessedEventsBefore :exec
+DELETE FROM "ProcessedEvent"
+WHERE "processedAt" < $1
+`
+
+func (q *Queries) DeleteProcessedEventsBefore(ctx context.Context, processedat time.Time) error {
+	_, err := q.db.ExecContext(ctx, deleteProcessedEventsBefore, processedat)
+	return err
+}
+
+const deleteSentOutboxEvents = `-- name: DeleteSentOutboxEvents :exec
+DELETE FROM "Outbox"
+WHERE "sentAt" IS NOT NULL
+  AND "sentAt" < $1
+`
+
+func (q *Queries) DeleteSentOutboxEvents(ctx context.Context, sentat sql.NullTime) error {
+	_, err := q.db.ExecContext(ctx, deleteSentOutboxEvents, sentat)
+	return err
+}
+
 const disableStreamKey = `-- name: DisableStreamKey :exec
 UPDATE "StreamKey"
 SET "isActive" = FALSE
@@ -146,6 +356,27 @@ func (q *Queries) DisableStreamKey(ctx context.Context, streamid string) error {
 	return err
 }
 
+const existProcessedEvent = `-- name: ExistProcessedEvent :one
+SELECT EXISTS (
+  SELECT 1
+  FROM "ProcessedEvent"
+  WHERE "eventId" = $1
+    AND "retryCount" = $2
+)
+`
+
+type ExistProcessedEventParams struct {
+	EventId    string
+	RetryCount int32
+}
+
+func (q *Queries) ExistProcessedEvent(ctx context.Context, arg ExistProcessedEventParams) (bool, error) {
+	row := q.db.QueryRowContext(ctx, existProcessedEvent, arg.EventId, arg.RetryCount)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
 const existStreamMeta = `-- name: ExistStreamMeta :one
 SELECT EXISTS (
   SELECT 1
@@ -381,9 +612,31 @@ func (q *Queries) GetStreamEvents(ctx context.Context, arg GetStreamEventsParams
 	return items, nil
 }
 
+const getStreamHandoff = `-- name: GetStreamHandoff :one
+SELECT id, "streamId", "fromWorker", "toWorker", status, "resumeSeq", "createdAt", "updatedAt"
+FROM "StreamHandoff"
+WHERE id = $1
+`
+
+func (q *Queries) GetStreamHandoff(ctx context.Context, id string) (StreamHandoff, error) {
+	row := q.db.QueryRowContext(ctx, getStreamHandoff, id)
+	var i StreamHandoff
+	err := row.Scan(
+		&i.ID,
+		&i.StreamId,
+		&i.FromWorker,
+		&i.ToWorker,
+		&i.Status,
+		&i.ResumeSeq,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
 const getStreamMeta = `-- name: GetStreamMeta :one
 
-SELECT id, "streamId", "totalDuration", "segmentCount", "lastSegmentSeq", "segmentDuration", timescale, "videoRepId", "audioRepId", "basePath", "createdAt", "updatedAt" FROM "StreamMeta"
+SELECT id, "streamId", "totalDuration", "segmentCount", "lastSegmentSeq", "segmentDuration", timescale, "videoRepId", "audioRepId", "basePath", "vodManifestPath", renditions, "createdAt", "updatedAt" FROM "StreamMeta"
 WHERE "streamId" = $1
 `
 
@@ -404,12 +657,52 @@ func (q *Queries) GetStreamMeta(ctx context.Context, streamid string) (StreamMet
 		&i.VideoRepId,
 		&i.AudioRepId,
 		&i.BasePath,
+		&i.VodManifestPath,
+		&i.Renditions,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
 	return i, err
 }
 
+const listExpiredStreamLeases = `-- name: ListExpiredStreamLeases :many
+SELECT "streamId", "ownerId", command, "acquiredAt", "heartbeatAt", "expiresAt"
+FROM "StreamLease"
+WHERE "expiresAt" < now()
+ORDER BY "expiresAt"
+LIMIT $1
+`
+
+func (q *Queries) ListExpiredStreamLeases(ctx context.Context, limit int32) ([]StreamLease, error) {
+	rows, err := q.db.QueryContext(ctx, listExpiredStreamLeases, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []StreamLease
+	for rows.Next() {
+		var i StreamLease
+		if err := rows.Scan(
+			&i.StreamId,
+			&i.OwnerId,
+			&i.Command,
+			&i.AcquiredAt,
+			&i.HeartbeatAt,
+			&i.ExpiresAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listLiveStreams = `-- name: ListLiveStreams :many
 SELECT id, "userId", title, description, "isLive", visibility, "startedAt", "endedAt", "createdAt", "updatedAt"
 FROM "Stream"
@@ -452,11 +745,151 @@ func (q *Queries) ListLiveStreams(ctx context.Context, limit int32) ([]Stream, e
 	return items, nil
 }
 
+const listStreamLeasesByOwner = `-- name: ListStreamLeasesByOwner :many
+SELECT "streamId", "ownerId", command, "acquiredAt", "heartbeatAt", "expiresAt"
+FROM "StreamLease"
+WHERE "ownerId" = $1
+ORDER BY "acquiredAt"
+`
+
+func (q *Queries) ListStreamLeasesByOwner(ctx context.Context, ownerid string) ([]StreamLease, error) {
+	rows, err := q.db.QueryContext(ctx, listStreamLeasesByOwner, ownerid)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []StreamLease
+	for rows.Next() {
+		var i StreamLease
+		if err := rows.Scan(
+			&i.StreamId,
+			&i.OwnerId,
+			&i.Command,
+			&i.AcquiredAt,
+			&i.HeartbeatAt,
+			&i.ExpiresAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const markOutboxEventFailed = `-- name: MarkOutboxEventFailed :exec
+UPDATE "Outbox"
+SET attempts = attempts + 1,
+    "lastError" = $2
+WHERE id = $1
+`
+
+type MarkOutboxEventFailedParams struct {
+	ID        string
+	LastError sql.NullString
+}
+
+func (q *Queries) MarkOutboxEventFailed(ctx context.Context, arg MarkOutboxEventFailedParams) error {
+	_, err := q.db.ExecContext(ctx, markOutboxEventFailed, arg.ID, arg.LastError)
+	return err
+}
+
+const markOutboxEventSent = `-- name: MarkOutboxEventSent :exec
+UPDATE "Outbox"
+SET "sentAt" = now(),
+    attempts = attempts + 1
+WHERE id = $1
+`
+
+func (q *Queries) MarkOutboxEventSent(ctx context.Context, id string) error {
+	_, err := q.db.ExecContext(ctx, markOutboxEventSent, id)
+	return err
+}
+
+const markStreamHandoffReady = `-- name: MarkStreamHandoffReady :execrows
+UPDATE "StreamHandoff"
+SET status = 'READY',
+    "toWorker" = $2,
+    "updatedAt" = now()
+WHERE id = $1
+  AND status = 'REQUESTED'
+`
+
+type MarkStreamHandoffReadyParams struct {
+	ID       string
+	ToWorker sql.NullString
+}
+
+func (q *Queries) MarkStreamHandoffReady(ctx context.Context, arg MarkStreamHandoffReadyParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, markStreamHandoffReady, arg.ID, arg.ToWorker)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const releaseStreamHandoff = `-- name: ReleaseStreamHandoff :exec
+UPDATE "StreamHandoff"
+SET status = 'RELEASED',
+    "resumeSeq" = $2,
+    "updatedAt" = now()
+WHERE id = $1
+`
+
+type ReleaseStreamHandoffParams struct {
+	ID        string
+	ResumeSeq sql.NullInt32
+}
+
+func (q *Queries) ReleaseStreamHandoff(ctx context.Context, arg ReleaseStreamHandoffParams) error {
+	_, err := q.db.ExecContext(ctx, releaseStreamHandoff, arg.ID, arg.ResumeSeq)
+	return err
+}
+
+const releaseStreamLease = `-- name: ReleaseStreamLease :exec
+DELETE FROM "StreamLease"
+WHERE "streamId" = $1
+  AND "ownerId" = $2
+`
+
+type ReleaseStreamLeaseParams struct {
+	StreamId string
+	OwnerId  string
+}
+
+func (q *Queries) ReleaseStreamLease(ctx context.Context, arg ReleaseStreamLeaseParams) error {
+	_, err := q.db.ExecContext(ctx, releaseStreamLease, arg.StreamId, arg.OwnerId)
+	return err
+}
+
+const setStreamMetaVodManifest = `-- name: SetStreamMetaVodManifest :exec
+UPDATE "StreamMeta"
+SET "vodManifestPath" = $2,
+    "updatedAt" = now()
+WHERE "streamId" = $1
+`
+
+type SetStreamMetaVodManifestParams struct {
+	StreamId        string
+	VodManifestPath sql.NullString
+}
+
+func (q *Queries) SetStreamMetaVodManifest(ctx context.Context, arg SetStreamMetaVodManifestParams) error {
+	_, err := q.db.ExecContext(ctx, setStreamMetaVodManifest, arg.StreamId, arg.VodManifestPath)
+	return err
+}
+
 const setStreamStarted = `-- name: SetStreamStarted :exec
 UPDATE "Stream"
 SET "startedAt" = now(),
     "updatedAt" = now()
 WHERE id = $1
+  AND "startedAt" IS NULL
 `
 
 func (q *Queries) SetStreamStarted(ctx context.Context, id string) error {
@@ -475,6 +908,40 @@ func (q *Queries) TouchStreamKey(ctx context.Context, streamid string) error {
 	return err
 }
 
+const transferStreamLease = `-- name: TransferStreamLease :execrows
+UPDATE "StreamLease"
+SET "ownerId" = $3,
+    "acquiredAt" = now(),
+    "heartbeatAt" = now()
+WHERE "streamId" = $1
+  AND "ownerId" = $2
+`
+
+type TransferStreamLeaseParams struct {
+	StreamId  string
+	OwnerId   string
+	OwnerId_2 string
+}
+
+func (q *Queries) TransferStreamLease(ctx context.Context, arg TransferStreamLeaseParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, transferStreamLease, arg.StreamId, arg.OwnerId, arg.OwnerId_2)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const tryLockOutboxRelay = `-- name: TryLockOutboxRelay :one
+SELECT pg_try_advisory_xact_lock(hashtext('Outbox')::bigint) AS locked
+`
+
+func (q *Queries) TryLockOutboxRelay(ctx context.Context) (bool, error) {
+	row := q.db.QueryRowContext(ctx, tryLockOutboxRelay)
+	var locked bool
+	err := row.Scan(&locked)
+	return locked, err
+}
+
 const updateStreamInfo = `-- name: UpdateStreamInfo :exec
 UPDATE "Stream"
 SET title = $2,
@@ -571,6 +1038,7 @@ SET "totalDuration" = $2,
     "videoRepId" = $7,
     "audioRepId" = $8,
     "basePath" = $9,
+    "renditions" = $10,
     "updatedAt" = now()
 WHERE "streamId" = $1
 `
@@ -585,6 +1053,7 @@ type UpdateStreamMetaWithSegmentsParams struct {
 	VideoRepId      string
 	AudioRepId      string
 	BasePath        sql.NullString
+	Renditions      json.RawMessage
 }
 
 // ============================================
@@ -601,6 +1070,7 @@ func (q *Queries) UpdateStreamMetaWithSegments(ctx context.Context, arg UpdateSt
 		arg.VideoRepId,
 		arg.AudioRepId,
 		arg.BasePath,
+		arg.Renditions,
 	)
 	return err
 }
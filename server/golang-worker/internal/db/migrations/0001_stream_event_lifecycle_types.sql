@@ -0,0 +1,10 @@
+-- Worker lifecycle event types, so failures can be counted per stream.
+-- ALTER TYPE ... ADD VALUE cannot run inside a transaction block before
+-- PostgreSQL 12; run this file with autocommit.
+
+ALTER TYPE "StreamEventType" ADD VALUE IF NOT EXISTS 'TRANSCODE_CRASH';
+ALTER TYPE "StreamEventType" ADD VALUE IF NOT EXISTS 'UPLOAD_FAILED';
+ALTER TYPE "StreamEventType" ADD VALUE IF NOT EXISTS 'RETRY_SCHEDULED';
+ALTER TYPE "StreamEventType" ADD VALUE IF NOT EXISTS 'RETRY_EXHAUSTED';
+ALTER TYPE "StreamEventType" ADD VALUE IF NOT EXISTS 'STOP_STAGE';
+ALTER TYPE "StreamEventType" ADD VALUE IF NOT EXISTS 'FINALIZED';
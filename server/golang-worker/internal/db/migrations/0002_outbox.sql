@@ -0,0 +1,18 @@
+-- Outbox for events the worker publishes to Kafka. Rows are written in the
+-- same transaction as the state change they announce and relayed afterwards.
+
+CREATE TABLE IF NOT EXISTS "Outbox" (
+  id TEXT PRIMARY KEY,
+
+  topic TEXT NOT NULL,
+  key TEXT NOT NULL,
+  payload JSONB NOT NULL,
+
+  attempts INTEGER NOT NULL DEFAULT 0,
+  "lastError" TEXT,
+
+  "createdAt" TIMESTAMPTZ NOT NULL DEFAULT now(),
+  "sentAt" TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS "Outbox_sentAt_createdAt_idx" ON "Outbox"("sentAt", "createdAt");
@@ -0,0 +1,15 @@
+-- Kafka events the worker has applied, so a redelivered message is
+-- recognised. Rows older than the dedupe window are deleted by the worker.
+
+CREATE TABLE IF NOT EXISTS "ProcessedEvent" (
+  "eventId" TEXT NOT NULL,
+  "retryCount" INTEGER NOT NULL DEFAULT 0,
+  "streamId" TEXT NOT NULL,
+  action TEXT NOT NULL,
+
+  "processedAt" TIMESTAMPTZ NOT NULL DEFAULT now(),
+
+  CONSTRAINT "ProcessedEvent_pkey" PRIMARY KEY ("eventId", "retryCount")
+);
+
+CREATE INDEX IF NOT EXISTS "ProcessedEvent_processedAt_idx" ON "ProcessedEvent"("processedAt");
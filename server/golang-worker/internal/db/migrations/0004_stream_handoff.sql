@@ -0,0 +1,18 @@
+-- Handoffs of live streams between workers. A worker that shuts down asks
+-- another one to take each stream over; the row is how the two agree on
+-- when the old one stops and which segment the new one continues from.
+
+CREATE TABLE IF NOT EXISTS "StreamHandoff" (
+  id TEXT PRIMARY KEY,
+  "streamId" TEXT NOT NULL,
+
+  "fromWorker" TEXT NOT NULL,
+  "toWorker" TEXT,
+  status TEXT NOT NULL DEFAULT 'REQUESTED',
+  "resumeSeq" INTEGER,
+
+  "createdAt" TIMESTAMPTZ NOT NULL DEFAULT now(),
+  "updatedAt" TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS "StreamHandoff_streamId_idx" ON "StreamHandoff"("streamId");
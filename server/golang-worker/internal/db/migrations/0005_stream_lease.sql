@@ -0,0 +1,15 @@
+-- Which worker runs each stream. A lease is renewed while the worker's
+-- FFmpeg runs; once it expires another worker may take the stream, resuming
+-- it from the command stored with the lease.
+
+CREATE TABLE IF NOT EXISTS "StreamLease" (
+  "streamId" TEXT PRIMARY KEY,
+  "ownerId" TEXT NOT NULL,
+  command JSONB NOT NULL,
+
+  "acquiredAt" TIMESTAMPTZ NOT NULL DEFAULT now(),
+  "heartbeatAt" TIMESTAMPTZ NOT NULL DEFAULT now(),
+  "expiresAt" TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS "StreamLease_expiresAt_idx" ON "StreamLease"("expiresAt");
@@ -0,0 +1,4 @@
+-- Path of the static VOD manifest the worker writes when a stream is
+-- finalized; NULL for streams finalized before it existed.
+
+ALTER TABLE "StreamMeta" ADD COLUMN IF NOT EXISTS "vodManifestPath" TEXT;
@@ -0,0 +1,5 @@
+-- Handoff event types, so a stream handed between workers is not recorded
+-- as an ordinary stop and start. Like 0001, run this file with autocommit.
+
+ALTER TYPE "StreamEventType" ADD VALUE IF NOT EXISTS 'HANDED_OVER';
+ALTER TYPE "StreamEventType" ADD VALUE IF NOT EXISTS 'TAKEN_OVER';
@@ -0,0 +1,4 @@
+-- Representations the worker encodes a stream into, so a manifest can be
+-- built for streams finalized without a VOD manifest; NULL for older ones.
+
+ALTER TABLE "StreamMeta" ADD COLUMN IF NOT EXISTS "renditions" JSONB;
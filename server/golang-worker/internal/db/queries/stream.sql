@@ -20,7 +20,8 @@ WHERE id = $1;
 UPDATE "Stream"
 SET "startedAt" = now(),
     "updatedAt" = now()
-WHERE id = $1;
+WHERE id = $1
+  AND "startedAt" IS NULL;
 
 -- name: UpdateStreamInfo :exec
 UPDATE "Stream"
@@ -76,6 +77,12 @@ SET "totalDuration" = $2,
     "updatedAt" = now()
 WHERE "streamId" = $1;
 
+-- name: SetStreamMetaVodManifest :exec
+UPDATE "StreamMeta"
+SET "vodManifestPath" = $2,
+    "updatedAt" = now()
+WHERE "streamId" = $1;
+
 -- name: ExistStreamMeta :one
 SELECT EXISTS (
   SELECT 1
@@ -139,6 +146,156 @@ FROM "Recording"
 WHERE "streamId" = $1
 ORDER BY "createdAt" DESC;
 
+-- =========================
+-- OUTBOX
+-- =========================
+
+-- name: CreateOutboxEvent :exec
+INSERT INTO "Outbox" (
+  id, topic, key, payload
+) VALUES (
+  $1, $2, $3, $4
+);
+
+-- name: TryLockOutboxRelay :one
+SELECT pg_try_advisory_xact_lock(hashtext('Outbox')::bigint) AS locked;
+
+-- name: ClaimOutboxEvents :many
+SELECT *
+FROM "Outbox"
+WHERE "sentAt" IS NULL
+  AND attempts < $1
+ORDER BY "createdAt", id
+LIMIT $2
+FOR UPDATE;
+
+-- name: MarkOutboxEventSent :exec
+UPDATE "Outbox"
+SET "sentAt" = now(),
+    attempts = attempts + 1
+WHERE id = $1;
+
+-- name: MarkOutboxEventFailed :exec
+UPDATE "Outbox"
+SET attempts = attempts + 1,
+    "lastError" = $2
+WHERE id = $1;
+
+-- name: DeleteSentOutboxEvents :exec
+DELETE FROM "Outbox"
+WHERE "sentAt" IS NOT NULL
+  AND "sentAt" < $1;
+
+-- =========================
+-- PROCESSED EVENT
+-- =========================
+
+-- name: CreateProcessedEvent :exec
+INSERT INTO "ProcessedEvent" (
+  "eventId", "retryCount", "streamId", action
+) VALUES (
+  $1, $2, $3, $4
+)
+ON CONFLICT ("eventId", "retryCount") DO NOTHING;
+
+-- name: ExistProcessedEvent :one
+SELECT EXISTS (
+  SELECT 1
+  FROM "ProcessedEvent"
+  WHERE "eventId" = $1
+    AND "retryCount" = $2
+);
+
+-- name: DeleteProcessedEventsBefore :exec
+DELETE FROM "ProcessedEvent"
+WHERE "processedAt" < $1;
+
+-- =========================
+-- STREAM HANDOFF
+-- =========================
+
+-- name: CreateStreamHandoff :exec
+INSERT INTO "StreamHandoff" (
+  id, "streamId", "fromWorker"
+) VALUES (
+  $1, $2, $3
+);
+
+-- name: GetStreamHandoff :one
+SELECT *
+FROM "StreamHandoff"
+WHERE id = $1;
+
+-- name: MarkStreamHandoffReady :execrows
+UPDATE "StreamHandoff"
+SET status = 'READY',
+    "toWorker" = $2,
+    "updatedAt" = now()
+WHERE id = $1
+  AND status = 'REQUESTED';
+
+-- name: AbortStreamHandoff :execrows
+UPDATE "StreamHandoff"
+SET status = 'ABORTED',
+    "updatedAt" = now()
+WHERE id = $1
+  AND status = 'REQUESTED';
+
+-- name: ReleaseStreamHandoff :exec
+UPDATE "StreamHandoff"
+SET status = 'RELEASED',
+    "resumeSeq" = $2,
+    "updatedAt" = now()
+WHERE id = $1;
+
+-- =========================
+-- STREAM LEASE
+-- =========================
+
+-- name: AcquireStreamLease :execrows
+INSERT INTO "StreamLease" (
+  "streamId", "ownerId", command, "expiresAt"
+) VALUES (
+  $1, $2, $3, $4
+)
+ON CONFLICT ("streamId") DO UPDATE
+SET "ownerId" = EXCLUDED."ownerId",
+    command = EXCLUDED.command,
+    "acquiredAt" = CASE
+      WHEN "StreamLease"."ownerId" = EXCLUDED."ownerId" THEN "StreamLease"."acquiredAt"
+      ELSE now()
+    END,
+    "heartbeatAt" = now(),
+    "expiresAt" = EXCLUDED."expiresAt"
+WHERE "StreamLease"."ownerId" = EXCLUDED."ownerId"
+   OR "StreamLease"."expiresAt" < now();
+
+-- name: ReleaseStreamLease :exec
+DELETE FROM "StreamLease"
+WHERE "streamId" = $1
+  AND "ownerId" = $2;
+
+-- name: TransferStreamLease :execrows
+UPDATE "StreamLease"
+SET "ownerId" = $3,
+    "acquiredAt" = now(),
+    "heartbeatAt" = now()
+WHERE "streamId" = $1
+  AND "ownerId" = $2;
+
+-- name: ListExpiredStreamLeases :many
+SELECT *
+FROM "StreamLease"
+WHERE "expiresAt" < now()
+ORDER BY "expiresAt"
+LIMIT $1;
+
+-- name: ListStreamLeasesByOwner :many
+SELECT *
+FROM "StreamLease"
+WHERE "ownerId" = $1
+ORDER BY "acquiredAt";
+
 -- ============================================
 -- META QUERIES
 -- ============================================
@@ -153,6 +310,7 @@ SET "totalDuration" = $2,
     "videoRepId" = $7,
     "audioRepId" = $8,
     "basePath" = $9,
+    "renditions" = $10,
     "updatedAt" = now()
 WHERE "streamId" = $1;
 
@@ -4,10 +4,18 @@ CREATE TYPE stream_visibility AS ENUM (
   'UNLISTED'
 );
 
-CREATE TYPE stream_event_type AS ENUM (
+CREATE TYPE "StreamEventType" AS ENUM (
   'STREAM_START',
   'STREAM_STOP',
   'STREAM_CONNECT',
   'STREAM_DISCONNECT',
-  'INVALID_KEY'
+  'INVALID_KEY',
+  'TRANSCODE_CRASH',
+  'UPLOAD_FAILED',
+  'RETRY_SCHEDULED',
+  'RETRY_EXHAUSTED',
+  'STOP_STAGE',
+  'FINALIZED',
+  'HANDED_OVER',
+  'TAKEN_OVER'
 );
\ No newline at end of file
@@ -39,6 +39,8 @@ CREATE TABLE IF NOT EXISTS "StreamMeta" (
   "videoRepId"      TEXT NOT NULL DEFAULT '0',
   "audioRepId"      TEXT NOT NULL DEFAULT '1',
   "basePath"        TEXT,
+  "vodManifestPath" TEXT,
+  "renditions"      JSONB,
 
   "createdAt" TIMESTAMPTZ NOT NULL DEFAULT now(),
   "updatedAt" TIMESTAMPTZ NOT NULL DEFAULT now(),
@@ -116,4 +118,72 @@ CREATE TABLE IF NOT EXISTS "Recording" (
 );
 
 CREATE INDEX "idx_Recording_streamId" ON "Recording"("streamId");
-CREATE INDEX "idx_Recording_createdAt" ON "Recording"("createdAt");
\ No newline at end of file
+CREATE INDEX "idx_Recording_createdAt" ON "Recording"("createdAt");
+
+-- =========================
+-- OUTBOX
+-- =========================
+CREATE TABLE IF NOT EXISTS "Outbox" (
+  id TEXT PRIMARY KEY,
+
+  topic TEXT NOT NULL,
+  key TEXT NOT NULL,
+  payload JSONB NOT NULL,
+
+  attempts INTEGER NOT NULL DEFAULT 0,
+  "lastError" TEXT,
+
+  "createdAt" TIMESTAMPTZ NOT NULL DEFAULT now(),
+  "sentAt" TIMESTAMPTZ
+);
+
+CREATE INDEX "idx_Outbox_sentAt_createdAt" ON "Outbox"("sentAt", "createdAt");
+
+-- =========================
+-- PROCESSED EVENT
+-- =========================
+CREATE TABLE IF NOT EXISTS "ProcessedEvent" (
+  "eventId" TEXT NOT NULL,
+  "retryCount" INTEGER NOT NULL DEFAULT 0,
+  "streamId" TEXT NOT NULL,
+  action TEXT NOT NULL,
+
+  "processedAt" TIMESTAMPTZ NOT NULL DEFAULT now(),
+
+  PRIMARY KEY ("eventId", "retryCount")
+);
+
+CREATE INDEX "idx_ProcessedEvent_processedAt" ON "ProcessedEvent"("processedAt");
+
+-- =========================
+-- STREAM HANDOFF
+-- =========================
+CREATE TABLE IF NOT EXISTS "StreamHandoff" (
+  id TEXT PRIMARY KEY,
+  "streamId" TEXT NOT NULL,
+
+  "fromWorker" TEXT NOT NULL,
+  "toWorker" TEXT,
+  status TEXT NOT NULL DEFAULT 'REQUESTED',
+  "resumeSeq" INTEGER,
+
+  "createdAt" TIMESTAMPTZ NOT NULL DEFAULT now(),
+  "updatedAt" TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX "idx_StreamHandoff_streamId" ON "StreamHandoff"("streamId");
+
+-- =========================
+-- STREAM LEASE
+-- =========================
+CREATE TABLE IF NOT EXISTS "StreamLease" (
+  "streamId" TEXT PRIMARY KEY,
+  "ownerId" TEXT NOT NULL,
+  command JSONB NOT NULL,
+
+  "acquiredAt" TIMESTAMPTZ NOT NULL DEFAULT now(),
+  "heartbeatAt" TIMESTAMPTZ NOT NULL DEFAULT now(),
+  "expiresAt" TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX "idx_StreamLease_expiresAt" ON "StreamLease"("expiresAt");
\ No newline at end of file
@@ -4,11 +4,13 @@ import (
 	"database/sql"
 
 	"github.com/bitstream/backend-go/internal/config"
+	"github.com/bitstream/backend-go/internal/kafka/producer"
 	"github.com/bitstream/backend-go/internal/storage/minio"
 )
 
 type Deps struct {
-	DB      *sql.DB
-	Config  *config.AppConfig
-	Storage *minio.Service
+	DB       *sql.DB
+	Config   *config.AppConfig
+	Storage  *minio.Service
+	Producer *producer.Producer
 }
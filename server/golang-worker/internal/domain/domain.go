@@ -1,6 +1,8 @@
 package domain
 
 import (
+	"context"
+
 	"github.com/bitstream/backend-go/internal/deps"
 	"github.com/bitstream/backend-go/internal/domain/streaming"
 )
@@ -9,6 +11,6 @@ func RegisterAll(d *deps.Deps) {
 	streaming.Register(d)
 }
 
-func Shutdown() {
-	streaming.Shutdown()
+func Shutdown(ctx context.Context) {
+	streaming.Shutdown(ctx)
 }
@@ -0,0 +1,85 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	stream "github.com/bitstream/backend-go/internal/db/generated"
+	"github.com/bitstream/backend-go/pkg/id"
+	"github.com/sqlc-dev/pqtype"
+)
+
+const writeTimeout = 5 * time.Second
+
+// Event is what happened to a stream inside the worker. It is stored in the
+// payload, next to the coarser StreamEventType of the row.
+type Event string
+
+const (
+	ProcessStarted Event = "process_started"
+	FirstSegment   Event = "first_segment"
+	ProcessCrashed Event = "process_crashed"
+	UploadFailed   Event = "upload_failed"
+	RetryScheduled Event = "retry_scheduled"
+	RetryExhausted Event = "retry_exhausted"
+	StopStage      Event = "stop_stage"
+	Finalized      Event = "finalized"
+	HandedOver     Event = "handed_over"
+	TakenOver      Event = "taken_over"
+)
+
+// eventTypes files each worker event under its StreamEventType, so failure
+// rates can be counted per type.
+var eventTypes = map[Event]string{
+	ProcessStarted: "STREAM_START",
+	FirstSegment:   "STREAM_CONNECT",
+	ProcessCrashed: "TRANSCODE_CRASH",
+	UploadFailed:   "UPLOAD_FAILED",
+	RetryScheduled: "RETRY_SCHEDULED",
+	RetryExhausted: "RETRY_EXHAUSTED",
+	StopStage:      "STOP_STAGE",
+	Finalized:      "FINALIZED",
+	HandedOver:     "HANDED_OVER",
+	TakenOver:      "TAKEN_OVER",
+}
+
+type Fields map[string]any
+
+// Recorder writes the lifecycle of streams as StreamEvent rows, so what the
+// worker did can be read back after the fact. Failing to write one is logged
+// and never interrupts the stream.
+type Recorder struct {
+	queries *stream.Queries
+}
+
+func NewRecorder(queries *stream.Queries) *Recorder {
+	return &Recorder{queries: queries}
+}
+
+func (r *Recorder) Record(streamID string, event Event, fields Fields) {
+	payload := Fields{"event": event}
+	for k, v := range fields {
+		payload[k] = v
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		slog.Error("Failed to encode stream event", "streamId", streamID, "event", event, "error", err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), writeTimeout)
+	defer cancel()
+
+	err = r.queries.CreateStreamEvent(ctx, stream.CreateStreamEventParams{
+		ID:       id.New(),
+		StreamId: streamID,
+		Type:     eventTypes[event],
+		Payload:  pqtype.NullRawMessage{RawMessage: data, Valid: true},
+	})
+	if err != nil {
+		slog.Error("Failed to record stream event", "streamId", streamID, "event", event, "error", err)
+	}
+}
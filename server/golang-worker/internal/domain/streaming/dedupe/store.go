@@ -0,0 +1,142 @@
+package dedupe
+
+import (
+	"container/list"
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	stream "github.com/bitstream/backend-go/internal/db/generated"
+)
+
+const (
+	cacheSize = 4096
+
+	// Window is how long an applied event is remembered. A redelivery of
+	// what was not committed is minutes old, but a consumer group reset to
+	// an earlier offset replays days of events.
+	Window = 7 * 24 * time.Hour
+
+	cleanupInterval = time.Hour
+	writeTimeout    = 5 * time.Second
+)
+
+// Key identifies one delivery of an event. A retry reuses the EventID of
+// the event it retries with a higher RetryCount, and is a new delivery.
+type Key struct {
+	EventID    string
+	RetryCount int
+}
+
+// Store remembers which events were applied, in an LRU in front of the
+// ProcessedEvent table. The table keeps it across restarts and between
+// workers of the same group.
+type Store struct {
+	queries *stream.Queries
+
+	mu    sync.Mutex
+	order *list.List
+	seen  map[Key]*list.Element
+}
+
+func NewStore(queries *stream.Queries) *Store {
+	return &Store{
+		queries: queries,
+		order:   list.New(),
+		seen:    make(map[Key]*list.Element),
+	}
+}
+
+// Seen reports whether key was already applied. When the table cannot be
+// reached the event is treated as new: applying a START twice is cheaper
+// than losing one.
+func (s *Store) Seen(key Key) bool {
+	if key.EventID == "" {
+		return false
+	}
+
+	s.mu.Lock()
+	if el, ok := s.seen[key]; ok {
+		s.order.MoveToFront(el)
+		s.mu.Unlock()
+		return true
+	}
+	s.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), writeTimeout)
+	defer cancel()
+
+	exists, err := s.queries.ExistProcessedEvent(ctx, stream.ExistProcessedEventParams{
+		EventId:    key.EventID,
+		RetryCount: int32(key.RetryCount),
+	})
+	if err != nil {
+		slog.Error("Failed to look up processed event", "eventId", key.EventID, "error", err)
+		return false
+	}
+	if exists {
+		s.remember(key)
+	}
+	return exists
+}
+
+// Record remembers key as applied. It is called once the event has been
+// applied, so a worker dying in between leaves the event unrecorded and
+// its redelivery applies it again instead of skipping it.
+func (s *Store) Record(key Key, streamID, action string) {
+	if key.EventID == "" {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), writeTimeout)
+	defer cancel()
+
+	err := s.queries.CreateProcessedEvent(ctx, stream.CreateProcessedEventParams{
+		EventId:    key.EventID,
+		RetryCount: int32(key.RetryCount),
+		StreamId:   streamID,
+		Action:     action,
+	})
+	if err != nil {
+		slog.Error("Failed to record processed event", "eventId", key.EventID, "streamId", streamID, "error", err)
+	}
+
+	s.remember(key)
+}
+
+func (s *Store) remember(key Key) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if el, ok := s.seen[key]; ok {
+		s.order.MoveToFront(el)
+		return
+	}
+
+	s.seen[key] = s.order.PushFront(key)
+	if s.order.Len() > cacheSize {
+		oldest := s.order.Back()
+		s.order.Remove(oldest)
+		delete(s.seen, oldest.Value.(Key))
+	}
+}
+
+// Run deletes events older than the window until quit is closed.
+func (s *Store) Run(quit <-chan struct{}) {
+	ticker := time.NewTicker(cleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-quit:
+			return
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), writeTimeout)
+			if err := s.queries.DeleteProcessedEventsBefore(ctx, time.Now().Add(-Window)); err != nil {
+				slog.Error("Failed to delete processed events", "error", err)
+			}
+			cancel()
+		}
+	}
+}
@@ -2,8 +2,12 @@ package ffmpeg
 
 import (
 	"context"
+	"fmt"
 	"os/exec"
 	"path/filepath"
+	"strings"
+
+	"github.com/bitstream/backend-go/internal/config"
 )
 
 func BuildStreamCommand(
@@ -11,6 +15,7 @@ func BuildStreamCommand(
 	rtmpURL string,
 	streamDir string,
 	env string,
+	layout OutputLayout,
 ) *exec.Cmd {
 
 	baseArgs := []string{
@@ -22,36 +27,43 @@ func BuildStreamCommand(
 	}
 
 	encodingArgs := []string{
-		// Video encoding
+		"-filter_complex", buildLadderFilter(layout.Ladder),
+
+		// Video encoding (shared by every rung)
 		"-c:v", "libx264",
 		"-preset", "veryfast",
 		"-tune", "zerolatency",
-		"-profile:v", "main",
-		"-level", "4.0",
 
 		"-g", "60",
 		"-keyint_min", "60",
 		"-sc_threshold", "0",
 		"-force_key_frames", "expr:gte(t,n_forced*2)",
-
-		// Bitrate
-		"-b:v", "2500k",
-		"-maxrate", "2800k",
-		"-bufsize", "5000k",
 		"-pix_fmt", "yuv420p",
+	}
+
+	encodingArgs = append(encodingArgs, buildLadderArgs(layout.Ladder)...)
 
-		// Audio encoding
+	encodingArgs = append(encodingArgs,
+		// Audio encoding. The layout always has an audio representation, so a
+		// source without an audio track fails here instead of going unnoticed.
+		"-map", "0:a:0",
 		"-c:a", "aac",
 		"-b:a", "128k",
 		"-ar", "48000",
 		"-ac", "2",
+	)
+
+	fragDuration := "2"
+	if layout.Mode.IsLowLatencyHLS() {
+		// one fragment per LL-HLS part
+		fragDuration = fmt.Sprintf("%.3f", layout.PartTarget.Seconds())
 	}
 
 	dashArgs := []string{
 		"-f", "dash",
 
 		"-seg_duration", "2",
-		"-frag_duration", "2",
+		"-frag_duration", fragDuration,
 		"-min_seg_duration", "2000000",
 
 		"-window_size", "15",
@@ -74,12 +86,68 @@ func BuildStreamCommand(
 
 		"-init_seg_name", "init-$RepresentationID$.mp4",
 		"-media_seg_name", "chunk-$RepresentationID$-$Number$.m4s",
+	}
+
+	if layout.Mode.IsLowLatencyHLS() {
+		dashArgs = append(dashArgs, "-frag_type", "duration")
+	}
 
-		filepath.ToSlash(filepath.Join(streamDir, "manifest.mpd")),
+	if layout.Mode.HasHLS() {
+		// CMAF: the same fMP4 segments are also listed in HLS playlists
+		dashArgs = append(dashArgs,
+			"-dash_segment_type", "mp4",
+			"-hls_playlist", "1",
+			"-hls_master_name", HLSMasterName,
+		)
 	}
 
+	dashArgs = append(dashArgs, filepath.ToSlash(filepath.Join(streamDir, ManifestName)))
+
 	args := append(baseArgs, encodingArgs...)
 	args = append(args, dashArgs...)
 
 	return exec.CommandContext(ctx, "ffmpeg", args...)
 }
+
+// buildLadderFilter splits the decoded input once and scales a copy per rung,
+// so the whole ladder is produced by a single decode.
+func buildLadderFilter(ladder []config.RenditionConfig) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "[0:v]split=%d", len(ladder))
+	for i := range ladder {
+		fmt.Fprintf(&b, "[v%d]", i)
+	}
+
+	for i, r := range ladder {
+		width := r.Width
+		if width <= 0 {
+			width = -2
+		}
+		fmt.Fprintf(&b, ";[v%d]scale=w=%d:h=%d[v%dout]", i, width, r.Height, i)
+	}
+
+	return b.String()
+}
+
+func buildLadderArgs(ladder []config.RenditionConfig) []string {
+	var args []string
+
+	for i, r := range ladder {
+		args = append(args,
+			"-map", fmt.Sprintf("[v%dout]", i),
+			fmt.Sprintf("-b:v:%d", i), r.VideoBitrate,
+			fmt.Sprintf("-maxrate:v:%d", i), r.MaxRate,
+			fmt.Sprintf("-bufsize:v:%d", i), r.BufSize,
+		)
+
+		if r.Profile != "" {
+			args = append(args, fmt.Sprintf("-profile:v:%d", i), r.Profile)
+		}
+		if r.Level != "" {
+			args = append(args, fmt.Sprintf("-level:v:%d", i), r.Level)
+		}
+	}
+
+	return args
+}
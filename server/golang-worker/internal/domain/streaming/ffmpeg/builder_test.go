@@ -0,0 +1,46 @@
+package ffmpeg
+
+import (
+	"testing"
+
+	"github.com/bitstream/backend-go/internal/config"
+)
+
+func TestBuildLadderFilter(t *testing.T) {
+	tests := []struct {
+		name   string
+		ladder []config.RenditionConfig
+		want   string
+	}{
+		{
+			name:   "single rung",
+			ladder: []config.RenditionConfig{{Width: 1280, Height: 720}},
+			want:   "[0:v]split=1[v0];[v0]scale=w=1280:h=720[v0out]",
+		},
+		{
+			name: "full ladder",
+			ladder: []config.RenditionConfig{
+				{Width: 1920, Height: 1080},
+				{Width: 1280, Height: 720},
+				{Width: 854, Height: 480},
+			},
+			want: "[0:v]split=3[v0][v1][v2]" +
+				";[v0]scale=w=1920:h=1080[v0out]" +
+				";[v1]scale=w=1280:h=720[v1out]" +
+				";[v2]scale=w=854:h=480[v2out]",
+		},
+		{
+			name:   "width follows the aspect ratio when unset",
+			ladder: []config.RenditionConfig{{Height: 360}},
+			want:   "[0:v]split=1[v0];[v0]scale=w=-2:h=360[v0out]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := buildLadderFilter(tt.ladder); got != tt.want {
+				t.Errorf("buildLadderFilter() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package ffmpeg
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+
+	"github.com/bitstream/backend-go/pkg/fmp4"
+)
+
+// referenceRep is the representation whose segments define the stream's
+// timeline. Every rung is cut at the same keyframes, so one is enough.
+func (st *SegmentTracker) referenceRep() string {
+	if len(st.layout.VideoRepIDs) == 0 {
+		return "0"
+	}
+	return st.layout.VideoRepIDs[0]
+}
+
+// measureSegment records how long a landed segment of the reference
+// representation really is, from the tfdt and trun boxes of its fragments.
+// It runs before the local file can be cleaned up, which waits on the
+// watermark this segment is part of.
+func (st *SegmentTracker) measureSegment(repId string, seq int) {
+	if repId != st.referenceRep() {
+		return
+	}
+
+	timescale, ok := st.timescaleOf(repId)
+	if !ok {
+		return
+	}
+
+	path := filepath.Join(st.streamDir, fmt.Sprintf("chunk-%s-%d.m4s", repId, seq))
+	data, err := os.ReadFile(path)
+	if err != nil {
+		slog.Warn("Failed to read segment for duration", "file", path, "error", err)
+		return
+	}
+
+	_, duration, err := fmp4.SegmentSpan(data)
+	if err != nil {
+		slog.Warn("Failed to parse segment duration", "file", path, "error", err)
+		return
+	}
+
+	st.mu.Lock()
+	st.segDurations[seq] = float64(duration) / float64(timescale)
+	st.mu.Unlock()
+}
+
+func (st *SegmentTracker) timescaleOf(repId string) (uint32, bool) {
+	if timescale, ok := st.timescales[repId]; ok {
+		return timescale, true
+	}
+
+	path := filepath.Join(st.streamDir, fmt.Sprintf("init-%s.mp4", repId))
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, false
+	}
+
+	timescale, err := fmp4.ParseTimescale(data)
+	if err != nil || timescale == 0 {
+		slog.Warn("Failed to parse init segment timescale", "file", path, "error", err)
+		return 0, false
+	}
+
+	st.timescales[repId] = timescale
+	return timescale, true
+}
+
+// mediaTotalsLocked returns how many segments are complete and their
+// summed duration in seconds. Segment numbers start at 1, so the complete
+// sequence is also the count. A segment that could not be measured counts
+// with the nominal duration. A taken-over stream adds what was stored
+// before it.
+func (st *SegmentTracker) mediaTotalsLocked() (count int, total float64) {
+	own := max(st.lastSegmentSeq, 0)
+	for seq := 1; seq <= own; seq++ {
+		if duration, ok := st.segDurations[seq]; ok {
+			total += duration
+		} else {
+			total += st.segmentDuration
+		}
+	}
+	return own + st.seqOffset, total + st.baseDuration
+}
@@ -0,0 +1,161 @@
+package ffmpeg
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bitstream/backend-go/internal/config"
+	"github.com/bitstream/backend-go/internal/domain/streaming/llhls"
+)
+
+const audioBitrate = 128_000
+
+var DefaultLadder = []config.RenditionConfig{
+	{Name: "1080p", Width: 1920, Height: 1080, VideoBitrate: "5000k", MaxRate: "5350k", BufSize: "7500k", Profile: "high", Level: "4.2"},
+	{Name: "720p", Width: 1280, Height: 720, VideoBitrate: "2500k", MaxRate: "2800k", BufSize: "5000k", Profile: "main", Level: "4.0"},
+	{Name: "480p", Width: 854, Height: 480, VideoBitrate: "1200k", MaxRate: "1300k", BufSize: "2400k", Profile: "main", Level: "3.1"},
+	{Name: "360p", Width: 640, Height: 360, VideoBitrate: "700k", MaxRate: "750k", BufSize: "1400k", Profile: "baseline", Level: "3.0"},
+}
+
+// OutputLayout maps the renditions of one FFmpeg invocation to the DASH
+// Representation IDs the muxer assigns them. Video rungs are mapped first, in
+// ladder order, so rung i becomes Representation i and audio comes last.
+type OutputLayout struct {
+	Mode        OutputMode
+	PartTarget  time.Duration
+	Ladder      []config.RenditionConfig
+	VideoRepIDs []string
+	AudioRepID  string
+}
+
+func ResolveLadder(cfg config.FFmpegConfig) []config.RenditionConfig {
+	if len(cfg.Renditions) == 0 {
+		return DefaultLadder
+	}
+	return cfg.Renditions
+}
+
+func NewOutputLayout(ladder []config.RenditionConfig, mode OutputMode) OutputLayout {
+	videoRepIDs := make([]string, len(ladder))
+	for i := range ladder {
+		videoRepIDs[i] = strconv.Itoa(i)
+	}
+
+	return OutputLayout{
+		Mode:        mode,
+		Ladder:      ladder,
+		VideoRepIDs: videoRepIDs,
+		AudioRepID:  strconv.Itoa(len(ladder)),
+	}
+}
+
+func (l OutputLayout) RepIDs() []string {
+	return append(append([]string{}, l.VideoRepIDs...), l.AudioRepID)
+}
+
+// JoinedVideoRepIDs is the StreamMeta.videoRepId encoding of the ladder.
+func (l OutputLayout) JoinedVideoRepIDs() string {
+	return strings.Join(l.VideoRepIDs, ",")
+}
+
+// LLHLSRenditions describes the ladder to the LL-HLS writer.
+func (l OutputLayout) LLHLSRenditions() []llhls.Rendition {
+	renditions := make([]llhls.Rendition, 0, len(l.Ladder)+1)
+	for i, r := range l.Ladder {
+		renditions = append(renditions, llhls.Rendition{
+			RepID:     l.VideoRepIDs[i],
+			Bandwidth: parseBitrate(r.MaxRate) + audioBitrate,
+			Width:     r.Width,
+			Height:    r.Height,
+		})
+	}
+	return append(renditions, llhls.Rendition{
+		RepID:     l.AudioRepID,
+		Bandwidth: audioBitrate,
+		Audio:     true,
+	})
+}
+
+// MetaRendition is one representation as stored in StreamMeta.renditions, so
+// a manifest can be built for the stream without its MPD.
+type MetaRendition struct {
+	RepID       string `json:"repId"`
+	ContentType string `json:"contentType"`
+	Bandwidth   int    `json:"bandwidth"`
+	Width       int    `json:"width,omitempty"`
+	Height      int    `json:"height,omitempty"`
+	Codecs      string `json:"codecs"`
+}
+
+// MetaRenditions describes the layout's representations, audio last.
+func (l OutputLayout) MetaRenditions() []MetaRendition {
+	renditions := make([]MetaRendition, 0, len(l.Ladder)+1)
+	for i, r := range l.Ladder {
+		bandwidth := parseBitrate(r.MaxRate)
+		if bandwidth == 0 {
+			bandwidth = parseBitrate(r.VideoBitrate)
+		}
+		renditions = append(renditions, MetaRendition{
+			RepID:       l.VideoRepIDs[i],
+			ContentType: "video",
+			Bandwidth:   bandwidth,
+			Width:       max(r.Width, 0), // scaled to the aspect ratio otherwise
+			Height:      r.Height,
+			Codecs:      avcCodec(r.Profile, r.Level),
+		})
+	}
+	return append(renditions, MetaRendition{
+		RepID:       l.AudioRepID,
+		ContentType: "audio",
+		Bandwidth:   audioBitrate,
+		Codecs:      "mp4a.40.2",
+	})
+}
+
+// avcCodec is the RFC 6381 codecs string of an x264 profile and level, such
+// as "avc1.640028" for high at 4.0.
+func avcCodec(profile, level string) string {
+	profileIdc := "6400"
+	switch profile {
+	case "main":
+		profileIdc = "4d40"
+	case "baseline":
+		profileIdc = "42e0"
+	}
+
+	levelIdc := 40
+	if v, err := strconv.ParseFloat(level, 64); err == nil && v > 0 {
+		levelIdc = int(math.Round(v * 10))
+	}
+	return fmt.Sprintf("avc1.%s%02x", profileIdc, levelIdc)
+}
+
+func (l OutputLayout) IsVideoRep(repID string) bool {
+	for _, id := range l.VideoRepIDs {
+		if id == repID {
+			return true
+		}
+	}
+	return false
+}
+
+// parseBitrate converts FFmpeg bitrate strings such as "2500k" or "2.5M" to
+// bits/s.
+func parseBitrate(raw string) int {
+	multiplier := 1.0
+	switch {
+	case strings.HasSuffix(raw, "k"), strings.HasSuffix(raw, "K"):
+		multiplier = 1_000
+	case strings.HasSuffix(raw, "M"):
+		multiplier = 1_000_000
+	}
+
+	value, err := strconv.ParseFloat(strings.TrimRight(raw, "kKM"), 64)
+	if err != nil || value < 0 || math.IsNaN(value) || math.IsInf(value, 0) {
+		return 0
+	}
+	return int(math.Round(value * multiplier))
+}
@@ -0,0 +1,54 @@
+package ffmpeg
+
+import (
+	"testing"
+
+	"github.com/bitstream/backend-go/internal/config"
+)
+
+func TestParseBitrate(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want int
+	}{
+		{raw: "2500k", want: 2_500_000},
+		{raw: "800K", want: 800_000},
+		{raw: "6M", want: 6_000_000},
+		{raw: "128000", want: 128_000},
+		{raw: "", want: 0},
+		{raw: "fast", want: 0},
+		{raw: "2.5M", want: 2_500_000},
+		{raw: "1.2k", want: 1_200},
+		{raw: "-1M", want: 0},
+		{raw: "InfM", want: 0},
+	}
+
+	for _, tt := range tests {
+		if got := parseBitrate(tt.raw); got != tt.want {
+			t.Errorf("parseBitrate(%q) = %d, want %d", tt.raw, got, tt.want)
+		}
+	}
+}
+
+func TestMetaRenditions(t *testing.T) {
+	layout := NewOutputLayout([]config.RenditionConfig{
+		{Width: 1920, Height: 1080, VideoBitrate: "5000k", MaxRate: "5350k", Profile: "high", Level: "4.2"},
+		{Width: -1, Height: 360, VideoBitrate: "700k", Profile: "baseline", Level: "3.0"},
+	}, OutputModeDASH)
+
+	want := []MetaRendition{
+		{RepID: "0", ContentType: "video", Bandwidth: 5_350_000, Width: 1920, Height: 1080, Codecs: "avc1.64002a"},
+		{RepID: "1", ContentType: "video", Bandwidth: 700_000, Height: 360, Codecs: "avc1.42e01e"},
+		{RepID: "2", ContentType: "audio", Bandwidth: audioBitrate, Codecs: "mp4a.40.2"},
+	}
+
+	got := layout.MetaRenditions()
+	if len(got) != len(want) {
+		t.Fatalf("MetaRenditions() = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("MetaRenditions()[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package ffmpeg
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+
+	stream "github.com/bitstream/backend-go/internal/db/generated"
+	"github.com/bitstream/backend-go/internal/domain/streaming/lifecycle"
+)
+
+const (
+	dashManifestMime = "application/dash+xml"
+
+	// manifestSnapshotName is the frozen copy of the manifest being uploaded,
+	// so the muxer can keep rewriting the original meanwhile
+	manifestSnapshotName = ManifestName + ".publish.tmp"
+)
+
+// publishManifest uploads the live manifest to storage once every segment it
+// lists has landed, so players reading it from storage never request a
+// segment that is not there yet. At most one upload runs at a time; a newer
+// manifest seen meanwhile is published when it finishes.
+func (st *SegmentTracker) publishManifest() {
+	data, err := os.ReadFile(filepath.Join(st.streamDir, ManifestName))
+	if err != nil {
+		return
+	}
+	if bytes.Equal(data, st.publishedManifest) {
+		st.manifestPending = false
+		return
+	}
+
+	manifest, err := ParseManifest(data)
+	if err != nil {
+		// caught mid-write by a scan; the next one sees the whole file
+		st.manifestPending = true
+		return
+	}
+	// every version is recorded, even one never published: the live
+	// manifest only lists a sliding window
+	st.recordTimeline(manifest)
+
+	if st.manifestInFlight {
+		st.manifestPending = true
+		return
+	}
+
+	// the muxer's last manifest marks the stream as ended, which viewers
+	// must not see while another worker continues it
+	if st.handingOver.Load() && !manifest.Dynamic {
+		st.manifestPending = false
+		return
+	}
+
+	if !st.manifestLanded(manifest) {
+		st.manifestPending = true
+		return
+	}
+
+	snapshot := filepath.Join(st.streamDir, manifestSnapshotName)
+	if err := os.WriteFile(snapshot, st.renumberManifest(data), 0644); err != nil {
+		slog.Error("Failed to snapshot manifest", "streamId", st.streamID, "error", err)
+		st.manifestPending = true
+		return
+	}
+
+	job := st.newJob(snapshot, dashManifestMime)
+	job.RemotePath = st.remotePath(ManifestName)
+	job.CacheControl = cacheControlLive
+
+	st.manifestInFlight = true
+	st.manifestPending = false
+	st.manifestUploading = data
+	st.enqueue(job)
+}
+
+// manifestLanded reports whether every segment the manifest lists is
+// at or below the contiguous upload watermark of its representation.
+func (st *SegmentTracker) manifestLanded(manifest *LiveManifest) bool {
+	st.mu.RLock()
+	defer st.mu.RUnlock()
+
+	for repId, timeline := range manifest.Timelines {
+		if last := timeline.LastNumber(); last > 0 && st.repSeq[repId] < last {
+			return false
+		}
+	}
+	return true
+}
+
+// markPlayable announces the stream is playable, and stamps it as started
+// unless the API already did at connect, in one transaction. A manifest is
+// only published once the segments it lists have landed, so the first one
+// to land is the moment viewers can start playing. On failure the next
+// published manifest tries again.
+func (st *SegmentTracker) markPlayable() {
+	ctx := context.Background()
+	err := st.notify.Tx(ctx, func(q *stream.Queries) error {
+		if err := q.SetStreamStarted(ctx, st.streamID); err != nil {
+			return err
+		}
+		return st.notify.Emit(ctx, q, st.streamID, lifecycle.StreamPlayable, lifecycle.Data{
+			"manifestPath": st.remotePath(ManifestName),
+		})
+	})
+	if err != nil {
+		slog.Error("Failed to mark stream playable", "streamId", st.streamID, "error", err)
+		return
+	}
+
+	slog.Info("Stream started (playable)", "streamId", st.streamID)
+	st.playable = true
+}
+
+// handleManifestResult publishes the manifest that changed while the last
+// one was uploading. After a failure it waits for the next trigger instead,
+// the pool has already retried.
+func (st *SegmentTracker) handleManifestResult(err error) {
+	uploaded := st.manifestUploading
+	st.manifestInFlight = false
+	st.manifestUploading = nil
+
+	if err != nil {
+		slog.Error("Failed to publish manifest", "streamId", st.streamID, "error", err)
+		st.manifestPending = true
+		return
+	}
+
+	st.publishedManifest = uploaded
+
+	if !st.playable {
+		st.markPlayable()
+	}
+
+	if st.manifestPending {
+		st.publishManifest()
+	}
+}
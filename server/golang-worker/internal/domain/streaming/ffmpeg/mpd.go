@@ -0,0 +1,190 @@
+package ffmpeg
+
+import (
+	"encoding/xml"
+	"fmt"
+)
+
+type mpdDocument struct {
+	XMLName               xml.Name    `xml:"MPD"`
+	Type                  string      `xml:"type,attr"`
+	AvailabilityStartTime string      `xml:"availabilityStartTime,attr"`
+	Periods               []mpdPeriod `xml:"Period"`
+}
+
+type mpdPeriod struct {
+	AdaptationSets []mpdAdaptationSet `xml:"AdaptationSet"`
+}
+
+type mpdAdaptationSet struct {
+	ID               string              `xml:"id,attr"`
+	ContentType      string              `xml:"contentType,attr"`
+	Lang             string              `xml:"lang,attr"`
+	SegmentAlignment string              `xml:"segmentAlignment,attr"`
+	SegmentTemplate  *mpdSegmentTemplate `xml:"SegmentTemplate"`
+	Representations  []mpdRepresentation `xml:"Representation"`
+}
+
+type mpdRepresentation struct {
+	ID                string              `xml:"id,attr"`
+	MimeType          string              `xml:"mimeType,attr"`
+	Codecs            string              `xml:"codecs,attr"`
+	Bandwidth         string              `xml:"bandwidth,attr"`
+	Width             string              `xml:"width,attr"`
+	Height            string              `xml:"height,attr"`
+	FrameRate         string              `xml:"frameRate,attr"`
+	Sar               string              `xml:"sar,attr"`
+	AudioSamplingRate string              `xml:"audioSamplingRate,attr"`
+	AudioChannels     *mpdDescriptor      `xml:"AudioChannelConfiguration"`
+	SegmentTemplate   *mpdSegmentTemplate `xml:"SegmentTemplate"`
+}
+
+type mpdDescriptor struct {
+	SchemeIDURI string `xml:"schemeIdUri,attr"`
+	Value       string `xml:"value,attr"`
+}
+
+type mpdSegmentTemplate struct {
+	Timescale      uint64       `xml:"timescale,attr"`
+	Initialization string       `xml:"initialization,attr"`
+	Media          string       `xml:"media,attr"`
+	StartNumber    *int         `xml:"startNumber,attr"`
+	Timeline       *mpdTimeline `xml:"SegmentTimeline"`
+}
+
+type mpdTimeline struct {
+	Segments []mpdTimelineEntry `xml:"S"`
+}
+
+type mpdTimelineEntry struct {
+	T *uint64 `xml:"t,attr"`
+	D uint64  `xml:"d,attr"`
+	R int     `xml:"r,attr"`
+}
+
+// TimelineSegment is one media segment of a representation as listed in the
+// manifest's SegmentTimeline, in timescale units.
+type TimelineSegment struct {
+	Number   int
+	Start    uint64
+	Duration uint64
+}
+
+type RepTimeline struct {
+	Timescale uint64
+	Segments  []TimelineSegment
+}
+
+// ManifestRepresentation is what a static manifest needs to describe one
+// representation again, copied from the live one.
+type ManifestRepresentation struct {
+	ID                string
+	ContentType       string
+	Lang              string
+	MimeType          string
+	Codecs            string
+	Bandwidth         string
+	Width             string
+	Height            string
+	FrameRate         string
+	Sar               string
+	AudioSamplingRate string
+	AudioChannels     *mpdDescriptor
+	Initialization    string
+	Media             string
+}
+
+// LiveManifest is the part of the muxer's MPD the worker cares about: the
+// segment timeline of every representation and how to describe it.
+type LiveManifest struct {
+	Dynamic               bool
+	AvailabilityStartTime string
+	Timelines             map[string]RepTimeline
+	Representations       []ManifestRepresentation
+}
+
+// ParseManifest reads the SegmentTimeline of every representation. The
+// SegmentTemplate may sit on the representation or on its adaptation set.
+func ParseManifest(data []byte) (*LiveManifest, error) {
+	var doc mpdDocument
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest: %w", err)
+	}
+
+	manifest := &LiveManifest{
+		Dynamic:               doc.Type == "dynamic",
+		AvailabilityStartTime: doc.AvailabilityStartTime,
+		Timelines:             make(map[string]RepTimeline),
+	}
+
+	for _, period := range doc.Periods {
+		for _, set := range period.AdaptationSets {
+			for _, rep := range set.Representations {
+				tmpl := rep.SegmentTemplate
+				if tmpl == nil {
+					tmpl = set.SegmentTemplate
+				}
+				if tmpl == nil || tmpl.Timeline == nil {
+					continue
+				}
+				manifest.Timelines[rep.ID] = expandTimeline(tmpl)
+				manifest.Representations = append(manifest.Representations, ManifestRepresentation{
+					ID:                rep.ID,
+					ContentType:       set.ContentType,
+					Lang:              set.Lang,
+					MimeType:          rep.MimeType,
+					Codecs:            rep.Codecs,
+					Bandwidth:         rep.Bandwidth,
+					Width:             rep.Width,
+					Height:            rep.Height,
+					FrameRate:         rep.FrameRate,
+					Sar:               rep.Sar,
+					AudioSamplingRate: rep.AudioSamplingRate,
+					AudioChannels:     rep.AudioChannels,
+					Initialization:    tmpl.Initialization,
+					Media:             tmpl.Media,
+				})
+			}
+		}
+	}
+
+	return manifest, nil
+}
+
+func expandTimeline(tmpl *mpdSegmentTemplate) RepTimeline {
+	timeline := RepTimeline{Timescale: tmpl.Timescale}
+	if timeline.Timescale == 0 {
+		timeline.Timescale = 1
+	}
+
+	number := 1
+	if tmpl.StartNumber != nil {
+		number = *tmpl.StartNumber
+	}
+
+	var next uint64
+	for _, entry := range tmpl.Timeline.Segments {
+		if entry.T != nil {
+			next = *entry.T
+		}
+		for range max(entry.R, 0) + 1 {
+			timeline.Segments = append(timeline.Segments, TimelineSegment{
+				Number:   number,
+				Start:    next,
+				Duration: entry.D,
+			})
+			number++
+			next += entry.D
+		}
+	}
+
+	return timeline
+}
+
+// LastNumber is the highest segment number the representation lists, or 0.
+func (t RepTimeline) LastNumber() int {
+	if len(t.Segments) == 0 {
+		return 0
+	}
+	return t.Segments[len(t.Segments)-1].Number
+}
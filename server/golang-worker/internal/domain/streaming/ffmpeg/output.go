@@ -0,0 +1,101 @@
+package ffmpeg
+
+import (
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/bitstream/backend-go/internal/config"
+	"github.com/bitstream/backend-go/internal/domain/streaming/llhls"
+)
+
+type OutputMode string
+
+const (
+	// OutputModeDASH writes a DASH manifest only.
+	OutputModeDASH OutputMode = "dash"
+	// OutputModeCMAF writes CMAF fMP4 segments referenced by both the DASH
+	// manifest and HLS master/media playlists, from a single encode.
+	OutputModeCMAF OutputMode = "cmaf"
+	// OutputModeLLHLS writes the DASH manifest plus Low-Latency HLS playlists
+	// with partial segments, produced by the llhls writer.
+	OutputModeLLHLS OutputMode = "llhls"
+)
+
+const defaultPartTarget = 500 * time.Millisecond
+
+const (
+	ManifestName    = "manifest.mpd"
+	HLSMasterName   = "master.m3u8"
+	HLSMediaPattern = "media_*.m3u8"
+	hlsPlaylistMime = "application/vnd.apple.mpegurl"
+)
+
+// ResolveOutputMode picks the per-stream mode when it is valid and falls back
+// to the configured default, then to DASH.
+func ResolveOutputMode(requested, fallback string) OutputMode {
+	for _, candidate := range []string{requested, fallback} {
+		switch OutputMode(strings.ToLower(candidate)) {
+		case OutputModeDASH:
+			return OutputModeDASH
+		case OutputModeCMAF:
+			return OutputModeCMAF
+		case OutputModeLLHLS:
+			return OutputModeLLHLS
+		case "":
+			continue
+		default:
+			slog.Warn("Unknown output mode, ignoring", "mode", candidate)
+		}
+	}
+	return OutputModeDASH
+}
+
+func (m OutputMode) HasHLS() bool {
+	return m == OutputModeCMAF
+}
+
+func (m OutputMode) IsLowLatencyHLS() bool {
+	return m == OutputModeLLHLS
+}
+
+func ResolvePartTarget(cfg config.FFmpegConfig) time.Duration {
+	if cfg.PartTarget <= 0 {
+		return defaultPartTarget
+	}
+	return cfg.PartTarget
+}
+
+// OutputFilePatterns lists every file the muxer or the segment tracker may
+// leave in a stream directory, for every representation of the ladder and
+// every output mode.
+func OutputFilePatterns() []string {
+	return []string{
+		ManifestName,
+		ManifestName + ".tmp",
+		VODManifestName,
+		checkpointName,
+		HLSMasterName,
+		HLSMediaPattern,
+		llhls.MasterPlaylistName,
+		"llhls_*.m3u8",
+		"part-*-*.m4s",
+		"init-*.mp4",
+		"chunk-*-*.m4s",
+		"*.tmp",
+	}
+}
+
+// ClearOutputFiles removes what an earlier process left in a stream
+// directory, so its segments are not mistaken for the new process's. The
+// upload journal is kept.
+func ClearOutputFiles(streamDir string) {
+	for _, pattern := range OutputFilePatterns() {
+		matches, _ := filepath.Glob(filepath.Join(streamDir, pattern))
+		for _, path := range matches {
+			_ = os.Remove(path)
+		}
+	}
+}
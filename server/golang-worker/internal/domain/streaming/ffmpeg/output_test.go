@@ -0,0 +1,27 @@
+package ffmpeg
+
+import "testing"
+
+func TestResolveOutputMode(t *testing.T) {
+	tests := []struct {
+		name      string
+		requested string
+		fallback  string
+		want      OutputMode
+	}{
+		{name: "requested wins", requested: "cmaf", fallback: "dash", want: OutputModeCMAF},
+		{name: "case insensitive", requested: "LLHLS", fallback: "", want: OutputModeLLHLS},
+		{name: "empty request uses fallback", requested: "", fallback: "cmaf", want: OutputModeCMAF},
+		{name: "unknown request uses fallback", requested: "smooth", fallback: "llhls", want: OutputModeLLHLS},
+		{name: "nothing set is dash", requested: "", fallback: "", want: OutputModeDASH},
+		{name: "nothing valid is dash", requested: "hds", fallback: "rtsp", want: OutputModeDASH},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ResolveOutputMode(tt.requested, tt.fallback); got != tt.want {
+				t.Errorf("ResolveOutputMode(%q, %q) = %q, want %q", tt.requested, tt.fallback, got, tt.want)
+			}
+		})
+	}
+}
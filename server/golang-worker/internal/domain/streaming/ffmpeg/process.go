@@ -12,7 +12,10 @@ import (
 	"time"
 
 	stream "github.com/bitstream/backend-go/internal/db/generated"
-	"github.com/bitstream/backend-go/internal/storage/minio"
+	"github.com/bitstream/backend-go/internal/domain/streaming/audit"
+	"github.com/bitstream/backend-go/internal/domain/streaming/lifecycle"
+	"github.com/bitstream/backend-go/internal/domain/streaming/llhls"
+	"github.com/bitstream/backend-go/internal/storage/uploader"
 )
 
 const (
@@ -27,15 +30,21 @@ type StreamProcess struct {
 	cancel    context.CancelFunc
 	outputDir string
 	queries   *stream.Queries
+	events    *audit.Recorder
+	tracker   *SegmentTracker
 
 	stdin  io.WriteCloser
 	stderr *bytes.Buffer
 
-	done    chan struct{}
-	exitErr error
+	done      chan struct{}
+	finalized chan struct{}
+	exitErr   error
 
-	mu         sync.Mutex
-	manualStop bool
+	mu            sync.Mutex
+	manualStop    bool
+	interrupted   chan struct{}
+	interruptOnce sync.Once
+	relinquished  bool
 }
 
 func GetStreamDirectory(outputDir, streamId string) string {
@@ -49,13 +58,17 @@ func NewStreamProcess(
 	streamID string,
 	rtmp string,
 	outputDir, env string,
+	layout OutputLayout,
 	queries *stream.Queries,
-	storage *minio.Service,
+	uploads *uploader.Pool,
+	events *audit.Recorder,
+	notify *lifecycle.Publisher,
+	takeover *Takeover,
 ) (*StreamProcess, error) {
 	ctx, cancel := context.WithCancel(context.Background())
 	streamDir := GetStreamDirectory(outputDir, streamID)
 
-	cmd := BuildStreamCommand(ctx, rtmp, streamDir, env)
+	cmd := BuildStreamCommand(ctx, rtmp, streamDir, env, layout)
 
 	stdinPipe, err := cmd.StdinPipe()
 	if err != nil {
@@ -74,21 +87,43 @@ func NewStreamProcess(
 	}
 
 	proc := &StreamProcess{
-		StreamID:   streamID,
-		cmd:        cmd,
-		ctx:        ctx,
-		cancel:     cancel,
-		stdin:      stdinPipe,
-		stderr:     stderrBuf,
-		done:       make(chan struct{}),
-		manualStop: false,
-		outputDir:  outputDir,
-		queries:    queries,
+		StreamID:    streamID,
+		cmd:         cmd,
+		ctx:         ctx,
+		cancel:      cancel,
+		stdin:       stdinPipe,
+		stderr:      stderrBuf,
+		done:        make(chan struct{}),
+		finalized:   make(chan struct{}),
+		interrupted: make(chan struct{}),
+		manualStop:  false,
+		outputDir:   outputDir,
+		queries:     queries,
+		events:      events,
 	}
 
-	tracker := NewSegmentTracker(ctx, streamID, streamDir, queries, storage)
+	proc.tracker = NewSegmentTracker(ctx, streamID, streamDir, layout, queries, uploads.NewQueue(), events, notify, takeover)
+
+	// the LL-HLS writer serves the stream until FFmpeg exits; it is done
+	// before the process counts as finalized, so a restart of the stream
+	// does not race the old writer's registration
+	writerDone := make(chan struct{})
+	if layout.Mode.IsLowLatencyHLS() {
+		go func() {
+			defer close(writerDone)
+			llhls.NewStream(streamID, streamDir, layout.LLHLSRenditions(), layout.PartTarget).Run(ctx)
+		}()
+	} else {
+		close(writerDone)
+	}
 
-	go tracker.Run()
+	go func() {
+		defer close(proc.finalized)
+		if !proc.tracker.Run() {
+			proc.relinquish()
+		}
+		<-writerDone
+	}()
 
 	go proc.monitor()
 
@@ -130,8 +165,66 @@ func (p *StreamProcess) Stop() error {
 	return p.terminateForcibly()
 }
 
+// Interrupt stops the process without waiting for the source to end, for a
+// worker that is shutting down: FFmpeg is asked to quit, then killed.
+// A Stop waiting for the source to end moves on as well.
+func (p *StreamProcess) Interrupt() error {
+	p.mu.Lock()
+	p.manualStop = true
+	p.mu.Unlock()
+	p.interruptOnce.Do(func() { close(p.interrupted) })
+
+	slog.Info("Interrupting stream", "streamId", p.StreamID)
+
+	if p.requestGracefulStop(GracefulShutdownTimeout) {
+		return nil
+	}
+
+	return p.terminateForcibly()
+}
+
+// HandOver stops the process once another worker has taken its stream
+// over. Its segments are still uploaded, but the stream is not finalized:
+// the ended manifest is not published and no VOD is announced.
+func (p *StreamProcess) HandOver() error {
+	p.tracker.handingOver.Store(true)
+
+	p.mu.Lock()
+	p.relinquished = true
+	p.mu.Unlock()
+
+	slog.Info("Handing stream over", "streamId", p.StreamID)
+	return p.Interrupt()
+}
+
+// relinquish stops a process whose takeover did not complete.
+func (p *StreamProcess) relinquish() {
+	p.mu.Lock()
+	p.relinquished = true
+	p.mu.Unlock()
+
+	if err := p.Interrupt(); err != nil {
+		slog.Error("Failed to stop process after takeover failed", "streamId", p.StreamID, "error", err)
+	}
+}
+
+// Relinquished reports whether the stream was left to another worker, or
+// never taken over, so finishing it is not this process's job.
+func (p *StreamProcess) Relinquished() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.relinquished
+}
+
+// TookOver reports whether the process took over the stream it was started
+// to continue.
+func (p *StreamProcess) TookOver() bool {
+	return p.tracker.tookOver.Load()
+}
+
 func (p *StreamProcess) waitForNaturalExit(timeout time.Duration) bool {
 	slog.Info("Stage 1: Waiting for natural EOF", "streamId", p.StreamID, "timeout", timeout)
+	p.events.Record(p.StreamID, audit.StopStage, audit.Fields{"stage": 1, "name": "natural_exit"})
 	select {
 	case <-p.done:
 		slog.Info("Shutdown successful: Stream finished naturally (EOF)", "streamId", p.StreamID)
@@ -139,11 +232,15 @@ func (p *StreamProcess) waitForNaturalExit(timeout time.Duration) bool {
 	case <-time.After(timeout):
 		slog.Warn("Stage 1 timed out: Natural exit window exceeded", "streamId", p.StreamID)
 		return false
+	case <-p.interrupted:
+		slog.Info("Stage 1 interrupted: worker is shutting down", "streamId", p.StreamID)
+		return false
 	}
 }
 
 func (p *StreamProcess) requestGracefulStop(timeout time.Duration) bool {
 	slog.Info("Stage 2: Requesting graceful stop via 'q'", "streamId", p.StreamID)
+	p.events.Record(p.StreamID, audit.StopStage, audit.Fields{"stage": 2, "name": "graceful_quit"})
 
 	if p.stdin != nil {
 		_, _ = p.stdin.Write([]byte("q\n"))
@@ -161,6 +258,7 @@ func (p *StreamProcess) requestGracefulStop(timeout time.Duration) bool {
 
 func (p *StreamProcess) terminateForcibly() error {
 	slog.Warn("Stage 3: Forcing hard termination (Kill)", "streamId", p.StreamID)
+	p.events.Record(p.StreamID, audit.StopStage, audit.Fields{"stage": 3, "name": "kill"})
 
 	p.cancel()
 	var killErr error
@@ -186,10 +284,46 @@ func (p *StreamProcess) Done() <-chan struct{} {
 	return p.done
 }
 
+// Finalized is closed once the segment tracker has uploaded everything the
+// process produced and written the final stream metadata, and the LL-HLS
+// writer has stopped.
+func (p *StreamProcess) Finalized() <-chan struct{} {
+	return p.finalized
+}
+
+// Running reports whether FFmpeg has not exited yet.
+func (p *StreamProcess) Running() bool {
+	select {
+	case <-p.done:
+		return false
+	default:
+		return true
+	}
+}
+
 func (p *StreamProcess) Error() error {
 	return p.exitErr
 }
 
+// ExitCode is the exit status of FFmpeg, or -1 while it runs or when it was
+// killed by a signal.
+func (p *StreamProcess) ExitCode() int {
+	if p.cmd.ProcessState == nil {
+		return -1
+	}
+	return p.cmd.ProcessState.ExitCode()
+}
+
+// StderrTail returns the last n bytes FFmpeg wrote to stderr. Only call it
+// once the process is done.
+func (p *StreamProcess) StderrTail(n int) string {
+	out := p.stderr.String()
+	if len(out) <= n {
+		return out
+	}
+	return out[len(out)-n:]
+}
+
 func (p *StreamProcess) IsManualStop() bool {
 	p.mu.Lock()
 	defer p.mu.Unlock()
@@ -0,0 +1,252 @@
+package ffmpeg
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	stream "github.com/bitstream/backend-go/internal/db/generated"
+	"github.com/bitstream/backend-go/internal/domain/streaming/audit"
+	"github.com/bitstream/backend-go/internal/domain/streaming/lifecycle"
+	"github.com/bitstream/backend-go/internal/storage/uploader"
+)
+
+const (
+	// checkpointName is where a tracker keeps what it needs to recover its
+	// stream after the worker dies, next to the local copy of the timeline
+	checkpointName = "checkpoint.json"
+
+	probeTimeout = 15 * time.Second
+)
+
+// checkpoint is how the local segment numbers and timestamps of a
+// taken-over stream map onto the stored ones. Without it the segments left
+// behind would be uploaded over the stored ones.
+type checkpoint struct {
+	SeqOffset         int              `json:"seqOffset"`
+	BaseDuration      float64          `json:"baseDuration"`
+	TimeShift         map[string]int64 `json:"timeShift,omitempty"`
+	AvailabilityStart string           `json:"availabilityStart,omitempty"`
+}
+
+// LocalSeqOffset is how far the stored segment numbers of a stream are
+// ahead of those of its files in streamDir, which the process writing them
+// took over.
+func LocalSeqOffset(streamDir string) int {
+	data, err := os.ReadFile(filepath.Join(streamDir, checkpointName))
+	if err != nil {
+		return 0
+	}
+	var cp checkpoint
+	if err := json.Unmarshal(data, &cp); err != nil {
+		return 0
+	}
+	return cp.SeqOffset
+}
+
+func (st *SegmentTracker) saveCheckpoint() {
+	data, err := json.Marshal(checkpoint{
+		SeqOffset:         st.seqOffset,
+		BaseDuration:      st.baseDuration,
+		TimeShift:         st.timeShift,
+		AvailabilityStart: st.availabilityStart,
+	})
+	if err == nil {
+		err = writeFileAtomic(filepath.Join(st.streamDir, checkpointName), data)
+	}
+	if err != nil {
+		slog.Warn("Failed to write stream checkpoint", "streamId", st.streamID, "error", err)
+	}
+}
+
+// saveTimeline keeps the full timeline next to the segments. The live
+// manifest only lists a sliding window, so it is all that is left of the
+// earlier segments if the worker dies.
+func (st *SegmentTracker) saveTimeline() {
+	// the saved copy is read back numbered from its start; one with a gap
+	// would be misnumbered, so the last one without is kept
+	if len(st.timelineGaps()) > 0 {
+		return
+	}
+
+	path := filepath.Join(st.streamDir, VODManifestName)
+	if err := writeFileAtomic(path, st.renderVODManifest()); err != nil {
+		slog.Warn("Failed to save stream timeline", "streamId", st.streamID, "error", err)
+	}
+}
+
+// clearCheckpoint drops what an earlier process of the stream saved, which
+// a new one numbering from the start would be recovered with otherwise.
+func (st *SegmentTracker) clearCheckpoint() {
+	for _, name := range []string{checkpointName, VODManifestName} {
+		if err := os.Remove(filepath.Join(st.streamDir, name)); err != nil && !os.IsNotExist(err) {
+			slog.Warn("Failed to clear stream checkpoint", "streamId", st.streamID, "file", name, "error", err)
+		}
+	}
+}
+
+func writeFileAtomic(path string, data []byte) error {
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// RecoverStream finishes what the process of a stream left undone when the
+// worker running it died: the segments left in its directory are uploaded,
+// and the stream's metadata and VOD manifest brought up to them. With
+// finish the stream is finalized as if it had ended then; otherwise it is
+// left for a takeover to continue.
+func RecoverStream(
+	streamID, outputDir string,
+	layout OutputLayout,
+	queries *stream.Queries,
+	uploads *uploader.Pool,
+	events *audit.Recorder,
+	notify *lifecycle.Publisher,
+	finish bool,
+) {
+	// the process is gone, so every file it left is complete
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	streamDir := GetStreamDirectory(outputDir, streamID)
+	st := NewSegmentTracker(ctx, streamID, streamDir, layout, queries, uploads.NewQueue(), events, notify, nil)
+
+	// viewers were already watching, and a takeover must not see the
+	// stream end
+	st.playable = true
+	st.handingOver.Store(!finish)
+
+	var segmentCount int
+	var totalDuration float64
+
+	if st.restore() {
+		slog.Info("Recovering segments left by the previous run", "streamId", streamID, "resumeAfter", st.seqOffset)
+		st.replayJournal()
+		segmentCount, totalDuration = st.flush()
+	} else if meta, err := queries.GetStreamMeta(context.Background(), streamID); err == nil {
+		// nothing left locally: the metadata last written stands
+		segmentCount = int(meta.SegmentCount.Int32)
+		totalDuration = meta.TotalDuration
+	}
+
+	if !finish {
+		slog.Info("Stream recovered, resuming", "streamId", streamID, "segments", segmentCount)
+		return
+	}
+
+	st.recordFinalized(segmentCount, totalDuration)
+
+	slog.Info("Stream finalized after the worker died",
+		"streamId", streamID,
+		"segments", segmentCount,
+		"duration", totalDuration,
+	)
+	st.events.Record(streamID, audit.Finalized, audit.Fields{
+		"segments":       segmentCount,
+		"duration":       totalDuration,
+		"pendingUploads": st.journal.Len(),
+		"recovered":      true,
+	})
+}
+
+// restore picks up the state a process left in the stream directory: its
+// checkpoint, its timeline, and the segments it had not cleaned up yet. It
+// reports whether any segment was left.
+func (st *SegmentTracker) restore() bool {
+	if data, err := os.ReadFile(filepath.Join(st.streamDir, checkpointName)); err == nil {
+		var cp checkpoint
+		if err := json.Unmarshal(data, &cp); err != nil {
+			slog.Warn("Failed to parse stream checkpoint", "streamId", st.streamID, "error", err)
+		} else {
+			st.seqOffset = cp.SeqOffset
+			st.baseDuration = cp.BaseDuration
+			st.availabilityStart = cp.AvailabilityStart
+			for repId, shift := range cp.TimeShift {
+				st.timeShift[repId] = shift
+			}
+		}
+	}
+
+	if data, err := os.ReadFile(filepath.Join(st.streamDir, VODManifestName)); err == nil {
+		if saved, err := ParseManifest(data); err != nil {
+			slog.Warn("Failed to parse the saved timeline", "streamId", st.streamID, "error", err)
+		} else {
+			st.seedTimeline(saved)
+		}
+	}
+
+	chunks, _ := filepath.Glob(filepath.Join(st.streamDir, chunkPattern))
+	if len(chunks) == 0 {
+		return false
+	}
+
+	// segments are only cleaned up once uploaded, so everything below the
+	// oldest one left has landed
+	oldest := make(map[string]int)
+	for _, path := range chunks {
+		repId, seq := st.parseChunkName(filepath.Base(path))
+		if current, ok := oldest[repId]; !ok || seq < current {
+			oldest[repId] = seq
+		}
+	}
+
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	for repId, seq := range oldest {
+		if seq > 1 {
+			st.repSeq[repId] = seq - 1
+		}
+	}
+
+	// the durations of the segments gone come from the timeline
+	if timeline := st.timelines[st.referenceRep()]; timeline != nil && timeline.Timescale > 0 {
+		for _, seg := range timeline.Segments {
+			if seq := seg.Number - st.seqOffset; seq >= 1 {
+				st.segDurations[seq] = float64(seg.Duration) / float64(timeline.Timescale)
+			}
+		}
+	}
+
+	st.lastSegmentSeq = st.completeSeqLocked()
+	st.uploadedSeq = st.lastSegmentSeq
+	return true
+}
+
+// ProbeSource checks that an RTMP source is still publishing by reading the
+// headers of its streams.
+func ProbeSource(ctx context.Context, rtmpURL string) error {
+	ctx, cancel := context.WithTimeout(ctx, probeTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "ffprobe",
+		"-v", "error",
+		"-rw_timeout", "10000000",
+		"-rtmp_live", "live",
+		"-show_entries", "stream=codec_type",
+		"-of", "csv=p=0",
+		rtmpURL,
+	)
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		return fmt.Errorf("%w: %s", err, bytes.TrimSpace(stderr.Bytes()))
+	}
+	if len(bytes.TrimSpace(out)) == 0 {
+		return errors.New("source has no streams")
+	}
+	return nil
+}
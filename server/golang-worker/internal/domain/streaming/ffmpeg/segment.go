@@ -3,62 +3,214 @@ package ffmpeg
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log/slog"
 	"os"
 	"path/filepath"
 	"sort"
+	"strconv"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	stream "github.com/bitstream/backend-go/internal/db/generated"
-	"github.com/bitstream/backend-go/internal/storage/minio"
+	"github.com/bitstream/backend-go/internal/domain/streaming/audit"
+	"github.com/bitstream/backend-go/internal/domain/streaming/lifecycle"
+	"github.com/bitstream/backend-go/internal/storage/uploader"
 )
 
 const (
 	liveBufferSegments = 30
+	pollInterval       = 500 * time.Millisecond
+
+	initPattern  = "init-*.mp4"
+	chunkPattern = "chunk-*-*.m4s"
 )
 
+type fileStamp struct {
+	size    int64
+	modTime time.Time
+}
+
+func (s fileStamp) equal(other fileStamp) bool {
+	return s.size == other.size && s.modTime.Equal(other.modTime)
+}
+
 type SegmentTracker struct {
 	ctx       context.Context
 	streamID  string
 	streamDir string
+	layout    OutputLayout
 	queries   *stream.Queries
-	storage   *minio.Service
+	uploads   *uploader.Queue
+	journal   *uploader.Journal
+	events    *audit.Recorder
+	notify    *lifecycle.Publisher
 
 	mu               sync.RWMutex
+	repSeq           map[string]int
+	landed           map[string]map[int]bool
 	lastSegmentSeq   int
 	uploadedSeq      int
 	firstSegUploaded bool
 	segmentDuration  float64
+	segDurations     map[int]float64
+	timescales       map[string]uint32
 	metaInitialized  bool
+
+	observed  map[string]fileStamp
+	submitted map[string]uploadStamp
+
+	chunksInFlight   int
+	pendingPlaylists map[string]struct{}
+	failed           map[string]uploader.Job
+
+	manifestInFlight  bool
+	manifestPending   bool
+	manifestUploading []byte
+	publishedManifest []byte
+	playable          bool
+
+	timelines    map[string]*RepTimeline
+	vodReps      map[string]ManifestRepresentation
+	vodRepOrder  []string
+	vodPublished bool
+
+	// a taken-over stream continues the segments stored before it
+	takeover          *Takeover
+	seqOffset         int
+	baseDuration      float64
+	timeShift         map[string]int64
+	availabilityStart string
+	tookOver          atomic.Bool
+
+	// set when the stream is handed to another worker, which finishes it
+	handingOver atomic.Bool
 }
 
 func NewSegmentTracker(
 	ctx context.Context,
 	streamID, streamDir string,
+	layout OutputLayout,
 	queries *stream.Queries,
-	storage *minio.Service,
+	uploads *uploader.Queue,
+	events *audit.Recorder,
+	notify *lifecycle.Publisher,
+	takeover *Takeover,
 ) *SegmentTracker {
+	journal, err := uploader.OpenJournal(streamDir)
+	if err != nil {
+		slog.Error("Failed to open upload journal, starting a new one", "streamId", streamID, "error", err)
+		journal = uploader.NewJournal(streamDir)
+	}
+
 	return &SegmentTracker{
-		ctx:             ctx,
-		streamID:        streamID,
-		streamDir:       streamDir,
-		queries:         queries,
-		storage:         storage,
-		lastSegmentSeq:  -1,
-		uploadedSeq:     -1,
-		segmentDuration: 2.0,
-		metaInitialized: false,
+		ctx:              ctx,
+		streamID:         streamID,
+		streamDir:        streamDir,
+		layout:           layout,
+		queries:          queries,
+		uploads:          uploads,
+		journal:          journal,
+		events:           events,
+		notify:           notify,
+		repSeq:           make(map[string]int),
+		landed:           make(map[string]map[int]bool),
+		lastSegmentSeq:   -1,
+		uploadedSeq:      -1,
+		segmentDuration:  2.0,
+		segDurations:     make(map[int]float64),
+		timescales:       make(map[string]uint32),
+		metaInitialized:  false,
+		observed:         make(map[string]fileStamp),
+		submitted:        make(map[string]uploadStamp),
+		pendingPlaylists: make(map[string]struct{}),
+		failed:           make(map[string]uploader.Job),
+		timelines:        make(map[string]*RepTimeline),
+		vodReps:          make(map[string]ManifestRepresentation),
+		takeover:         takeover,
+		timeShift:        make(map[string]int64),
+	}
+}
+
+// Run uploads what the process writes until it exits, then finalizes the
+// stream. It reports false when a takeover never completed, in which case
+// nothing was uploaded.
+func (st *SegmentTracker) Run() bool {
+	slog.Info("Segment tracker started", "streamId", st.streamID, "streamDir", st.streamDir)
+
+	if st.takeover == nil {
+		st.clearCheckpoint()
+	} else if !st.takeOver() {
+		return false
 	}
+
+	st.replayJournal()
+
+	events, err := watchStreamDir(st.ctx, st.streamDir)
+	if err != nil {
+		slog.Warn("Segment watch unavailable, falling back to polling", "streamId", st.streamID, "error", err)
+		st.runPolling()
+		return true
+	}
+
+	st.runWatching(events)
+	return true
 }
 
-func (st *SegmentTracker) Run() {
-	ticker := time.NewTicker(500 * time.Millisecond)
+// runWatching uploads files as soon as the muxer closes or renames them into
+// place, so no stability probing is needed and idle streams cost nothing.
+func (st *SegmentTracker) runWatching(events <-chan watchEvent) {
+	var resync <-chan time.Time
+
+	retry := time.NewTicker(failedRetryInterval)
+	defer retry.Stop()
+
+	for {
+		select {
+		case <-st.ctx.Done():
+			st.finalizeStream()
+			return
+		case ev, ok := <-events:
+			if !ok {
+				if st.ctx.Err() != nil {
+					st.finalizeStream()
+					return
+				}
+				slog.Warn("Segment watch closed, falling back to polling", "streamId", st.streamID)
+				st.runPolling()
+				return
+			}
+
+			if ev.overflow {
+				// events were dropped: rescan twice so stability can be judged
+				slog.Warn("Segment watch overflowed, rescanning", "streamId", st.streamID)
+				st.scanAndUpload()
+				resync = time.After(pollInterval)
+				continue
+			}
+
+			st.handleFile(filepath.Join(st.streamDir, ev.name))
+		case <-resync:
+			resync = nil
+			st.scanAndUpload()
+		case <-retry.C:
+			st.retryFailed()
+		case r := <-st.uploads.Results():
+			st.uploads.Ack()
+			st.handleResult(r)
+		}
+	}
+}
+
+func (st *SegmentTracker) runPolling() {
+	ticker := time.NewTicker(pollInterval)
 	defer ticker.Stop()
 
-	slog.Info("Segment tracker started", "streamId", st.streamID, "streamDir", st.streamDir)
+	retry := time.NewTicker(failedRetryInterval)
+	defer retry.Stop()
 
 	for {
 		select {
@@ -67,71 +219,64 @@ func (st *SegmentTracker) Run() {
 			return
 		case <-ticker.C:
 			st.scanAndUpload()
+		case <-retry.C:
+			st.retryFailed()
+		case r := <-st.uploads.Results():
+			st.uploads.Ack()
+			st.handleResult(r)
 		}
 	}
 }
 
+// handleFile uploads one file the muxer has finished writing.
+func (st *SegmentTracker) handleFile(path string) {
+	if !st.metaInitialized {
+		st.initializeMetadata()
+	}
+
+	name := filepath.Base(path)
+	switch {
+	case matchName(initPattern, name):
+		st.uploadInit(path)
+	case matchName(chunkPattern, name):
+		st.uploadChunk(path)
+		st.cleanupOldLocalChunks()
+	case name == ManifestName:
+		st.publishManifest()
+	case st.layout.Mode.HasHLS() && (name == HLSMasterName || matchName(HLSMediaPattern, name)):
+		st.pendingPlaylists[path] = struct{}{}
+		st.flushPlaylists()
+	}
+}
+
 func (st *SegmentTracker) scanAndUpload() {
 	if !st.metaInitialized {
 		st.initializeMetadata()
 	}
 
-	initMatches, _ := filepath.Glob(filepath.Join(st.streamDir, "init-*.mp4"))
+	initMatches, _ := filepath.Glob(filepath.Join(st.streamDir, initPattern))
 	for _, path := range initMatches {
 		if st.isFileStable(path) {
-			filename := filepath.Base(path)
-			remotePath := fmt.Sprintf("streams/%s/%s", st.streamID, filename)
-			if err := st.storage.UploadFile(context.Background(), path, remotePath, "video/mp4"); err == nil {
-				slog.Info("Uploaded init segment", "file", filename)
-			}
+			st.uploadInit(path)
 		}
 	}
 
-	chunkPattern := filepath.Join(st.streamDir, "chunk-*-*.m4s")
-	chunkMatches, err := filepath.Glob(chunkPattern)
+	chunkMatches, err := filepath.Glob(filepath.Join(st.streamDir, chunkPattern))
 	if err != nil {
 		slog.Error("Failed to glob chunks", "error", err)
 		return
 	}
 
 	for _, path := range chunkMatches {
-		if !st.isFileStable(path) {
-			continue
-		}
-
-		filename := filepath.Base(path)
-		repId, seq := st.parseChunkName(filename)
-
-		if seq == 1 && !st.firstSegUploaded {
-			if err := st.queries.SetStreamStarted(context.Background(), st.streamID); err != nil {
-				slog.Error("Failed to set stream startedAt", "err", err)
-			} else {
-				slog.Info("Stream started (first segment)", "streamId", st.streamID)
-				st.firstSegUploaded = true
-			}
-		}
-
-		remotePath := fmt.Sprintf("streams/%s/%s", st.streamID, filename)
-		if err := st.storage.UploadFile(context.Background(), path, remotePath, "video/iso.segment"); err != nil {
-			slog.Error("Failed to upload chunk", "file", path, "error", err)
-			continue
-		}
-
-		slog.Info("Uploaded segment", "repId", repId, "seq", seq)
-
-		st.mu.Lock()
-		if seq > st.lastSegmentSeq {
-			st.lastSegmentSeq = seq
-		}
-		if seq > st.uploadedSeq {
-			st.uploadedSeq = seq
+		if st.isFileStable(path) {
+			st.uploadChunk(path)
 		}
-		st.mu.Unlock()
+	}
 
-		if seq%5 == 0 {
-			st.updateMetadata()
-		}
+	if st.layout.Mode.HasHLS() {
+		st.uploadPlaylists()
 	}
+	st.publishManifest()
 
 	st.cleanupOldLocalChunks()
 }
@@ -145,8 +290,8 @@ func (st *SegmentTracker) initializeMetadata() {
 			StreamId:        st.streamID,
 			SegmentDuration: int32(st.segmentDuration * 1000),
 			Timescale:       1000,
-			VideoRepId:      "0",
-			AudioRepId:      "1",
+			VideoRepId:      st.layout.JoinedVideoRepIDs(),
+			AudioRepId:      st.layout.AudioRepID,
 			BasePath:        sql.NullString{Valid: true, String: fmt.Sprintf("streams/%s", st.streamID)},
 		})
 
@@ -164,12 +309,20 @@ func (st *SegmentTracker) initializeMetadata() {
 func (st *SegmentTracker) updateMetadata() {
 	st.mu.RLock()
 	lastSeq := st.lastSegmentSeq
+	segmentCount, totalDuration := st.mediaTotalsLocked()
 	st.mu.RUnlock()
 
-	totalDuration := float64(lastSeq+1) * st.segmentDuration
-	segmentCount := lastSeq + 1
+	if st.seqOffset > 0 {
+		lastSeq = max(lastSeq, 0) + st.seqOffset
+	}
+
+	renditions, err := json.Marshal(st.layout.MetaRenditions())
+	if err != nil {
+		slog.Error("Failed to encode stream renditions", "streamId", st.streamID, "error", err)
+		return
+	}
 
-	err := st.queries.UpdateStreamMetaWithSegments(
+	err = st.queries.UpdateStreamMetaWithSegments(
 		context.Background(),
 		stream.UpdateStreamMetaWithSegmentsParams{
 			StreamId:        st.streamID,
@@ -178,9 +331,10 @@ func (st *SegmentTracker) updateMetadata() {
 			LastSegmentSeq:  sql.NullInt32{Valid: true, Int32: int32(lastSeq)},
 			SegmentDuration: int32(st.segmentDuration * 1000),
 			Timescale:       1000,
-			VideoRepId:      "0",
-			AudioRepId:      "1",
+			VideoRepId:      st.layout.JoinedVideoRepIDs(),
+			AudioRepId:      st.layout.AudioRepID,
 			BasePath:        sql.NullString{Valid: true, String: fmt.Sprintf("streams/%s", st.streamID)},
+			Renditions:      renditions,
 		},
 	)
 
@@ -190,11 +344,52 @@ func (st *SegmentTracker) updateMetadata() {
 }
 
 func (st *SegmentTracker) finalizeStream() {
+	segmentCount, totalDuration := st.flush()
+
+	if st.handingOver.Load() {
+		slog.Info("Stream handed over", "streamId", st.streamID, "segments", segmentCount)
+		st.events.Record(st.streamID, audit.HandedOver, audit.Fields{
+			"segments":       segmentCount,
+			"pendingUploads": st.journal.Len(),
+		})
+		return
+	}
+
+	st.recordFinalized(segmentCount, totalDuration)
+
+	slog.Info("Stream finalized",
+		"streamId", st.streamID,
+		"segments", segmentCount,
+		"duration", totalDuration,
+	)
+	st.events.Record(st.streamID, audit.Finalized, audit.Fields{
+		"segments":       segmentCount,
+		"duration":       totalDuration,
+		"pendingUploads": st.journal.Len(),
+	})
+}
+
+// flush uploads everything left in the stream directory once nothing writes
+// to it anymore, then brings the stream's metadata and VOD manifest up to
+// it. It returns the stream's totals.
+func (st *SegmentTracker) flush() (segmentCount int, totalDuration float64) {
 	st.scanAndUpload()
+	st.uploads.Drain(st.handleResult)
+
+	// one last pass over failed uploads; what still fails stays journaled
+	// and is retried when the worker next starts
+	st.retryFailed()
+	st.uploads.Drain(st.handleResult)
+
+	// the muxer writes its last manifest on exit, after the final segments
+	st.publishManifest()
+	st.uploads.Drain(st.handleResult)
+	if pending := st.journal.Len(); pending > 0 {
+		slog.Warn("Stream finalized with uploads pending", "streamId", st.streamID, "pending", pending)
+	}
 
 	st.mu.RLock()
-	totalDuration := float64(st.lastSegmentSeq) * st.segmentDuration
-	segmentCount := st.lastSegmentSeq
+	segmentCount, totalDuration = st.mediaTotalsLocked()
 	st.mu.RUnlock()
 
 	_, err := st.queries.GetStreamMeta(context.Background(), st.streamID)
@@ -205,8 +400,8 @@ func (st *SegmentTracker) finalizeStream() {
 			StreamId:        st.streamID,
 			SegmentDuration: int32(st.segmentDuration * 1000),
 			Timescale:       1000,
-			VideoRepId:      "0",
-			AudioRepId:      "1",
+			VideoRepId:      st.layout.JoinedVideoRepIDs(),
+			AudioRepId:      st.layout.AudioRepID,
 			BasePath:        sql.NullString{Valid: true, String: fmt.Sprintf("streams/%s", st.streamID)},
 		})
 		if err != nil {
@@ -216,53 +411,55 @@ func (st *SegmentTracker) finalizeStream() {
 		slog.Error("Failed to check stream meta existence", "error", err)
 	}
 
-	err = st.queries.UpdateStreamMetaWithSegments(
-		context.Background(),
-		stream.UpdateStreamMetaWithSegmentsParams{
-			StreamId:        st.streamID,
-			TotalDuration:   totalDuration,
-			SegmentCount:    sql.NullInt32{Valid: true, Int32: int32(segmentCount)},
-			LastSegmentSeq:  sql.NullInt32{Valid: true, Int32: int32(st.lastSegmentSeq)},
-			SegmentDuration: int32(st.segmentDuration * 1000),
-			Timescale:       1000,
-			VideoRepId:      "0",
-			AudioRepId:      "1",
-			BasePath:        sql.NullString{Valid: true, String: fmt.Sprintf("streams/%s", st.streamID)},
-		},
-	)
+	st.updateMetadata()
 
-	if err != nil {
-		slog.Error("Failed to update stream meta", "error", err)
-	}
+	// the timeline so far goes up either way: a worker taking the stream
+	// over continues it
+	st.publishVODManifest()
 
-	slog.Info("Stream finalized",
-		"streamId", st.streamID,
-		"segments", segmentCount,
-		"duration", totalDuration,
-	)
+	return segmentCount, totalDuration
 }
 
-func (st *SegmentTracker) parseChunkName(filename string) (rid int, seq int) {
+func (st *SegmentTracker) parseChunkName(filename string) (repId string, seq int) {
+	var rid int
 	_, _ = fmt.Sscanf(filename, "chunk-%d-%d.m4s", &rid, &seq)
-	return
+	return strconv.Itoa(rid), seq
 }
 
+// completeSeqLocked returns the highest sequence that every representation
+// of the layout, audio included, has uploaded.
+func (st *SegmentTracker) completeSeqLocked() int {
+	complete := -1
+	for i, repId := range st.layout.RepIDs() {
+		seq, ok := st.repSeq[repId]
+		if !ok {
+			return -1
+		}
+		if i == 0 || seq < complete {
+			complete = seq
+		}
+	}
+	return complete
+}
+
+// isFileStable reports whether a file kept its size and mtime since the
+// previous scan. Once FFmpeg has exited nothing is written anymore, so every
+// non-empty file counts as stable.
 func (st *SegmentTracker) isFileStable(path string) bool {
-	i1, err := os.Stat(path)
-	if err != nil {
+	info, err := os.Stat(path)
+	if err != nil || info.Size() == 0 {
 		return false
 	}
 
-	time.Sleep(100 * time.Millisecond)
-
-	i2, err := os.Stat(path)
-	if err != nil {
-		return false
+	if st.ctx.Err() != nil {
+		return true
 	}
 
-	return i1.Size() == i2.Size() &&
-		i1.ModTime().Equal(i2.ModTime()) &&
-		i2.Size() > 0
+	current := fileStamp{size: info.Size(), modTime: info.ModTime()}
+	previous, seen := st.observed[path]
+	st.observed[path] = current
+
+	return seen && previous.equal(current)
 }
 
 func (st *SegmentTracker) GetLastSegment() int {
@@ -296,8 +493,7 @@ func (st *SegmentTracker) cleanupOldLocalChunks() {
 		deleteBeforeSeq = uploadedSeq
 	}
 
-	chunkPattern := filepath.Join(st.streamDir, "chunk-*-*.m4s")
-	matches, err := filepath.Glob(chunkPattern)
+	matches, err := filepath.Glob(filepath.Join(st.streamDir, chunkPattern))
 	if err != nil {
 		return
 	}
@@ -306,15 +502,22 @@ func (st *SegmentTracker) cleanupOldLocalChunks() {
 	for _, path := range matches {
 		filename := filepath.Base(path)
 		_, seq := st.parseChunkName(filename)
-		if seq > 0 && seq < deleteBeforeSeq {
+		if seq > 0 && seq < deleteBeforeSeq && !st.journal.Contains(path) {
 			toDelete = append(toDelete, path)
 		}
 	}
 
 	sort.Strings(toDelete)
 	for _, path := range toDelete {
+		delete(st.observed, path)
+		delete(st.submitted, path)
 		if err := os.Remove(path); err == nil {
 			slog.Debug("Cleaned up old local chunk", "path", filepath.Base(path))
 		}
 	}
 }
+
+func matchName(pattern, name string) bool {
+	ok, _ := filepath.Match(pattern, name)
+	return ok
+}
@@ -0,0 +1,219 @@
+package ffmpeg
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/bitstream/backend-go/internal/domain/streaming/audit"
+)
+
+// Takeover continues a stream whose earlier segments are already stored,
+// numbering new segments after them, so viewers do not see the stream end
+// when it moves to another process.
+type Takeover struct {
+	// Ready is called once FFmpeg has written its first segment. It returns
+	// the last stored segment number to continue from, after the worker
+	// handing the stream over, if any, has stopped.
+	Ready func(ctx context.Context) (int, error)
+
+	// Load reads a file the stream stored earlier, by name.
+	Load func(ctx context.Context, filename string) ([]byte, error)
+
+	// Copy stores a copy of a file the stream stored earlier under another
+	// name.
+	Copy func(ctx context.Context, filename, copyName string) error
+}
+
+// KeptInitName is the name the init segment of a representation is kept
+// under when a takeover replaces it with a different one. The stored
+// segments up to seq, and after the previous one kept, decode with it.
+func KeptInitName(repID string, seq int) string {
+	return fmt.Sprintf("init-%s-%d.mp4", repID, seq)
+}
+
+var (
+	representationRe = regexp.MustCompile(`(?s)<Representation\b[^>]*?\bid="([^"]*)".*?</Representation>`)
+	startNumberRe    = regexp.MustCompile(`\bstartNumber="(\d+)"`)
+	timelineStartRe  = regexp.MustCompile(`(<S\b[^>]*?\bt=")(\d+)"`)
+	availabilityRe   = regexp.MustCompile(`\bavailabilityStartTime="[^"]*"`)
+)
+
+// takeOver holds the tracker back until FFmpeg has written its first
+// segment and Ready has returned, then picks up where the stored stream
+// ends. It reports false when the process ends first or Ready fails; nothing
+// may be uploaded then, the local numbers would overwrite stored segments.
+func (st *SegmentTracker) takeOver() bool {
+	first := filepath.Join(st.streamDir, fmt.Sprintf("chunk-%s-1.m4s", st.referenceRep()))
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for !st.isFileStable(first) {
+		select {
+		case <-st.ctx.Done():
+			return false
+		case <-ticker.C:
+		}
+	}
+
+	slog.Info("First segment written, taking the stream over", "streamId", st.streamID)
+
+	seq, err := st.takeover.Ready(st.ctx)
+	if err != nil {
+		slog.Error("Failed to take the stream over", "streamId", st.streamID, "error", err)
+		return false
+	}
+
+	st.seqOffset = max(seq, 0)
+	st.keepStoredInits()
+	if meta, err := st.queries.GetStreamMeta(st.ctx, st.streamID); err == nil {
+		st.baseDuration = meta.TotalDuration
+	}
+	st.loadStored()
+	st.saveCheckpoint()
+
+	// viewers are already watching: there is nothing to announce
+	st.playable = true
+	st.tookOver.Store(true)
+
+	slog.Info("Stream taken over", "streamId", st.streamID, "resumeAfter", st.seqOffset)
+	st.events.Record(st.streamID, audit.TakenOver, audit.Fields{"resumeAfter": st.seqOffset})
+	return true
+}
+
+// keepStoredInits keeps the stored init segment of every representation
+// this process writes a different one for, before its own replaces it: a
+// change of the ladder or the encoder settings leaves the stored segments
+// undecodable with the new one.
+func (st *SegmentTracker) keepStoredInits() {
+	if st.seqOffset == 0 {
+		return
+	}
+
+	for _, repId := range st.layout.RepIDs() {
+		name := fmt.Sprintf("init-%s.mp4", repId)
+
+		// a representation the stream did not have yet has nothing stored
+		stored, err := st.takeover.Load(st.ctx, name)
+		if err != nil {
+			continue
+		}
+		if local, err := os.ReadFile(filepath.Join(st.streamDir, name)); err == nil && bytes.Equal(local, stored) {
+			continue
+		}
+
+		if err := st.takeover.Copy(st.ctx, name, KeptInitName(repId, st.seqOffset)); err != nil {
+			slog.Warn("Failed to keep the stored init segment", "streamId", st.streamID, "repId", repId, "error", err)
+		}
+	}
+}
+
+// loadStored reads the live manifest viewers play and the timeline the
+// previous process recorded, so the published manifest and the VOD one
+// continue them. Without them the stream still continues, with a jump in
+// its timestamps.
+func (st *SegmentTracker) loadStored() {
+	if data, err := st.takeover.Load(st.ctx, ManifestName); err != nil {
+		slog.Warn("Failed to load the stored manifest", "streamId", st.streamID, "error", err)
+	} else if live, err := ParseManifest(data); err != nil {
+		slog.Warn("Failed to parse the stored manifest", "streamId", st.streamID, "error", err)
+	} else {
+		st.availabilityStart = live.AvailabilityStartTime
+	}
+
+	data, err := st.takeover.Load(st.ctx, VODManifestName)
+	if err != nil {
+		slog.Warn("Failed to load the stored timeline", "streamId", st.streamID, "error", err)
+		return
+	}
+
+	stored, err := ParseManifest(data)
+	if err != nil {
+		slog.Warn("Failed to parse the stored timeline", "streamId", st.streamID, "error", err)
+		return
+	}
+	st.seedTimeline(stored)
+}
+
+// seedTimeline starts the stream's full timeline from a VOD manifest.
+func (st *SegmentTracker) seedTimeline(stored *LiveManifest) {
+	for _, rep := range stored.Representations {
+		timeline := stored.Timelines[rep.ID]
+		st.vodRepOrder = append(st.vodRepOrder, rep.ID)
+		st.vodReps[rep.ID] = rep
+		st.timelines[rep.ID] = &timeline
+	}
+}
+
+// timeShiftFor returns how far the timestamps of a representation move so
+// its first segment starts where the stored timeline ends.
+func (st *SegmentTracker) timeShiftFor(repId string, timeline RepTimeline) int64 {
+	if shift, ok := st.timeShift[repId]; ok {
+		return shift
+	}
+
+	var shift int64
+	stored := st.timelines[repId]
+	if stored != nil && len(stored.Segments) > 0 && len(timeline.Segments) > 0 {
+		if stored.Timescale == timeline.Timescale {
+			last := stored.Segments[len(stored.Segments)-1]
+			shift = int64(last.Start+last.Duration) - int64(timeline.Segments[0].Start)
+		} else {
+			slog.Warn("Timescale changed on takeover, keeping timestamps",
+				"streamId", st.streamID,
+				"repId", repId,
+				"stored", stored.Timescale,
+				"current", timeline.Timescale,
+			)
+		}
+	}
+
+	st.timeShift[repId] = shift
+	st.saveCheckpoint()
+	return shift
+}
+
+// remoteName is the name a local file is stored under. Segments of a
+// taken-over stream are numbered after the stored ones.
+func (st *SegmentTracker) remoteName(filename string) string {
+	if st.seqOffset == 0 || !matchName(chunkPattern, filename) {
+		return filename
+	}
+	repId, seq := st.parseChunkName(filename)
+	return fmt.Sprintf("chunk-%s-%d.m4s", repId, seq+st.seqOffset)
+}
+
+// renumberManifest rewrites the muxer's manifest for a taken-over stream:
+// segment numbers and timestamps continue the stored ones, and the
+// availability start stays the one players already synchronized on.
+func (st *SegmentTracker) renumberManifest(data []byte) []byte {
+	if st.seqOffset == 0 {
+		return data
+	}
+
+	if st.availabilityStart != "" {
+		data = availabilityRe.ReplaceAll(data, []byte(`availabilityStartTime="`+st.availabilityStart+`"`))
+	}
+
+	return representationRe.ReplaceAllFunc(data, func(rep []byte) []byte {
+		repId := string(representationRe.FindSubmatch(rep)[1])
+		shift := st.timeShift[repId]
+
+		rep = startNumberRe.ReplaceAllFunc(rep, func(attr []byte) []byte {
+			number, _ := strconv.Atoi(string(startNumberRe.FindSubmatch(attr)[1]))
+			return fmt.Appendf(nil, `startNumber="%d"`, number+st.seqOffset)
+		})
+		return timelineStartRe.ReplaceAllFunc(rep, func(attr []byte) []byte {
+			m := timelineStartRe.FindSubmatch(attr)
+			t, _ := strconv.ParseInt(string(m[2]), 10, 64)
+			return fmt.Appendf(nil, `%s%d"`, m[1], t+shift)
+		})
+	})
+}
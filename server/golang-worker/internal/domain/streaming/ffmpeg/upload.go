@@ -0,0 +1,352 @@
+package ffmpeg
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/bitstream/backend-go/internal/domain/streaming/audit"
+	"github.com/bitstream/backend-go/internal/storage/uploader"
+)
+
+const (
+	contentTypeInit    = "video/mp4"
+	contentTypeSegment = "video/iso.segment"
+
+	// playlists and manifests change on every segment and must never be
+	// served stale by a cache in front of storage
+	cacheControlLive = "no-cache"
+
+	failedRetryInterval = 30 * time.Second
+)
+
+// uploadStamp identifies the version of a file that was submitted for
+// upload. Init segments also carry a content hash, since the muxer may rewrite
+// them in place with the same size.
+type uploadStamp struct {
+	fileStamp
+	sum [sha256.Size]byte
+}
+
+func (st *SegmentTracker) remotePath(filename string) string {
+	return fmt.Sprintf("streams/%s/%s", st.streamID, filename)
+}
+
+func (st *SegmentTracker) newJob(path, contentType string) uploader.Job {
+	return uploader.Job{
+		LocalPath:   path,
+		RemotePath:  st.remotePath(st.remoteName(filepath.Base(path))),
+		ContentType: contentType,
+	}
+}
+
+// enqueue hands a job to the upload pool. It blocks while the stream has
+// its share of uploads outstanding, processing results meanwhile.
+func (st *SegmentTracker) enqueue(job uploader.Job) {
+	if err := st.uploads.Enqueue(context.Background(), job, st.handleResult); err != nil {
+		slog.Error("Failed to enqueue upload", "file", job.LocalPath, "error", err)
+	}
+}
+
+// enqueueDurable records the job in the stream's journal before uploading
+// it, so it is retried after a restart if storage never confirms it.
+func (st *SegmentTracker) enqueueDurable(job uploader.Job) {
+	if err := st.journal.Add(job); err != nil {
+		slog.Error("Failed to journal upload", "file", job.LocalPath, "error", err)
+	}
+	if matchName(chunkPattern, filepath.Base(job.LocalPath)) {
+		st.chunksInFlight++
+	}
+	st.enqueue(job)
+}
+
+func (st *SegmentTracker) uploadInit(path string) {
+	stamp, changed := st.needsUpload(path, true)
+	if !changed {
+		return
+	}
+	st.submitted[path] = stamp
+	st.enqueueDurable(st.newJob(path, contentTypeInit))
+}
+
+func (st *SegmentTracker) uploadChunk(path string) {
+	stamp, changed := st.needsUpload(path, false)
+	if !changed {
+		return
+	}
+	st.submitted[path] = stamp
+	st.enqueueDurable(st.newJob(path, contentTypeSegment))
+}
+
+// needsUpload reports whether path is new or changed since it was last
+// submitted. Size and mtime decide first; with hashContent a changed stamp
+// only counts if the content hash differs too.
+func (st *SegmentTracker) needsUpload(path string, hashContent bool) (uploadStamp, bool) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return uploadStamp{}, false
+	}
+
+	stamp := uploadStamp{fileStamp: fileStamp{size: info.Size(), modTime: info.ModTime()}}
+	previous, seen := st.submitted[path]
+	if seen && previous.fileStamp.equal(stamp.fileStamp) {
+		return stamp, false
+	}
+
+	if !hashContent {
+		return stamp, true
+	}
+
+	sum, err := hashFile(path)
+	if err != nil {
+		slog.Warn("Failed to hash file, uploading anyway", "file", path, "error", err)
+		return stamp, true
+	}
+	stamp.sum = sum
+
+	if seen && previous.sum == sum {
+		st.submitted[path] = stamp
+		return stamp, false
+	}
+	return stamp, true
+}
+
+func hashFile(path string) ([sha256.Size]byte, error) {
+	var sum [sha256.Size]byte
+
+	f, err := os.Open(path)
+	if err != nil {
+		return sum, err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return sum, err
+	}
+	copy(sum[:], h.Sum(nil))
+	return sum, nil
+}
+
+// replayJournal re-submits the uploads a previous run of this stream left
+// unconfirmed.
+func (st *SegmentTracker) replayJournal() {
+	pending := st.journal.Pending()
+	if len(pending) == 0 {
+		return
+	}
+
+	slog.Info("Replaying upload journal", "streamId", st.streamID, "pending", len(pending))
+	for _, job := range pending {
+		st.failed[job.RemotePath] = job
+	}
+	st.retryFailed()
+}
+
+// retryFailed re-submits uploads the pool gave up on. They stay in the
+// journal meanwhile, so their local files are kept.
+func (st *SegmentTracker) retryFailed() {
+	if len(st.failed) == 0 {
+		return
+	}
+
+	failed := st.failed
+	st.failed = make(map[string]uploader.Job)
+
+	for _, job := range failed {
+		info, err := os.Stat(job.LocalPath)
+		if errors.Is(err, os.ErrNotExist) {
+			slog.Warn("Failed upload no longer exists locally, dropping", "file", job.LocalPath)
+			_ = st.journal.Remove(job.RemotePath)
+			delete(st.submitted, job.LocalPath)
+			st.dropChunk(job.LocalPath)
+			continue
+		}
+		if _, seen := st.submitted[job.LocalPath]; !seen && err == nil {
+			// journaled by a previous run: keep the scans from submitting it twice
+			st.submitted[job.LocalPath] = uploadStamp{fileStamp: fileStamp{size: info.Size(), modTime: info.ModTime()}}
+		}
+		st.enqueueDurable(job)
+	}
+}
+
+// uploadPlaylists publishes the HLS master and media playlists. They are
+// rewritten by the muxer on every segment, so they go up after the chunks
+// they reference.
+func (st *SegmentTracker) uploadPlaylists() {
+	mediaMatches, _ := filepath.Glob(filepath.Join(st.streamDir, HLSMediaPattern))
+	playlists := append([]string{filepath.Join(st.streamDir, HLSMasterName)}, mediaMatches...)
+
+	for _, path := range playlists {
+		if _, err := os.Stat(path); err != nil {
+			continue
+		}
+		st.pendingPlaylists[path] = struct{}{}
+	}
+
+	st.flushPlaylists()
+}
+
+// flushPlaylists uploads the playlists waiting on chunk uploads once none
+// are in flight, so a playlist never lists a segment that is not in storage.
+func (st *SegmentTracker) flushPlaylists() {
+	if st.chunksInFlight > 0 || len(st.pendingPlaylists) == 0 {
+		return
+	}
+
+	pending := st.pendingPlaylists
+	st.pendingPlaylists = make(map[string]struct{})
+
+	for path := range pending {
+		job := st.newJob(path, hlsPlaylistMime)
+		job.CacheControl = cacheControlLive
+		st.enqueue(job)
+	}
+}
+
+func (st *SegmentTracker) handleResult(r uploader.Result) {
+	switch r.Job.RemotePath {
+	case st.remotePath(ManifestName):
+		st.handleManifestResult(r.Err)
+		return
+	case st.remotePath(VODManifestName):
+		if r.Err != nil {
+			slog.Error("Failed to upload VOD manifest", "streamId", st.streamID, "error", r.Err)
+		}
+		st.vodPublished = r.Err == nil
+		return
+	}
+
+	filename := filepath.Base(r.Job.LocalPath)
+	isChunk := matchName(chunkPattern, filename)
+
+	if isChunk {
+		st.chunksInFlight--
+	}
+
+	durable := isChunk || matchName(initPattern, filename)
+
+	if r.Err != nil {
+		slog.Error("Failed to upload file", "file", r.Job.LocalPath, "error", r.Err)
+		if durable && !errors.Is(r.Err, os.ErrNotExist) {
+			if len(st.failed) == 0 {
+				// one event per outage, not per object
+				st.events.Record(st.streamID, audit.UploadFailed, audit.Fields{
+					"file":  filename,
+					"error": r.Err.Error(),
+				})
+			}
+			st.failed[r.Job.RemotePath] = r.Job
+		} else if durable {
+			_ = st.journal.Remove(r.Job.RemotePath)
+			delete(st.submitted, r.Job.LocalPath)
+			st.dropChunk(r.Job.LocalPath)
+		}
+		return
+	}
+
+	if durable {
+		if err := st.journal.Remove(r.Job.RemotePath); err != nil {
+			slog.Error("Failed to update upload journal", "error", err)
+		}
+	}
+
+	switch {
+	case isChunk:
+		repId, seq := st.parseChunkName(filename)
+		slog.Info("Uploaded segment", "repId", repId, "seq", seq)
+		st.markLanded(repId, seq)
+	case matchName(initPattern, filename):
+		slog.Info("Uploaded init segment", "file", filename)
+	}
+
+	st.flushPlaylists()
+}
+
+// markLanded records an uploaded chunk and advances the representation's
+// watermark.
+func (st *SegmentTracker) markLanded(repId string, seq int) {
+	st.measureSegment(repId, seq)
+	st.resolveSeq(repId, seq)
+}
+
+// dropChunk lets the watermark pass a chunk whose local file is gone before
+// it was uploaded. It never lands, and every later chunk would wait for it.
+func (st *SegmentTracker) dropChunk(localPath string) {
+	filename := filepath.Base(localPath)
+	if !matchName(chunkPattern, filename) {
+		return
+	}
+
+	repId, seq := st.parseChunkName(filename)
+	slog.Warn("Segment lost before upload, skipping it", "streamId", st.streamID, "repId", repId, "seq", seq)
+	st.resolveSeq(repId, seq)
+}
+
+// resolveSeq records a chunk that landed or was dropped, and advances the
+// representation's watermark over every contiguous sequence resolved.
+// Uploads finish out of order, so a sequence only counts once all lower ones
+// are resolved. One the muxer removed before a scan saw it is never
+// submitted, so it is passed once a later one has landed.
+func (st *SegmentTracker) resolveSeq(repId string, seq int) {
+	st.mu.Lock()
+
+	if st.landed[repId] == nil {
+		st.landed[repId] = make(map[int]bool)
+	}
+	st.landed[repId][seq] = true
+
+	watermark := st.repSeq[repId]
+	for {
+		next := watermark + 1
+		if st.landed[repId][next] {
+			delete(st.landed[repId], next)
+		} else if len(st.landed[repId]) > 0 && st.unseenGoneLocked(repId, next) {
+			slog.Warn("Segment removed before it was uploaded, skipping it", "streamId", st.streamID, "repId", repId, "seq", next)
+		} else {
+			break
+		}
+		watermark = next
+	}
+	if watermark > 0 {
+		st.repSeq[repId] = watermark
+	}
+
+	previous := st.lastSegmentSeq
+	st.lastSegmentSeq = st.completeSeqLocked()
+	st.uploadedSeq = st.lastSegmentSeq
+	current := st.lastSegmentSeq
+
+	st.mu.Unlock()
+
+	if current >= 1 && !st.firstSegUploaded {
+		slog.Info("First segment landed", "streamId", st.streamID)
+		st.firstSegUploaded = true
+		st.events.Record(st.streamID, audit.FirstSegment, audit.Fields{"seq": current})
+	}
+
+	if current/5 > previous/5 {
+		st.updateMetadata()
+	}
+
+	if st.manifestPending {
+		st.publishManifest()
+	}
+}
+
+// unseenGoneLocked reports whether a chunk was never submitted and its file
+// no longer exists.
+func (st *SegmentTracker) unseenGoneLocked(repId string, seq int) bool {
+	path := filepath.Join(st.streamDir, fmt.Sprintf("chunk-%s-%d.m4s", repId, seq))
+	if _, submitted := st.submitted[path]; submitted {
+		return false
+	}
+	_, err := os.Stat(path)
+	return errors.Is(err, os.ErrNotExist)
+}
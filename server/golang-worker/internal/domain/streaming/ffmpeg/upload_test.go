@@ -0,0 +1,84 @@
+package ffmpeg
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func newWatermarkTracker(t *testing.T) *SegmentTracker {
+	t.Helper()
+	return &SegmentTracker{
+		streamID:  "stream",
+		streamDir: t.TempDir(),
+		repSeq:    make(map[string]int),
+		landed:    make(map[string]map[int]bool),
+		submitted: make(map[string]uploadStamp),
+	}
+}
+
+func TestResolveSeqMissingChunk(t *testing.T) {
+	t.Run("dropped upload", func(t *testing.T) {
+		st := newWatermarkTracker(t)
+		missing := filepath.Join(st.streamDir, "chunk-0-2.m4s")
+		st.submitted[missing] = uploadStamp{}
+
+		st.resolveSeq("0", 1)
+		st.resolveSeq("0", 3)
+		if got := st.repSeq["0"]; got != 1 {
+			t.Fatalf("watermark = %d while chunk 2 is uploading, want 1", got)
+		}
+
+		// its upload fails because the file is gone
+		delete(st.submitted, missing)
+		st.dropChunk(missing)
+		if got := st.repSeq["0"]; got != 3 {
+			t.Errorf("watermark = %d, want 3 past the dropped chunk", got)
+		}
+	})
+
+	t.Run("removed before a scan saw it", func(t *testing.T) {
+		st := newWatermarkTracker(t)
+
+		st.resolveSeq("0", 1)
+		st.resolveSeq("0", 3)
+		st.resolveSeq("0", 4)
+		if got := st.repSeq["0"]; got != 4 {
+			t.Errorf("watermark = %d, want 4 past the chunk never seen", got)
+		}
+	})
+
+	t.Run("written but not submitted yet", func(t *testing.T) {
+		st := newWatermarkTracker(t)
+		if err := os.WriteFile(filepath.Join(st.streamDir, "chunk-0-2.m4s"), []byte("segment"), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		st.resolveSeq("0", 1)
+		st.resolveSeq("0", 3)
+		if got := st.repSeq["0"]; got != 1 {
+			t.Errorf("watermark = %d, want 1 until chunk 2 lands", got)
+		}
+	})
+
+	t.Run("nothing above the watermark", func(t *testing.T) {
+		st := newWatermarkTracker(t)
+
+		// the next chunk is simply not written yet
+		st.resolveSeq("0", 1)
+		if got := st.repSeq["0"]; got != 1 {
+			t.Errorf("watermark = %d, want 1", got)
+		}
+		if len(st.landed["0"]) != 0 {
+			t.Errorf("landed = %v, want nothing pending", st.landed["0"])
+		}
+	})
+}
+
+func TestDropChunkIgnoresOtherFiles(t *testing.T) {
+	st := newWatermarkTracker(t)
+	st.dropChunk(filepath.Join(st.streamDir, "init-0.mp4"))
+	if len(st.repSeq) != 0 || len(st.landed) != 0 {
+		t.Errorf("dropping an init segment moved a watermark: %v %v", st.repSeq, st.landed)
+	}
+}
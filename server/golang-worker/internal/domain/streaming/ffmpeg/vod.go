@@ -0,0 +1,269 @@
+package ffmpeg
+
+import (
+	"context"
+	"database/sql"
+	"encoding/xml"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+
+	stream "github.com/bitstream/backend-go/internal/db/generated"
+	"github.com/bitstream/backend-go/internal/domain/streaming/lifecycle"
+)
+
+const VODManifestName = "vod.mpd"
+
+// recordTimeline folds the segments of a live manifest into the stream's
+// full timeline. The live manifest only keeps a sliding window, so every
+// version is merged as it is seen. A taken-over stream continues the stored
+// timeline, with numbers and timestamps moved past its end. Segments that
+// slid out of the window unseen leave a gap, which the static manifest
+// cannot number around.
+func (st *SegmentTracker) recordTimeline(manifest *LiveManifest) {
+	grown := false
+	for _, rep := range manifest.Representations {
+		if _, seen := st.vodReps[rep.ID]; !seen {
+			st.vodRepOrder = append(st.vodRepOrder, rep.ID)
+		}
+		st.vodReps[rep.ID] = rep
+	}
+
+	for repId, timeline := range manifest.Timelines {
+		full, ok := st.timelines[repId]
+		if !ok {
+			full = &RepTimeline{Timescale: timeline.Timescale}
+			st.timelines[repId] = full
+		}
+
+		var shift int64
+		if st.seqOffset > 0 {
+			shift = st.timeShiftFor(repId, timeline)
+		}
+
+		last := full.LastNumber()
+		for _, seg := range timeline.Segments {
+			seg.Number += st.seqOffset
+			seg.Start = uint64(int64(seg.Start) + shift)
+			if seg.Number <= last {
+				continue
+			}
+			if last > 0 && seg.Number != last+1 {
+				slog.Error("Segments missing from the stream timeline",
+					"streamId", st.streamID,
+					"repId", repId,
+					"after", last,
+					"next", seg.Number,
+				)
+			}
+			full.Segments = append(full.Segments, seg)
+			last = seg.Number
+			grown = true
+		}
+	}
+
+	if grown {
+		st.saveTimeline()
+	}
+}
+
+// timelineGaps lists the representations whose timeline skips segment
+// numbers.
+func (st *SegmentTracker) timelineGaps() []string {
+	var gaps []string
+	for _, repId := range st.vodRepOrder {
+		timeline := st.timelines[repId]
+		if timeline == nil {
+			continue
+		}
+		for i := 1; i < len(timeline.Segments); i++ {
+			if timeline.Segments[i].Number != timeline.Segments[i-1].Number+1 {
+				gaps = append(gaps, repId)
+				break
+			}
+		}
+	}
+	return gaps
+}
+
+// vodDuration is the length of the longest representation, in seconds.
+func (st *SegmentTracker) vodDuration() float64 {
+	var longest float64
+	for _, timeline := range st.timelines {
+		if len(timeline.Segments) == 0 {
+			continue
+		}
+		first := timeline.Segments[0]
+		last := timeline.Segments[len(timeline.Segments)-1]
+		seconds := float64(last.Start+last.Duration-first.Start) / float64(timeline.Timescale)
+		longest = max(longest, seconds)
+	}
+	return longest
+}
+
+// renderVODManifest writes a static MPD listing every segment of the
+// stream with its real duration, keeping the timestamps FFmpeg produced so
+// audio and video stay aligned.
+func (st *SegmentTracker) renderVODManifest() []byte {
+	var b strings.Builder
+
+	b.WriteString(`<?xml version="1.0" encoding="utf-8"?>` + "\n")
+	b.WriteString(`<MPD xmlns="urn:mpeg:dash:schema:mpd:2011"` + "\n")
+	b.WriteString(`     profiles="urn:mpeg:dash:profile:isoff-live:2011"` + "\n")
+	b.WriteString(`     type="static"` + "\n")
+	fmt.Fprintf(&b, `     mediaPresentationDuration="PT%.3fS"`+"\n", st.vodDuration())
+	b.WriteString(`     minBufferTime="PT4S">` + "\n")
+	b.WriteString(`  <Period id="0" start="PT0S">` + "\n")
+
+	// one adaptation set per content type and language, in manifest order
+	type setKey struct{ contentType, lang string }
+	var order []setKey
+	sets := make(map[setKey][]ManifestRepresentation)
+	for _, repId := range st.vodRepOrder {
+		rep := st.vodReps[repId]
+		if timeline := st.timelines[repId]; timeline == nil || len(timeline.Segments) == 0 {
+			continue
+		}
+		key := setKey{rep.ContentType, rep.Lang}
+		if _, ok := sets[key]; !ok {
+			order = append(order, key)
+		}
+		sets[key] = append(sets[key], rep)
+	}
+
+	for i, key := range order {
+		fmt.Fprintf(&b, `    <AdaptationSet id="%d"%s%s segmentAlignment="true">`+"\n",
+			i, xmlAttr("contentType", key.contentType), xmlAttr("lang", key.lang))
+
+		for _, rep := range sets[key] {
+			st.renderRepresentation(&b, rep)
+		}
+
+		b.WriteString("    </AdaptationSet>\n")
+	}
+
+	b.WriteString("  </Period>\n")
+	b.WriteString("</MPD>\n")
+
+	return []byte(b.String())
+}
+
+func (st *SegmentTracker) renderRepresentation(b *strings.Builder, rep ManifestRepresentation) {
+	timeline := st.timelines[rep.ID]
+
+	fmt.Fprintf(b, `      <Representation%s%s%s%s%s%s%s%s%s>`+"\n",
+		xmlAttr("id", rep.ID),
+		xmlAttr("mimeType", rep.MimeType),
+		xmlAttr("codecs", rep.Codecs),
+		xmlAttr("bandwidth", rep.Bandwidth),
+		xmlAttr("width", rep.Width),
+		xmlAttr("height", rep.Height),
+		xmlAttr("frameRate", rep.FrameRate),
+		xmlAttr("sar", rep.Sar),
+		xmlAttr("audioSamplingRate", rep.AudioSamplingRate),
+	)
+
+	if rep.AudioChannels != nil {
+		fmt.Fprintf(b, `        <AudioChannelConfiguration%s%s />`+"\n",
+			xmlAttr("schemeIdUri", rep.AudioChannels.SchemeIDURI),
+			xmlAttr("value", rep.AudioChannels.Value),
+		)
+	}
+
+	fmt.Fprintf(b, `        <SegmentTemplate timescale="%d"%s%s startNumber="%d">`+"\n",
+		timeline.Timescale,
+		xmlAttr("initialization", rep.Initialization),
+		xmlAttr("media", rep.Media),
+		timeline.Segments[0].Number,
+	)
+	b.WriteString("          <SegmentTimeline>\n")
+
+	// runs of equal, contiguous durations collapse into one S with a repeat
+	segments := timeline.Segments
+	for i := 0; i < len(segments); {
+		run := 1
+		for i+run < len(segments) &&
+			segments[i+run].Duration == segments[i].Duration &&
+			segments[i+run].Start == segments[i+run-1].Start+segments[i+run-1].Duration {
+			run++
+		}
+
+		if run > 1 {
+			fmt.Fprintf(b, `            <S t="%d" d="%d" r="%d" />`+"\n", segments[i].Start, segments[i].Duration, run-1)
+		} else {
+			fmt.Fprintf(b, `            <S t="%d" d="%d" />`+"\n", segments[i].Start, segments[i].Duration)
+		}
+		i += run
+	}
+
+	b.WriteString("          </SegmentTimeline>\n")
+	b.WriteString("        </SegmentTemplate>\n")
+	b.WriteString("      </Representation>\n")
+}
+
+// publishVODManifest uploads the static manifest next to the segments.
+// Its path is recorded on the stream meta when the stream is finalized, so
+// replays no longer depend on a manifest synthesized from counters.
+func (st *SegmentTracker) publishVODManifest() {
+	if len(st.timelines) == 0 {
+		slog.Warn("No segment timeline recorded, skipping VOD manifest", "streamId", st.streamID)
+		return
+	}
+	// numbered implicitly, every segment after a gap would point at the
+	// wrong file
+	if gaps := st.timelineGaps(); len(gaps) > 0 {
+		slog.Error("Stream timeline has gaps, skipping VOD manifest", "streamId", st.streamID, "repIds", gaps)
+		return
+	}
+
+	path := filepath.Join(st.streamDir, VODManifestName)
+	if err := os.WriteFile(path, st.renderVODManifest(), 0644); err != nil {
+		slog.Error("Failed to write VOD manifest", "streamId", st.streamID, "error", err)
+		return
+	}
+
+	st.vodPublished = false
+	st.enqueue(st.newJob(path, dashManifestMime))
+	st.uploads.Drain(st.handleResult)
+
+	if st.vodPublished {
+		slog.Info("VOD manifest published", "streamId", st.streamID, "duration", st.vodDuration())
+	}
+}
+
+// recordFinalized records the VOD manifest path and announces the finished
+// stream in one transaction.
+func (st *SegmentTracker) recordFinalized(segmentCount int, totalDuration float64) {
+	ctx := context.Background()
+	data := lifecycle.Data{"segments": segmentCount, "duration": totalDuration}
+
+	err := st.notify.Tx(ctx, func(q *stream.Queries) error {
+		if st.vodPublished {
+			path := st.remotePath(VODManifestName)
+			err := q.SetStreamMetaVodManifest(ctx, stream.SetStreamMetaVodManifestParams{
+				StreamId:        st.streamID,
+				VodManifestPath: sql.NullString{Valid: true, String: path},
+			})
+			if err != nil {
+				return err
+			}
+			data["vodManifestPath"] = path
+		}
+		return st.notify.Emit(ctx, q, st.streamID, lifecycle.VODFinalized, data)
+	})
+	if err != nil {
+		slog.Error("Failed to record finalized stream", "streamId", st.streamID, "error", err)
+	}
+}
+
+func xmlAttr(name, value string) string {
+	if value == "" {
+		return ""
+	}
+
+	var escaped strings.Builder
+	_ = xml.EscapeText(&escaped, []byte(value))
+	return fmt.Sprintf(` %s="%s"`, name, escaped.String())
+}
@@ -0,0 +1,240 @@
+package ffmpeg
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func newTimelineTracker(t *testing.T) *SegmentTracker {
+	t.Helper()
+	return &SegmentTracker{
+		streamID:  "stream",
+		streamDir: t.TempDir(),
+		timelines: make(map[string]*RepTimeline),
+		vodReps:   make(map[string]ManifestRepresentation),
+		timeShift: make(map[string]int64),
+	}
+}
+
+var (
+	videoRep = ManifestRepresentation{
+		ID:             "0",
+		ContentType:    "video",
+		MimeType:       "video/mp4",
+		Codecs:         "avc1.64001f",
+		Bandwidth:      "3000000",
+		Width:          "1280",
+		Height:         "720",
+		Initialization: "init-$RepresentationID$.mp4",
+		Media:          "chunk-$RepresentationID$-$Number$.m4s",
+	}
+	audioRep = ManifestRepresentation{
+		ID:                "1",
+		ContentType:       "audio",
+		Lang:              "en",
+		MimeType:          "audio/mp4",
+		Codecs:            "mp4a.40.2",
+		Bandwidth:         "128000",
+		AudioSamplingRate: "48000",
+		AudioChannels:     &mpdDescriptor{SchemeIDURI: "urn:mpeg:dash:23003:3:audio_channel_configuration:2011", Value: "2"},
+		Initialization:    "init-$RepresentationID$.mp4",
+		Media:             "chunk-$RepresentationID$-$Number$.m4s",
+	}
+)
+
+// liveWindow is a live manifest listing segments first..last, all of them
+// 2s long except video segment 3.
+func liveWindow(first, last int) *LiveManifest {
+	video := RepTimeline{Timescale: 90000}
+	audio := RepTimeline{Timescale: 48000}
+	for n := first; n <= last; n++ {
+		start := uint64(n-1) * 180000
+		duration := uint64(180000)
+		if n == 3 {
+			duration = 171000
+		} else if n > 3 {
+			start -= 9000
+		}
+		video.Segments = append(video.Segments, TimelineSegment{Number: n, Start: start, Duration: duration})
+		audio.Segments = append(audio.Segments, TimelineSegment{Number: n, Start: uint64(n-1) * 96000, Duration: 96000})
+	}
+
+	return &LiveManifest{
+		Dynamic:         true,
+		Timelines:       map[string]RepTimeline{"0": video, "1": audio},
+		Representations: []ManifestRepresentation{videoRep, audioRep},
+	}
+}
+
+func TestRenderVODManifest(t *testing.T) {
+	st := newTimelineTracker(t)
+
+	// the live window slides, segments 2 and 3 are seen twice
+	st.recordTimeline(liveWindow(1, 3))
+	st.recordTimeline(liveWindow(2, 4))
+
+	want := strings.Join([]string{
+		`<?xml version="1.0" encoding="utf-8"?>`,
+		`<MPD xmlns="urn:mpeg:dash:schema:mpd:2011"`,
+		`     profiles="urn:mpeg:dash:profile:isoff-live:2011"`,
+		`     type="static"`,
+		`     mediaPresentationDuration="PT8.000S"`,
+		`     minBufferTime="PT4S">`,
+		`  <Period id="0" start="PT0S">`,
+		`    <AdaptationSet id="0" contentType="video" segmentAlignment="true">`,
+		`      <Representation id="0" mimeType="video/mp4" codecs="avc1.64001f" bandwidth="3000000" width="1280" height="720">`,
+		`        <SegmentTemplate timescale="90000" initialization="init-$RepresentationID$.mp4" media="chunk-$RepresentationID$-$Number$.m4s" startNumber="1">`,
+		`          <SegmentTimeline>`,
+		`            <S t="0" d="180000" r="1" />`,
+		`            <S t="360000" d="171000" />`,
+		`            <S t="531000" d="180000" />`,
+		`          </SegmentTimeline>`,
+		`        </SegmentTemplate>`,
+		`      </Representation>`,
+		`    </AdaptationSet>`,
+		`    <AdaptationSet id="1" contentType="audio" lang="en" segmentAlignment="true">`,
+		`      <Representation id="1" mimeType="audio/mp4" codecs="mp4a.40.2" bandwidth="128000" audioSamplingRate="48000">`,
+		`        <AudioChannelConfiguration schemeIdUri="urn:mpeg:dash:23003:3:audio_channel_configuration:2011" value="2" />`,
+		`        <SegmentTemplate timescale="48000" initialization="init-$RepresentationID$.mp4" media="chunk-$RepresentationID$-$Number$.m4s" startNumber="1">`,
+		`          <SegmentTimeline>`,
+		`            <S t="0" d="96000" r="3" />`,
+		`          </SegmentTimeline>`,
+		`        </SegmentTemplate>`,
+		`      </Representation>`,
+		`    </AdaptationSet>`,
+		`  </Period>`,
+		`</MPD>`,
+	}, "\n") + "\n"
+
+	got := string(st.renderVODManifest())
+	if got != want {
+		t.Errorf("renderVODManifest() =\n%s\nwant\n%s", got, want)
+	}
+
+	// the timeline is saved as it grows
+	saved, err := os.ReadFile(filepath.Join(st.streamDir, VODManifestName))
+	if err != nil {
+		t.Fatalf("timeline not saved: %v", err)
+	}
+	if string(saved) != want {
+		t.Errorf("saved timeline =\n%s\nwant\n%s", saved, want)
+	}
+}
+
+func TestRenderVODManifestRoundTrip(t *testing.T) {
+	st := newTimelineTracker(t)
+	live := liveWindow(1, 5)
+	st.recordTimeline(live)
+
+	parsed, err := ParseManifest(st.renderVODManifest())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parsed.Dynamic {
+		t.Error("VOD manifest is dynamic")
+	}
+	if !reflect.DeepEqual(parsed.Timelines, live.Timelines) {
+		t.Errorf("parsed timelines = %+v, want %+v", parsed.Timelines, live.Timelines)
+	}
+	if !reflect.DeepEqual(parsed.Representations, live.Representations) {
+		t.Errorf("parsed representations = %+v, want %+v", parsed.Representations, live.Representations)
+	}
+}
+
+func TestRecordTimelineTakeover(t *testing.T) {
+	st := newTimelineTracker(t)
+	st.recordTimeline(liveWindow(1, 2))
+
+	// the new process numbers and timestamps from the start again
+	st.seqOffset = 2
+	st.recordTimeline(liveWindow(1, 2))
+
+	video := st.timelines["0"]
+	want := []TimelineSegment{
+		{Number: 1, Start: 0, Duration: 180000},
+		{Number: 2, Start: 180000, Duration: 180000},
+		{Number: 3, Start: 360000, Duration: 180000},
+		{Number: 4, Start: 540000, Duration: 180000},
+	}
+	if !reflect.DeepEqual(video.Segments, want) {
+		t.Errorf("video timeline = %+v, want %+v", video.Segments, want)
+	}
+	if got := st.vodDuration(); got != 8 {
+		t.Errorf("vodDuration() = %v, want 8", got)
+	}
+}
+
+func TestRenderVODManifestSkipsEmpty(t *testing.T) {
+	st := newTimelineTracker(t)
+	live := liveWindow(1, 1)
+	live.Timelines["1"] = RepTimeline{Timescale: 48000}
+	st.recordTimeline(live)
+
+	got := string(st.renderVODManifest())
+	if strings.Contains(got, `contentType="audio"`) {
+		t.Errorf("representation without segments was listed:\n%s", got)
+	}
+	if !strings.Contains(got, `mediaPresentationDuration="PT2.000S"`) {
+		t.Errorf("wrong duration:\n%s", got)
+	}
+}
+
+func TestXMLAttr(t *testing.T) {
+	if got := xmlAttr("lang", ""); got != "" {
+		t.Errorf(`xmlAttr("lang", "") = %q, want nothing`, got)
+	}
+	if got, want := xmlAttr("media", `a&b<"c">`), ` media="a&amp;b&lt;&#34;c&#34;&gt;"`; got != want {
+		t.Errorf("xmlAttr() = %q, want %q", got, want)
+	}
+}
+
+func TestRecordTimelineGap(t *testing.T) {
+	st := newTimelineTracker(t)
+	st.recordTimeline(liveWindow(1, 2))
+	saved, err := os.ReadFile(filepath.Join(st.streamDir, VODManifestName))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// segments 3 and 4 slid out of the window unseen
+	st.recordTimeline(liveWindow(5, 6))
+
+	if gaps := st.timelineGaps(); !reflect.DeepEqual(gaps, []string{"0", "1"}) {
+		t.Errorf("timelineGaps() = %v, want [0 1]", gaps)
+	}
+	if got := st.timelines["0"].LastNumber(); got != 6 {
+		t.Errorf("last recorded segment = %d, want 6", got)
+	}
+
+	// the copy without the gap is kept
+	now, err := os.ReadFile(filepath.Join(st.streamDir, VODManifestName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(now) != string(saved) {
+		t.Errorf("saved timeline with a gap:\n%s", now)
+	}
+}
+
+func TestPublishManifestRecordsWhileUploading(t *testing.T) {
+	st := newTimelineTracker(t)
+
+	source := newTimelineTracker(t)
+	source.recordTimeline(liveWindow(1, 3))
+	if err := os.WriteFile(filepath.Join(st.streamDir, ManifestName), source.renderVODManifest(), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	st.manifestInFlight = true
+	st.publishManifest()
+
+	if !st.manifestPending {
+		t.Error("manifest seen during an upload is not left pending")
+	}
+	if got := st.timelines["0"].LastNumber(); got != 3 {
+		t.Errorf("last recorded segment = %d, want 3", got)
+	}
+}
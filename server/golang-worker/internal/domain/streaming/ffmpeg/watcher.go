@@ -0,0 +1,12 @@
+package ffmpeg
+
+import "errors"
+
+var errWatchUnsupported = errors.New("directory watch is not supported on this platform")
+
+// watchEvent names a file the muxer finished writing, or reports that the
+// kernel dropped events and the directory has to be rescanned.
+type watchEvent struct {
+	name     string
+	overflow bool
+}
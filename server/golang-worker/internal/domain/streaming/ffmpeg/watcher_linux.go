@@ -0,0 +1,90 @@
+//go:build linux
+
+package ffmpeg
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// watchStreamDir reports files in dir once they are closed after writing
+// (IN_CLOSE_WRITE) or renamed into place (IN_MOVED_TO). These are exactly the
+// moments a segment or manifest is complete, which portable watchers such as
+// fsnotify do not expose. The channel is closed when ctx is done.
+func watchStreamDir(ctx context.Context, dir string) (<-chan watchEvent, error) {
+	fd, err := unix.InotifyInit1(unix.IN_CLOEXEC | unix.IN_NONBLOCK)
+	if err != nil {
+		return nil, fmt.Errorf("inotify init: %w", err)
+	}
+
+	if _, err := unix.InotifyAddWatch(fd, dir, unix.IN_CLOSE_WRITE|unix.IN_MOVED_TO); err != nil {
+		_ = unix.Close(fd)
+		return nil, fmt.Errorf("inotify watch %s: %w", dir, err)
+	}
+
+	// a non-blocking fd is registered with the runtime poller, so Close
+	// unblocks a pending Read
+	file := os.NewFile(uintptr(fd), "inotify")
+	events := make(chan watchEvent, 64)
+
+	go func() {
+		<-ctx.Done()
+		_ = file.Close()
+	}()
+
+	go func() {
+		defer close(events)
+
+		buf := make([]byte, 64*(unix.SizeofInotifyEvent+unix.NAME_MAX+1))
+		for {
+			n, err := file.Read(buf)
+			if err != nil {
+				if ctx.Err() == nil {
+					slog.Error("inotify read failed", "dir", dir, "error", err)
+				}
+				return
+			}
+
+			for _, ev := range parseInotifyEvents(buf[:n]) {
+				select {
+				case events <- ev:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return events, nil
+}
+
+func parseInotifyEvents(buf []byte) []watchEvent {
+	var events []watchEvent
+
+	for offset := 0; offset+unix.SizeofInotifyEvent <= len(buf); {
+		mask := binary.NativeEndian.Uint32(buf[offset+4:])
+		nameLen := int(binary.NativeEndian.Uint32(buf[offset+12:]))
+
+		nameStart := offset + unix.SizeofInotifyEvent
+		if nameStart+nameLen > len(buf) {
+			break
+		}
+		name := string(bytes.TrimRight(buf[nameStart:nameStart+nameLen], "\x00"))
+		offset = nameStart + nameLen
+
+		switch {
+		case mask&unix.IN_Q_OVERFLOW != 0:
+			events = append(events, watchEvent{overflow: true})
+		case name != "":
+			events = append(events, watchEvent{name: name})
+		}
+	}
+
+	return events
+}
@@ -0,0 +1,9 @@
+//go:build !linux
+
+package ffmpeg
+
+import "context"
+
+func watchStreamDir(ctx context.Context, dir string) (<-chan watchEvent, error) {
+	return nil, errWatchUnsupported
+}
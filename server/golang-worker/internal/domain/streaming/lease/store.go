@@ -0,0 +1,177 @@
+package lease
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	stream "github.com/bitstream/backend-go/internal/db/generated"
+)
+
+const (
+	// TTL is how long a lease outlives its last renewal. The streams of a
+	// worker that dies are taken over by others after at most this long.
+	TTL = 30 * time.Second
+
+	renewInterval = TTL / 3
+	writeTimeout  = 5 * time.Second
+)
+
+// Lease is this worker's hold on one stream.
+type Lease struct {
+	StreamID string
+	command  []byte
+	renewed  time.Time
+}
+
+// Store holds this worker's leases in the StreamLease table. A stream's
+// FFmpeg only runs on the worker holding its lease, so two workers never
+// transcode the same stream; the lease stores the command that started it,
+// so another worker can resume the stream once it expires.
+type Store struct {
+	queries *stream.Queries
+	owner   string
+
+	mu   sync.Mutex
+	held map[string]*Lease
+}
+
+func NewStore(queries *stream.Queries, owner string) *Store {
+	return &Store{
+		queries: queries,
+		owner:   owner,
+		held:    make(map[string]*Lease),
+	}
+}
+
+// Acquire takes the lease of a stream, or renews it when this worker holds
+// it already. It returns nil when another worker holds a lease that has not
+// expired.
+func (s *Store) Acquire(ctx context.Context, streamID string, command []byte) (*Lease, error) {
+	rows, err := s.queries.AcquireStreamLease(ctx, stream.AcquireStreamLeaseParams{
+		StreamId:  streamID,
+		OwnerId:   s.owner,
+		Command:   command,
+		ExpiresAt: time.Now().Add(TTL),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if rows == 0 {
+		return nil, nil
+	}
+
+	l := &Lease{StreamID: streamID, command: command, renewed: time.Now()}
+
+	s.mu.Lock()
+	s.held[streamID] = l
+	s.mu.Unlock()
+
+	return l, nil
+}
+
+// Release gives a lease up. It does nothing once the stream's lease was
+// acquired again or transferred since.
+func (s *Store) Release(l *Lease) {
+	if !s.forget(l) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), writeTimeout)
+	defer cancel()
+
+	err := s.queries.ReleaseStreamLease(ctx, stream.ReleaseStreamLeaseParams{
+		StreamId: l.StreamID,
+		OwnerId:  s.owner,
+	})
+	if err != nil {
+		slog.Error("Failed to release stream lease", "streamId", l.StreamID, "error", err)
+	}
+}
+
+// Transfer hands the lease of a stream to the worker that took it over.
+func (s *Store) Transfer(ctx context.Context, streamID, to string) error {
+	s.mu.Lock()
+	delete(s.held, streamID)
+	s.mu.Unlock()
+
+	_, err := s.queries.TransferStreamLease(ctx, stream.TransferStreamLeaseParams{
+		StreamId:  streamID,
+		OwnerId:   s.owner,
+		OwnerId_2: to,
+	})
+	return err
+}
+
+func (s *Store) forget(l *Lease) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.held[l.StreamID] != l {
+		return false
+	}
+	delete(s.held, l.StreamID)
+	return true
+}
+
+// Run renews the held leases until done is closed. A lease that another
+// worker holds by now, or that could not be renewed for a whole TTL, is
+// forgotten and reported to lost: the stream may be running elsewhere.
+func (s *Store) Run(done <-chan struct{}, lost func(streamID string)) {
+	ticker := time.NewTicker(renewInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			s.renew(lost)
+		}
+	}
+}
+
+func (s *Store) renew(lost func(streamID string)) {
+	s.mu.Lock()
+	held := make([]*Lease, 0, len(s.held))
+	for _, l := range s.held {
+		held = append(held, l)
+	}
+	s.mu.Unlock()
+
+	for _, l := range held {
+		ctx, cancel := context.WithTimeout(context.Background(), writeTimeout)
+		rows, err := s.queries.AcquireStreamLease(ctx, stream.AcquireStreamLeaseParams{
+			StreamId:  l.StreamID,
+			OwnerId:   s.owner,
+			Command:   l.command,
+			ExpiresAt: time.Now().Add(TTL),
+		})
+		cancel()
+
+		switch {
+		case err == nil && rows > 0:
+			s.mu.Lock()
+			l.renewed = time.Now()
+			s.mu.Unlock()
+			continue
+		case err == nil:
+			slog.Error("Stream lease was taken by another worker", "streamId", l.StreamID)
+		default:
+			s.mu.Lock()
+			renewed := l.renewed
+			s.mu.Unlock()
+
+			if time.Since(renewed) < TTL {
+				slog.Warn("Failed to renew stream lease", "streamId", l.StreamID, "error", err)
+				continue
+			}
+			slog.Error("Stream lease expired before it could be renewed", "streamId", l.StreamID, "error", err)
+		}
+
+		if s.forget(l) {
+			lost(l.StreamID)
+		}
+	}
+}
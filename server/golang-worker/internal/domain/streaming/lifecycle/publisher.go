@@ -0,0 +1,142 @@
+package lifecycle
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"log/slog"
+	"sync"
+	"time"
+
+	stream "github.com/bitstream/backend-go/internal/db/generated"
+	"github.com/bitstream/backend-go/internal/kafka/producer"
+	"github.com/bitstream/backend-go/internal/kafka/topics"
+	"github.com/bitstream/backend-go/pkg/id"
+)
+
+const writeTimeout = 5 * time.Second
+
+// EventType is what the API is told about a stream. Unlike the audit events
+// these are part of a contract, so existing values must not change.
+type EventType string
+
+const (
+	TranscodeStarted EventType = "TRANSCODE_STARTED"
+	StreamPlayable   EventType = "STREAM_PLAYABLE"
+	TranscodeCrashed EventType = "TRANSCODE_CRASHED"
+	StreamFailed     EventType = "STREAM_FAILED"
+	VODFinalized     EventType = "VOD_FINALIZED"
+)
+
+type Data map[string]any
+
+type Event struct {
+	EventID    string    `json:"eventId"`
+	StreamID   string    `json:"streamId"`
+	Type       EventType `json:"type"`
+	Data       Data      `json:"data,omitempty"`
+	OccurredAt time.Time `json:"occurredAt"`
+}
+
+// Publisher emits lifecycle events through the Outbox table. An event is
+// written in the same transaction as the state change it announces, and a
+// relay goroutine sends it to the stream.lifecycle topic afterwards, so the
+// database and the event stream cannot disagree after a crash. Delivery is
+// at least once: consumers dedupe on eventId.
+type Publisher struct {
+	db       *sql.DB
+	queries  *stream.Queries
+	producer *producer.Producer
+
+	wake chan struct{}
+	quit chan struct{}
+	done chan struct{}
+	once sync.Once
+}
+
+func NewPublisher(db *sql.DB, p *producer.Producer) *Publisher {
+	return &Publisher{
+		db:       db,
+		queries:  stream.New(db),
+		producer: p,
+		wake:     make(chan struct{}, 1),
+		quit:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+func (p *Publisher) Start() {
+	go p.relay()
+}
+
+// Publish emits an event that does not go with any other write. Failing to
+// store it is logged and never interrupts the stream.
+func (p *Publisher) Publish(streamID string, eventType EventType, data Data) {
+	ctx, cancel := context.WithTimeout(context.Background(), writeTimeout)
+	defer cancel()
+
+	if err := p.Emit(ctx, p.queries, streamID, eventType, data); err != nil {
+		slog.Error("Failed to store lifecycle event", "streamId", streamID, "type", eventType, "error", err)
+		return
+	}
+	p.notify()
+}
+
+// Emit stores an event through q, which is bound to the caller's
+// transaction when the event announces a write made in it.
+func (p *Publisher) Emit(ctx context.Context, q *stream.Queries, streamID string, eventType EventType, data Data) error {
+	event := Event{
+		EventID:    id.New(),
+		StreamID:   streamID,
+		Type:       eventType,
+		Data:       data,
+		OccurredAt: time.Now().UTC(),
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	return q.CreateOutboxEvent(ctx, stream.CreateOutboxEventParams{
+		ID:      event.EventID,
+		Topic:   topics.STREAM_LIFECYCLE,
+		Key:     streamID,
+		Payload: payload,
+	})
+}
+
+// Tx runs fn in a transaction, so the writes it makes and the events it
+// emits are committed together.
+func (p *Publisher) Tx(ctx context.Context, fn func(q *stream.Queries) error) error {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := fn(p.queries.WithTx(tx)); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	p.notify()
+	return nil
+}
+
+// Close relays what is still stored and stops the relay.
+func (p *Publisher) Close() {
+	p.once.Do(func() {
+		close(p.quit)
+	})
+	<-p.done
+}
+
+func (p *Publisher) notify() {
+	select {
+	case p.wake <- struct{}{}:
+	default:
+	}
+}
@@ -0,0 +1,178 @@
+package lifecycle
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"time"
+
+	stream "github.com/bitstream/backend-go/internal/db/generated"
+)
+
+const (
+	relayInterval  = time.Second
+	relayBatchSize = 100
+
+	// an event that fails this often is parked: it stays in the table
+	// unsent, with its last error, and no longer holds up its stream
+	maxRelayAttempts = 30
+
+	// this many failed sends in a row look like the broker being down
+	// rather than bad events, and are not held against the events
+	relayOutageFailures = 3
+
+	// sent rows are kept for a day, to look into what was published
+	sentRetention   = 24 * time.Hour
+	cleanupInterval = time.Hour
+)
+
+// relay publishes stored events in the order they were written. Every
+// worker runs one, but a batch is only relayed while holding the relay
+// lock, so a single batch is in flight across the fleet at any time.
+func (p *Publisher) relay() {
+	defer close(p.done)
+
+	ticker := time.NewTicker(relayInterval)
+	defer ticker.Stop()
+
+	cleanup := time.NewTicker(cleanupInterval)
+	defer cleanup.Stop()
+
+	for {
+		select {
+		case <-p.quit:
+			p.flush()
+			return
+		case <-p.wake:
+			p.flush()
+		case <-ticker.C:
+			p.flush()
+		case <-cleanup.C:
+			p.deleteSent()
+		}
+	}
+}
+
+func (p *Publisher) flush() {
+	for {
+		sent, err := p.relayBatch()
+		if err != nil {
+			slog.Error("Failed to relay lifecycle events", "error", err)
+			return
+		}
+		if sent < relayBatchSize {
+			return
+		}
+	}
+}
+
+// relayBatch sends one batch and marks what went out as sent. A failed send
+// holds back the later events of its stream, so they are never published out
+// of order, while other streams go on; the rest is retried on the next tick.
+// A crash between sending and committing sends the batch again.
+func (p *Publisher) relayBatch() (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	q := p.queries.WithTx(tx)
+
+	// another worker is relaying; it will pick these rows up too
+	locked, err := q.TryLockOutboxRelay(ctx)
+	if err != nil || !locked {
+		return 0, err
+	}
+
+	rows, err := q.ClaimOutboxEvents(ctx, stream.ClaimOutboxEventsParams{
+		Attempts: maxRelayAttempts,
+		Limit:    relayBatchSize,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	held := make(map[string]struct{})
+	var failed []failedSend
+	for _, row := range rows {
+		if _, ok := held[row.Key]; ok {
+			continue
+		}
+
+		if err := p.producer.Publish(row.Topic, row.Key, row.Payload); err != nil {
+			held[row.Key] = struct{}{}
+			failed = append(failed, failedSend{row: row, err: err})
+			if len(failed) >= relayOutageFailures {
+				slog.Warn("Lifecycle events keep failing to publish, retrying later", "failures", len(failed), "error", err)
+				failed = nil
+				break
+			}
+			continue
+		}
+
+		if err := markFailed(ctx, q, failed); err != nil {
+			return sent, err
+		}
+		failed = nil
+
+		if err := q.MarkOutboxEventSent(ctx, row.ID); err != nil {
+			return sent, err
+		}
+		sent++
+	}
+
+	if err := markFailed(ctx, q, failed); err != nil {
+		return sent, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	if len(held) > 0 {
+		// stop flushing until the next tick
+		return 0, nil
+	}
+	return sent, nil
+}
+
+type failedSend struct {
+	row stream.Outbox
+	err error
+}
+
+// markFailed counts a failed attempt against each event. Once an event used
+// up its attempts it is parked.
+func markFailed(ctx context.Context, q *stream.Queries, failed []failedSend) error {
+	for _, f := range failed {
+		attempts := f.row.Attempts + 1
+		if attempts >= maxRelayAttempts {
+			slog.Error("Parking lifecycle event that keeps failing to publish", "id", f.row.ID, "streamId", f.row.Key, "attempts", attempts, "error", f.err)
+		} else {
+			slog.Warn("Failed to publish lifecycle event", "id", f.row.ID, "streamId", f.row.Key, "attempts", attempts, "error", f.err)
+		}
+
+		if err := q.MarkOutboxEventFailed(ctx, stream.MarkOutboxEventFailedParams{
+			ID:        f.row.ID,
+			LastError: sql.NullString{Valid: true, String: f.err.Error()},
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *Publisher) deleteSent() {
+	ctx, cancel := context.WithTimeout(context.Background(), writeTimeout)
+	defer cancel()
+
+	before := sql.NullTime{Valid: true, Time: time.Now().Add(-sentRetention)}
+	if err := p.queries.DeleteSentOutboxEvents(ctx, before); err != nil {
+		slog.Error("Failed to delete sent lifecycle events", "error", err)
+	}
+}
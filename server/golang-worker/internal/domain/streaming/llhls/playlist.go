@@ -0,0 +1,237 @@
+package llhls
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// keep this many complete segments in the media playlist
+	playlistWindow = 10
+	// parts are only listed for segments within this many target durations of
+	// the live edge, as required by the spec
+	partWindowTargets = 3
+)
+
+var (
+	ErrTooFarAhead = errors.New("llhls: requested media sequence is too far ahead")
+	ErrStreamEnded = errors.New("llhls: stream ended")
+)
+
+type part struct {
+	uri         string
+	duration    float64
+	independent bool
+}
+
+type segment struct {
+	msn      int
+	uri      string
+	duration float64
+	parts    []part
+}
+
+// Playlist is the LL-HLS media playlist of one rendition. It is updated by the
+// chunk watcher and read by blocking playlist requests.
+type Playlist struct {
+	repID      string
+	path       string
+	initURI    string
+	partTarget float64
+
+	mu       sync.Mutex
+	segments []segment
+	current  segment
+	ended    bool
+	changed  chan struct{}
+}
+
+func NewPlaylist(repID, streamDir string, partTarget time.Duration) *Playlist {
+	return &Playlist{
+		repID:      repID,
+		path:       filepath.Join(streamDir, MediaPlaylistName(repID)),
+		initURI:    fmt.Sprintf("init-%s.mp4", repID),
+		partTarget: partTarget.Seconds(),
+		current:    segment{msn: 1},
+		changed:    make(chan struct{}),
+	}
+}
+
+func MediaPlaylistName(repID string) string {
+	return fmt.Sprintf("llhls_%s.m3u8", repID)
+}
+
+func (p *Playlist) AddPart(msn int, uri string, duration float64, independent bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if msn != p.current.msn {
+		return
+	}
+
+	p.current.parts = append(p.current.parts, part{
+		uri:         uri,
+		duration:    duration,
+		independent: independent,
+	})
+	p.publishLocked()
+}
+
+// CompleteSegment closes the in-progress segment and opens msn+1.
+func (p *Playlist) CompleteSegment(msn int, uri string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if msn != p.current.msn {
+		return
+	}
+
+	p.current.uri = uri
+	for _, pt := range p.current.parts {
+		p.current.duration += pt.duration
+	}
+	p.segments = append(p.segments, p.current)
+	if len(p.segments) > playlistWindow {
+		p.segments = p.segments[len(p.segments)-playlistWindow:]
+	}
+
+	p.current = segment{msn: msn + 1}
+	p.publishLocked()
+}
+
+func (p *Playlist) End() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.ended {
+		return
+	}
+	p.ended = true
+	p.publishLocked()
+}
+
+// Wait blocks until the playlist contains part partIdx of segment msn (or the
+// whole segment when partIdx < 0), as required for blocking playlist reload.
+func (p *Playlist) Wait(ctx context.Context, msn, partIdx int) error {
+	for {
+		p.mu.Lock()
+		if p.hasLocked(msn, partIdx) {
+			p.mu.Unlock()
+			return nil
+		}
+		if p.ended {
+			p.mu.Unlock()
+			return ErrStreamEnded
+		}
+		// the spec allows blocking on at most the next two segments
+		if msn > p.current.msn+1 {
+			p.mu.Unlock()
+			return ErrTooFarAhead
+		}
+		changed := p.changed
+		p.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-changed:
+		}
+	}
+}
+
+func (p *Playlist) TargetDuration() time.Duration {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return time.Duration(p.targetDurationLocked()) * time.Second
+}
+
+func (p *Playlist) Render() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.renderLocked()
+}
+
+func (p *Playlist) hasLocked(msn, partIdx int) bool {
+	if msn < p.current.msn {
+		return true
+	}
+	if msn > p.current.msn || partIdx < 0 {
+		return false
+	}
+	return partIdx < len(p.current.parts)
+}
+
+func (p *Playlist) targetDurationLocked() int {
+	target := 1.0
+	for _, s := range p.segments {
+		target = math.Max(target, s.duration)
+	}
+	return int(math.Ceil(target))
+}
+
+func (p *Playlist) renderLocked() string {
+	var b strings.Builder
+
+	target := p.targetDurationLocked()
+
+	b.WriteString("#EXTM3U\n")
+	b.WriteString("#EXT-X-VERSION:9\n")
+	fmt.Fprintf(&b, "#EXT-X-TARGETDURATION:%d\n", target)
+	fmt.Fprintf(&b, "#EXT-X-SERVER-CONTROL:CAN-BLOCK-RELOAD=YES,PART-HOLD-BACK=%.3f\n", 3*p.partTarget)
+	fmt.Fprintf(&b, "#EXT-X-PART-INF:PART-TARGET=%.3f\n", p.partTarget)
+
+	firstMSN := p.current.msn
+	if len(p.segments) > 0 {
+		firstMSN = p.segments[0].msn
+	}
+	fmt.Fprintf(&b, "#EXT-X-MEDIA-SEQUENCE:%d\n", firstMSN)
+	fmt.Fprintf(&b, "#EXT-X-MAP:URI=\"%s\"\n", p.initURI)
+
+	partCutoff := len(p.segments) - partWindowTargets
+	for i, s := range p.segments {
+		if i >= partCutoff {
+			writeParts(&b, s.parts)
+		}
+		fmt.Fprintf(&b, "#EXTINF:%.5f,\n%s\n", s.duration, s.uri)
+	}
+
+	writeParts(&b, p.current.parts)
+
+	if p.ended {
+		b.WriteString("#EXT-X-ENDLIST\n")
+		return b.String()
+	}
+
+	fmt.Fprintf(&b, "#EXT-X-PRELOAD-HINT:TYPE=PART,URI=\"%s\"\n",
+		PartName(p.repID, p.current.msn, len(p.current.parts)))
+
+	return b.String()
+}
+
+func writeParts(b *strings.Builder, parts []part) {
+	for _, pt := range parts {
+		fmt.Fprintf(b, "#EXT-X-PART:DURATION=%.5f,URI=\"%s\"", pt.duration, pt.uri)
+		if pt.independent {
+			b.WriteString(",INDEPENDENT=YES")
+		}
+		b.WriteString("\n")
+	}
+}
+
+// publishLocked writes the playlist to disk and wakes every blocked reader.
+func (p *Playlist) publishLocked() {
+	tmp := p.path + ".tmp"
+	if err := os.WriteFile(tmp, []byte(p.renderLocked()), 0644); err == nil {
+		_ = os.Rename(tmp, p.path)
+	}
+
+	close(p.changed)
+	p.changed = make(chan struct{})
+}
@@ -0,0 +1,163 @@
+package llhls
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPlaylistRender(t *testing.T) {
+	dir := t.TempDir()
+	p := NewPlaylist("0", dir, 500*time.Millisecond)
+
+	p.AddPart(1, PartName("0", 1, 0), 0.5, true)
+	p.AddPart(1, PartName("0", 1, 1), 0.5, false)
+	p.CompleteSegment(1, "chunk-0-1.m4s")
+	p.AddPart(2, PartName("0", 2, 0), 0.5, true)
+
+	want := strings.Join([]string{
+		"#EXTM3U",
+		"#EXT-X-VERSION:9",
+		"#EXT-X-TARGETDURATION:1",
+		"#EXT-X-SERVER-CONTROL:CAN-BLOCK-RELOAD=YES,PART-HOLD-BACK=1.500",
+		"#EXT-X-PART-INF:PART-TARGET=0.500",
+		"#EXT-X-MEDIA-SEQUENCE:1",
+		`#EXT-X-MAP:URI="init-0.mp4"`,
+		`#EXT-X-PART:DURATION=0.50000,URI="part-0-1.0.m4s",INDEPENDENT=YES`,
+		`#EXT-X-PART:DURATION=0.50000,URI="part-0-1.1.m4s"`,
+		"#EXTINF:1.00000,",
+		"chunk-0-1.m4s",
+		`#EXT-X-PART:DURATION=0.50000,URI="part-0-2.0.m4s",INDEPENDENT=YES`,
+		`#EXT-X-PRELOAD-HINT:TYPE=PART,URI="part-0-2.1.m4s"`,
+	}, "\n") + "\n"
+
+	if got := p.Render(); got != want {
+		t.Errorf("Render() =\n%s\nwant\n%s", got, want)
+	}
+
+	written, err := os.ReadFile(p.path)
+	if err != nil {
+		t.Fatalf("playlist not written: %v", err)
+	}
+	if string(written) != want {
+		t.Errorf("written playlist =\n%s\nwant\n%s", written, want)
+	}
+}
+
+func TestPlaylistWindow(t *testing.T) {
+	p := NewPlaylist("0", t.TempDir(), 500*time.Millisecond)
+
+	for msn := 1; msn <= playlistWindow+2; msn++ {
+		p.AddPart(msn, PartName("0", msn, 0), 2, true)
+		p.CompleteSegment(msn, fmt.Sprintf("chunk-0-%d.m4s", msn))
+	}
+
+	got := p.Render()
+	if !strings.Contains(got, "#EXT-X-MEDIA-SEQUENCE:3\n") {
+		t.Errorf("media sequence does not start after the window:\n%s", got)
+	}
+	if n := strings.Count(got, "#EXTINF:"); n != playlistWindow {
+		t.Errorf("listed %d segments, want %d", n, playlistWindow)
+	}
+	// parts of older segments are left out
+	if n := strings.Count(got, "#EXT-X-PART:"); n != partWindowTargets {
+		t.Errorf("listed %d parts, want %d", n, partWindowTargets)
+	}
+	if !strings.Contains(got, "#EXT-X-TARGETDURATION:2\n") {
+		t.Errorf("target duration is not the longest segment:\n%s", got)
+	}
+}
+
+func TestPlaylistEnd(t *testing.T) {
+	p := NewPlaylist("1", t.TempDir(), time.Second)
+	p.AddPart(1, PartName("1", 1, 0), 1, true)
+	p.CompleteSegment(1, "chunk-1-1.m4s")
+	p.End()
+
+	got := p.Render()
+	if !strings.HasSuffix(got, "#EXT-X-ENDLIST\n") {
+		t.Errorf("ended playlist has no end tag:\n%s", got)
+	}
+	if strings.Contains(got, "#EXT-X-PRELOAD-HINT") {
+		t.Errorf("ended playlist still hints the next part:\n%s", got)
+	}
+}
+
+func TestPlaylistWait(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("returns for what is listed", func(t *testing.T) {
+		p := NewPlaylist("0", t.TempDir(), time.Second)
+		p.AddPart(1, PartName("0", 1, 0), 1, true)
+		p.CompleteSegment(1, "chunk-0-1.m4s")
+		p.AddPart(2, PartName("0", 2, 0), 1, true)
+
+		for _, req := range [][2]int{{1, -1}, {1, 5}, {2, 0}} {
+			if err := p.Wait(ctx, req[0], req[1]); err != nil {
+				t.Errorf("Wait(%d, %d) = %v, want nil", req[0], req[1], err)
+			}
+		}
+	})
+
+	t.Run("blocks until the part is added", func(t *testing.T) {
+		p := NewPlaylist("0", t.TempDir(), time.Second)
+
+		done := make(chan error, 1)
+		go func() { done <- p.Wait(ctx, 1, 1) }()
+
+		p.AddPart(1, PartName("0", 1, 0), 1, true)
+		select {
+		case err := <-done:
+			t.Fatalf("Wait returned %v before its part was added", err)
+		case <-time.After(20 * time.Millisecond):
+		}
+
+		p.AddPart(1, PartName("0", 1, 1), 1, false)
+		select {
+		case err := <-done:
+			if err != nil {
+				t.Errorf("Wait() = %v, want nil", err)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("Wait did not return once its part was added")
+		}
+	})
+
+	t.Run("refuses segments too far ahead", func(t *testing.T) {
+		p := NewPlaylist("0", t.TempDir(), time.Second)
+		if err := p.Wait(ctx, 3, -1); !errors.Is(err, ErrTooFarAhead) {
+			t.Errorf("Wait() = %v, want %v", err, ErrTooFarAhead)
+		}
+	})
+
+	t.Run("stops when the stream ends", func(t *testing.T) {
+		p := NewPlaylist("0", t.TempDir(), time.Second)
+
+		done := make(chan error, 1)
+		go func() { done <- p.Wait(ctx, 2, -1) }()
+		p.End()
+
+		select {
+		case err := <-done:
+			if !errors.Is(err, ErrStreamEnded) {
+				t.Errorf("Wait() = %v, want %v", err, ErrStreamEnded)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("Wait did not return once the stream ended")
+		}
+	})
+
+	t.Run("stops when the request is canceled", func(t *testing.T) {
+		p := NewPlaylist("0", t.TempDir(), time.Second)
+
+		ctx, cancel := context.WithCancel(ctx)
+		cancel()
+		if err := p.Wait(ctx, 1, 0); !errors.Is(err, context.Canceled) {
+			t.Errorf("Wait() = %v, want %v", err, context.Canceled)
+		}
+	})
+}
@@ -0,0 +1,194 @@
+package llhls
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const contentTypePlaylist = "application/vnd.apple.mpegurl"
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[string]*Stream)
+)
+
+func register(s *Stream) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[s.StreamID] = s
+}
+
+// unregister removes s unless a newer writer of the same stream replaced it.
+func unregister(s *Stream) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if registry[s.StreamID] == s {
+		delete(registry, s.StreamID)
+	}
+}
+
+func lookup(streamID string) (*Stream, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	s, ok := registry[streamID]
+	return s, ok
+}
+
+// Server is the LL-HLS origin. Blocking playlist reload and preload hints
+// need a server that can hold requests, so live LL-HLS is served from the
+// worker that owns the stream rather than from object storage.
+type Server struct {
+	httpServer *http.Server
+}
+
+func NewServer(port int) *Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/llhls/", handle)
+
+	return &Server{
+		httpServer: &http.Server{
+			Addr:              fmt.Sprintf(":%d", port),
+			Handler:           mux,
+			ReadHeaderTimeout: 5 * time.Second,
+		},
+	}
+}
+
+func (s *Server) Start() {
+	go func() {
+		slog.Info("LL-HLS server listening", "addr", s.httpServer.Addr)
+		if err := s.httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			slog.Error("LL-HLS server failed", "error", err)
+		}
+	}()
+}
+
+func (s *Server) Shutdown(ctx context.Context) error {
+	return s.httpServer.Shutdown(ctx)
+}
+
+// handle serves /llhls/<streamId>/<file>.
+func handle(w http.ResponseWriter, r *http.Request) {
+	streamID, file, ok := strings.Cut(strings.TrimPrefix(r.URL.Path, "/llhls/"), "/")
+	if !ok || file == "" || file != filepath.Base(file) {
+		http.NotFound(w, r)
+		return
+	}
+
+	s, ok := lookup(streamID)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+
+	switch {
+	case file == MasterPlaylistName:
+		w.Header().Set("Content-Type", contentTypePlaylist)
+		w.Header().Set("Cache-Control", "no-cache")
+		_, _ = w.Write([]byte(s.RenderMaster()))
+	case strings.HasPrefix(file, "llhls_") && strings.HasSuffix(file, ".m3u8"):
+		serveMediaPlaylist(w, r, s, strings.TrimSuffix(strings.TrimPrefix(file, "llhls_"), ".m3u8"))
+	case strings.HasPrefix(file, "part-"):
+		servePart(w, r, s, file)
+	case isMediaFile(file):
+		http.ServeFile(w, r, filepath.Join(s.Dir(), file))
+	default:
+		// the stream directory also holds the worker's own state
+		http.NotFound(w, r)
+	}
+}
+
+// isMediaFile reports whether file is an init segment or a complete segment.
+func isMediaFile(file string) bool {
+	for _, pattern := range []string{"init-*.mp4", "chunk-*-*.m4s"} {
+		if ok, _ := filepath.Match(pattern, file); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func serveMediaPlaylist(w http.ResponseWriter, r *http.Request, s *Stream, repID string) {
+	pl, ok := s.Playlist(repID)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	query := r.URL.Query()
+	if rawMSN := query.Get("_HLS_msn"); rawMSN != "" {
+		msn, err := strconv.Atoi(rawMSN)
+		if err != nil {
+			http.Error(w, "invalid _HLS_msn", http.StatusBadRequest)
+			return
+		}
+
+		partIdx := -1
+		if rawPart := query.Get("_HLS_part"); rawPart != "" {
+			if partIdx, err = strconv.Atoi(rawPart); err != nil {
+				http.Error(w, "invalid _HLS_part", http.StatusBadRequest)
+				return
+			}
+		}
+
+		if !waitFor(w, r, pl, msn, partIdx) {
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", contentTypePlaylist)
+	w.Header().Set("Cache-Control", "no-cache")
+	_, _ = w.Write([]byte(pl.Render()))
+}
+
+// servePart holds a request for the hinted part until FFmpeg has written it.
+func servePart(w http.ResponseWriter, r *http.Request, s *Stream, file string) {
+	var repID string
+	var msn, partIdx int
+	_, err := fmt.Sscanf(strings.ReplaceAll(file, "-", " "), "part %s %d.%d.m4s", &repID, &msn, &partIdx)
+	if err != nil || file != PartName(repID, msn, partIdx) {
+		http.NotFound(w, r)
+		return
+	}
+
+	pl, ok := s.Playlist(repID)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	if !waitFor(w, r, pl, msn, partIdx) {
+		return
+	}
+
+	w.Header().Set("Cache-Control", "public, max-age=60")
+	http.ServeFile(w, r, filepath.Join(s.Dir(), file))
+}
+
+// waitFor blocks for at most three target durations, as the spec asks of
+// servers, and writes the error response itself when it gives up.
+func waitFor(w http.ResponseWriter, r *http.Request, pl *Playlist, msn, partIdx int) bool {
+	ctx, cancel := context.WithTimeout(r.Context(), 3*pl.TargetDuration())
+	defer cancel()
+
+	err := pl.Wait(ctx, msn, partIdx)
+	switch {
+	case err == nil, errors.Is(err, ErrStreamEnded):
+		return true
+	case errors.Is(err, ErrTooFarAhead):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		http.Error(w, "part not available", http.StatusServiceUnavailable)
+	}
+	return false
+}
@@ -0,0 +1,75 @@
+package llhls
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestUnregisterKeepsNewerWriter(t *testing.T) {
+	old := NewStream("restarted", t.TempDir(), nil, time.Second)
+	current := NewStream("restarted", t.TempDir(), nil, time.Second)
+
+	register(old)
+	register(current)
+	unregister(old)
+
+	if s, ok := lookup("restarted"); !ok || s != current {
+		t.Fatalf("lookup() = %p, %v, want the newer writer %p", s, ok, current)
+	}
+
+	unregister(current)
+	if _, ok := lookup("restarted"); ok {
+		t.Error("stream still registered once its writer stopped")
+	}
+}
+
+func TestHandleServesOnlyMedia(t *testing.T) {
+	dir := t.TempDir()
+	s := NewStream("served", dir, []Rendition{{RepID: "0"}}, time.Second)
+	register(s)
+	defer unregister(s)
+
+	p, _ := s.Playlist("0")
+	p.AddPart(1, PartName("0", 1, 0), 1, true)
+
+	for _, name := range []string{
+		"init-0.mp4", "chunk-0-1.m4s", PartName("0", 1, 0),
+		"upload-journal.json", "worker-id", "checkpoint.json", "vod.mpd",
+		"chunk-0-2.m4s.tmp", PartName("0", 1, 0) + ".tmp", "llhls_0.m3u8.tmp",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(name), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		file string
+		want int
+	}{
+		{file: MasterPlaylistName, want: http.StatusOK},
+		{file: MediaPlaylistName("0"), want: http.StatusOK},
+		{file: "init-0.mp4", want: http.StatusOK},
+		{file: "chunk-0-1.m4s", want: http.StatusOK},
+		{file: PartName("0", 1, 0), want: http.StatusOK},
+		{file: "upload-journal.json", want: http.StatusNotFound},
+		{file: "worker-id", want: http.StatusNotFound},
+		{file: "checkpoint.json", want: http.StatusNotFound},
+		{file: "vod.mpd", want: http.StatusNotFound},
+		{file: "chunk-0-2.m4s.tmp", want: http.StatusNotFound},
+		{file: PartName("0", 1, 0) + ".tmp", want: http.StatusNotFound},
+		{file: "llhls_0.m3u8.tmp", want: http.StatusNotFound},
+		{file: MediaPlaylistName("7"), want: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		handle(rec, httptest.NewRequest(http.MethodGet, "/llhls/served/"+tt.file, nil))
+		if rec.Code != tt.want {
+			t.Errorf("GET %s = %d, want %d", tt.file, rec.Code, tt.want)
+		}
+	}
+}
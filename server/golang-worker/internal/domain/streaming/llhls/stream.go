@@ -0,0 +1,232 @@
+package llhls
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/bitstream/backend-go/pkg/fmp4"
+)
+
+const (
+	MasterPlaylistName = "llhls.m3u8"
+	minPollInterval    = 50 * time.Millisecond
+)
+
+type Rendition struct {
+	RepID     string
+	Bandwidth int
+	Width     int
+	Height    int
+	Audio     bool
+}
+
+// chunkState follows the segment FFmpeg is currently appending fragments to.
+type chunkState struct {
+	msn       int
+	consumed  int64
+	parts     int
+	timescale uint32
+}
+
+// Stream turns the CMAF chunks FFmpeg writes for one stream into LL-HLS
+// partial segments. FFmpeg's own HLS muxer cannot hold playlist requests
+// until a part exists, so parts and playlists are produced here instead.
+type Stream struct {
+	StreamID   string
+	dir        string
+	partTarget time.Duration
+	renditions []Rendition
+
+	playlists map[string]*Playlist
+	chunks    map[string]*chunkState
+}
+
+func NewStream(streamID, streamDir string, renditions []Rendition, partTarget time.Duration) *Stream {
+	s := &Stream{
+		StreamID:   streamID,
+		dir:        streamDir,
+		partTarget: partTarget,
+		renditions: renditions,
+		playlists:  make(map[string]*Playlist),
+		chunks:     make(map[string]*chunkState),
+	}
+
+	for _, r := range renditions {
+		s.playlists[r.RepID] = NewPlaylist(r.RepID, streamDir, partTarget)
+		s.chunks[r.RepID] = &chunkState{msn: 1}
+	}
+
+	return s
+}
+
+func PartName(repID string, msn, idx int) string {
+	return fmt.Sprintf("part-%s-%d.%d.m4s", repID, msn, idx)
+}
+
+func (s *Stream) Run(ctx context.Context) {
+	register(s)
+	defer unregister(s)
+
+	interval := max(s.partTarget/4, minPollInterval)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	slog.Info("LL-HLS writer started", "streamId", s.StreamID, "partTarget", s.partTarget)
+
+	if err := os.WriteFile(filepath.Join(s.dir, MasterPlaylistName), []byte(s.RenderMaster()), 0644); err != nil {
+		slog.Error("Failed to write LL-HLS master playlist", "streamId", s.StreamID, "error", err)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.poll()
+			for _, pl := range s.playlists {
+				pl.End()
+			}
+			slog.Info("LL-HLS writer stopped", "streamId", s.StreamID)
+			return
+		case <-ticker.C:
+			s.poll()
+		}
+	}
+}
+
+func (s *Stream) Playlist(repID string) (*Playlist, bool) {
+	pl, ok := s.playlists[repID]
+	return pl, ok
+}
+
+func (s *Stream) Dir() string {
+	return s.dir
+}
+
+func (s *Stream) RenderMaster() string {
+	var b strings.Builder
+
+	b.WriteString("#EXTM3U\n")
+	b.WriteString("#EXT-X-VERSION:9\n")
+	b.WriteString("#EXT-X-INDEPENDENT-SEGMENTS\n")
+
+	audioGroup := ""
+	for _, r := range s.renditions {
+		if r.Audio {
+			audioGroup = "audio"
+			fmt.Fprintf(&b, "#EXT-X-MEDIA:TYPE=AUDIO,GROUP-ID=\"%s\",NAME=\"audio\",DEFAULT=YES,AUTOSELECT=YES,URI=\"%s\"\n",
+				audioGroup, MediaPlaylistName(r.RepID))
+		}
+	}
+
+	for _, r := range s.renditions {
+		if r.Audio {
+			continue
+		}
+		fmt.Fprintf(&b, "#EXT-X-STREAM-INF:BANDWIDTH=%d", r.Bandwidth)
+		if r.Width > 0 && r.Height > 0 {
+			fmt.Fprintf(&b, ",RESOLUTION=%dx%d", r.Width, r.Height)
+		}
+		if audioGroup != "" {
+			fmt.Fprintf(&b, ",AUDIO=\"%s\"", audioGroup)
+		}
+		fmt.Fprintf(&b, "\n%s\n", MediaPlaylistName(r.RepID))
+	}
+
+	return b.String()
+}
+
+func (s *Stream) poll() {
+	for _, r := range s.renditions {
+		if err := s.advance(r.RepID); err != nil {
+			slog.Debug("LL-HLS rendition not ready", "streamId", s.StreamID, "repId", r.RepID, "error", err)
+		}
+	}
+}
+
+// advance turns newly completed fragments of the in-progress chunk into parts,
+// and closes the segment once FFmpeg has moved on to the next chunk.
+func (s *Stream) advance(repID string) error {
+	state := s.chunks[repID]
+	pl := s.playlists[repID]
+
+	if state.timescale == 0 {
+		init, err := os.ReadFile(filepath.Join(s.dir, fmt.Sprintf("init-%s.mp4", repID)))
+		if err != nil {
+			return err
+		}
+		timescale, err := fmp4.ParseTimescale(init)
+		if err != nil {
+			return err
+		}
+		state.timescale = timescale
+	}
+
+	for {
+		chunkName := fmt.Sprintf("chunk-%s-%d.m4s", repID, state.msn)
+		data, err := s.readFrom(chunkName, state.consumed)
+		if err != nil {
+			return err
+		}
+
+		// checked before parsing so a finished chunk is never closed early
+		_, nextErr := os.Stat(filepath.Join(s.dir, fmt.Sprintf("chunk-%s-%d.m4s", repID, state.msn+1)))
+		nextExists := nextErr == nil
+
+		for _, f := range fmp4.ParseFragments(data) {
+			partName := PartName(repID, state.msn, state.parts)
+			if err := os.WriteFile(filepath.Join(s.dir, partName), data[f.Offset:f.Offset+f.Size], 0644); err != nil {
+				return err
+			}
+
+			pl.AddPart(state.msn, partName, float64(f.Duration)/float64(state.timescale), f.Independent)
+			state.parts++
+			state.consumed += f.Size
+		}
+
+		if !nextExists {
+			return nil
+		}
+
+		pl.CompleteSegment(state.msn, chunkName)
+		s.removeParts(repID, state.msn-partWindowTargets-1)
+
+		state.msn++
+		state.consumed = 0
+		state.parts = 0
+	}
+}
+
+// readFrom reads a chunk from offset, following FFmpeg's temp-file naming
+// while the chunk is still open.
+func (s *Stream) readFrom(chunkName string, offset int64) ([]byte, error) {
+	path := filepath.Join(s.dir, chunkName)
+
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		f, err = os.Open(path + ".tmp")
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return nil, err
+	}
+	return io.ReadAll(f)
+}
+
+func (s *Stream) removeParts(repID string, msn int) {
+	if msn < 1 {
+		return
+	}
+	matches, _ := filepath.Glob(filepath.Join(s.dir, fmt.Sprintf("part-%s-%d.*.m4s", repID, msn)))
+	for _, path := range matches {
+		_ = os.Remove(path)
+	}
+}
@@ -8,6 +8,8 @@ import (
 	"time"
 
 	stream "github.com/bitstream/backend-go/internal/db/generated"
+	"github.com/bitstream/backend-go/internal/domain/streaming/ffmpeg"
+	"github.com/bitstream/backend-go/internal/storage/uploader"
 )
 
 const (
@@ -20,15 +22,19 @@ type GarbageCollector struct {
 	cancel    context.CancelFunc
 	queries   *stream.Queries
 	outputDir string
+
+	// recovering reports streams whose files are still being recovered
+	recovering func(streamID string) bool
 }
 
-func NewGarbageCollector(queries *stream.Queries, outputDir string) *GarbageCollector {
+func NewGarbageCollector(queries *stream.Queries, outputDir string, recovering func(streamID string) bool) *GarbageCollector {
 	ctx, cancel := context.WithCancel(context.Background())
 	return &GarbageCollector{
-		ctx:       ctx,
-		cancel:    cancel,
-		queries:   queries,
-		outputDir: outputDir,
+		ctx:        ctx,
+		cancel:     cancel,
+		queries:    queries,
+		outputDir:  outputDir,
+		recovering: recovering,
 	}
 }
 
@@ -62,6 +68,14 @@ func (gc *GarbageCollector) collect() {
 	slog.Info("GC: Found local stream directories", "count", len(localStreamIDs))
 
 	for _, streamID := range localStreamIDs {
+		if gc.recovering(streamID) {
+			slog.Info("GC: Stream is being recovered, keeping its files", "streamId", streamID)
+			continue
+		}
+		if uploader.HasPendingJournal(filepath.Join(gc.outputDir, streamID)) {
+			slog.Warn("GC: Stream has unconfirmed uploads, keeping its files", "streamId", streamID)
+			continue
+		}
 		if gc.shouldCleanup(streamID) {
 			gc.cleanupStreamDirectory(streamID)
 		}
@@ -116,14 +130,7 @@ func (gc *GarbageCollector) cleanupStreamDirectory(streamID string) {
 		return
 	}
 
-	patterns := []string{
-		"manifest.mpd",
-		"manifest.mpd.tmp",
-		"init-*.mp4",
-		"chunk-*.m4s",
-	}
-
-	for _, pattern := range patterns {
+	for _, pattern := range ffmpeg.OutputFilePatterns() {
 		matches, _ := filepath.Glob(filepath.Join(streamDir, pattern))
 		for _, path := range matches {
 			if err := os.Remove(path); err == nil {
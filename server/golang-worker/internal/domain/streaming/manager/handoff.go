@@ -0,0 +1,322 @@
+package manager
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bitstream/backend-go/internal/config"
+	stream "github.com/bitstream/backend-go/internal/db/generated"
+	"github.com/bitstream/backend-go/internal/domain/streaming/ffmpeg"
+	"github.com/bitstream/backend-go/internal/domain/streaming/lease"
+	"github.com/bitstream/backend-go/internal/domain/streaming/model"
+	"github.com/bitstream/backend-go/internal/kafka/topics"
+	"github.com/bitstream/backend-go/pkg/id"
+)
+
+// Status of a StreamHandoff. The worker handing a stream over creates it
+// REQUESTED; the worker taking over marks it READY once its FFmpeg has
+// written a segment, and the first one RELEASED once it has stopped. A
+// REQUESTED handoff nobody answered in time is ABORTED.
+const (
+	handoffRequested = "REQUESTED"
+	handoffReady     = "READY"
+	handoffReleased  = "RELEASED"
+	handoffAborted   = "ABORTED"
+)
+
+const (
+	defaultHandoffTimeout = 30 * time.Second
+	handoffPollInterval   = 500 * time.Millisecond
+	handoffWriteTimeout   = 5 * time.Second
+)
+
+var errHandoffAborted = errors.New("stream handoff was aborted")
+
+func (m *StreamManager) handoffTimeout() time.Duration {
+	if m.config.Handoff.Timeout <= 0 {
+		return defaultHandoffTimeout
+	}
+	return m.config.Handoff.Timeout
+}
+
+// releaseTimeout bounds the wait for the worker handing a stream over to
+// stop it and upload its last segments. Both happen within its shutdown,
+// which is assumed to have the same timeout as this worker's.
+func (m *StreamManager) releaseTimeout() time.Duration {
+	if m.config.ShutdownTimeout <= 0 {
+		return config.DefaultShutdownTimeout
+	}
+	return m.config.ShutdownTimeout
+}
+
+// handOffAll asks other workers to take over every stream this one runs,
+// in parallel. A stream is stopped only once its new FFmpeg has written a
+// segment; one that no worker takes over in time is left running for the
+// shutdown to end.
+func (m *StreamManager) handOffAll(ctx context.Context) {
+	if !m.config.Handoff.Enabled {
+		return
+	}
+
+	m.mu.Lock()
+	procs := make(map[*ffmpeg.StreamProcess]model.StreamPayload, len(m.process))
+	for streamID, proc := range m.process {
+		if proc.Running() {
+			procs[proc] = m.started[streamID]
+		}
+	}
+	m.mu.Unlock()
+
+	var wg sync.WaitGroup
+	for proc, p := range procs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			m.handOff(ctx, p, proc)
+		}()
+	}
+	wg.Wait()
+}
+
+// resumable reports whether p's stream can be continued after its stored
+// segments by a TAKEOVER. HLS playlists are numbered by the muxer and would
+// not continue, so only DASH output can.
+func (m *StreamManager) resumable(p model.StreamPayload) (ffmpeg.OutputMode, bool) {
+	mode := ffmpeg.ResolveOutputMode(p.OutputMode, m.config.FFmpeg.OutputMode)
+	return mode, mode == ffmpeg.OutputModeDASH
+}
+
+// resumeAction is the command that brings back a stream whose worker is
+// gone: a TAKEOVER where the output continues, otherwise a fresh START.
+func (m *StreamManager) resumeAction(p model.StreamPayload) model.StreamAction {
+	if _, ok := m.resumable(p); ok {
+		return model.StreamTakeover
+	}
+	return model.StreamStart
+}
+
+func (m *StreamManager) handOff(ctx context.Context, p model.StreamPayload, proc *ffmpeg.StreamProcess) {
+	if mode, ok := m.resumable(p); !ok {
+		slog.Info("Stream output cannot be handed over, stopping it", "streamId", p.StreamID, "outputMode", mode)
+		return
+	}
+
+	handoffID := id.New()
+	if err := m.queries.CreateStreamHandoff(ctx, stream.CreateStreamHandoffParams{
+		ID:         handoffID,
+		StreamId:   p.StreamID,
+		FromWorker: m.workerID,
+	}); err != nil {
+		slog.Error("Failed to create stream handoff", "streamId", p.StreamID, "error", err)
+		return
+	}
+
+	takeover := p
+	takeover.EventID = id.New()
+	takeover.Action = model.StreamTakeover
+	takeover.RetryCount = 0
+	takeover.OccurredAt = time.Now().UTC().Format(time.RFC3339Nano)
+	takeover.HandoffID = handoffID
+
+	value, err := json.Marshal(takeover)
+	if err == nil {
+		err = m.commands.Publish(topics.STREAM_ON_PUBLISH, p.StreamID, value)
+	}
+	if err != nil {
+		slog.Error("Failed to request stream takeover", "streamId", p.StreamID, "error", err)
+		m.abortHandoff(handoffID)
+		return
+	}
+
+	slog.Info("Asked another worker to take the stream over", "streamId", p.StreamID, "handoffId", handoffID)
+
+	if _, err := m.awaitHandoff(ctx, handoffID, handoffReady, m.handoffTimeout()); err != nil && m.abortHandoff(handoffID) {
+		slog.Warn("No worker took the stream over, stopping it", "streamId", p.StreamID, "handoffId", handoffID, "error", err)
+		return
+	}
+
+	if err := proc.HandOver(); err != nil {
+		slog.Error("Failed to stop handed over stream", "streamId", p.StreamID, "error", err)
+	}
+
+	// the new worker continues after the last segment this one stored
+	select {
+	case <-proc.Finalized():
+	case <-ctx.Done():
+		slog.Warn("Releasing stream before its uploads finished", "streamId", p.StreamID, "error", ctx.Err())
+	}
+
+	writeCtx, cancel := context.WithTimeout(context.Background(), handoffWriteTimeout)
+	defer cancel()
+
+	var resumeSeq sql.NullInt32
+	if meta, err := m.queries.GetStreamMeta(writeCtx, p.StreamID); err == nil {
+		resumeSeq = meta.LastSegmentSeq
+	}
+
+	if handoff, err := m.queries.GetStreamHandoff(writeCtx, handoffID); err == nil && handoff.ToWorker.Valid {
+		if err := m.leases.Transfer(writeCtx, p.StreamID, handoff.ToWorker.String); err != nil {
+			slog.Error("Failed to transfer stream lease", "streamId", p.StreamID, "to", handoff.ToWorker.String, "error", err)
+		}
+	}
+
+	if err := m.queries.ReleaseStreamHandoff(writeCtx, stream.ReleaseStreamHandoffParams{
+		ID:        handoffID,
+		ResumeSeq: resumeSeq,
+	}); err != nil {
+		slog.Error("Failed to release stream handoff", "streamId", p.StreamID, "handoffId", handoffID, "error", err)
+		return
+	}
+
+	slog.Info("Stream handed over", "streamId", p.StreamID, "handoffId", handoffID, "resumeSeq", resumeSeq.Int32)
+}
+
+// abortHandoff withdraws a handoff nobody answered yet. It reports false
+// when a worker marked it ready meanwhile, which then goes ahead.
+func (m *StreamManager) abortHandoff(handoffID string) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), handoffWriteTimeout)
+	defer cancel()
+
+	rows, err := m.queries.AbortStreamHandoff(ctx, handoffID)
+	if err != nil {
+		slog.Error("Failed to abort stream handoff", "handoffId", handoffID, "error", err)
+		return true
+	}
+	return rows > 0
+}
+
+// awaitHandoff polls a handoff until it reaches status, for at most timeout.
+func (m *StreamManager) awaitHandoff(ctx context.Context, handoffID, status string, timeout time.Duration) (stream.StreamHandoff, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(handoffPollInterval)
+	defer ticker.Stop()
+
+	for {
+		handoff, err := m.queries.GetStreamHandoff(ctx, handoffID)
+		switch {
+		case err != nil && ctx.Err() == nil:
+			slog.Warn("Failed to read stream handoff", "handoffId", handoffID, "error", err)
+		case err != nil:
+		case handoff.Status == status:
+			return handoff, nil
+		case handoff.Status == handoffAborted:
+			return handoff, errHandoffAborted
+		}
+
+		select {
+		case <-ctx.Done():
+			return stream.StreamHandoff{}, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// takeover continues p's stream after the segments already stored. Unless
+// held already, the stream's lease is acquired into held once the worker
+// handing over has stopped.
+func (m *StreamManager) takeover(p model.StreamPayload, held **lease.Lease) *ffmpeg.Takeover {
+	return &ffmpeg.Takeover{
+		Ready: func(ctx context.Context) (int, error) {
+			seq, err := m.resumeSeq(ctx, p)
+			if err != nil || *held != nil {
+				return seq, err
+			}
+
+			l, err := m.acquireLease(ctx, p)
+			if err != nil {
+				return 0, err
+			}
+			if l == nil {
+				return 0, errLeaseHeld
+			}
+			*held = l
+			return seq, nil
+		},
+		Load: func(ctx context.Context, filename string) ([]byte, error) {
+			var buf bytes.Buffer
+			err := m.storage.DownloadTo(ctx, fmt.Sprintf("streams/%s/%s", p.StreamID, filename), &buf)
+			return buf.Bytes(), err
+		},
+		Copy: func(ctx context.Context, filename, copyName string) error {
+			return m.storage.CopyObject(ctx,
+				fmt.Sprintf("streams/%s/%s", p.StreamID, filename),
+				fmt.Sprintf("streams/%s/%s", p.StreamID, copyName),
+			)
+		},
+	}
+}
+
+// resumeSeq is the last stored segment of the stream. For a handoff it first
+// tells the worker handing over to stop, and waits until it has.
+func (m *StreamManager) resumeSeq(ctx context.Context, p model.StreamPayload) (int, error) {
+	if p.HandoffID != "" {
+		rows, err := m.queries.MarkStreamHandoffReady(ctx, stream.MarkStreamHandoffReadyParams{
+			ID:       p.HandoffID,
+			ToWorker: sql.NullString{Valid: true, String: m.workerID},
+		})
+		if err != nil {
+			return 0, err
+		}
+		if rows == 0 {
+			return 0, errHandoffAborted
+		}
+
+		if _, err := m.awaitHandoff(ctx, p.HandoffID, handoffReleased, m.releaseTimeout()); err != nil {
+			if ctx.Err() != nil {
+				return 0, ctx.Err()
+			}
+			slog.Warn("Stream was not released, continuing after its stored segments",
+				"streamId", p.StreamID,
+				"handoffId", p.HandoffID,
+				"error", err,
+			)
+		}
+	}
+
+	return m.storedSeq(ctx, p.StreamID)
+}
+
+// storedSeq is the last segment stored of any representation of the
+// stream, so that continuing after it overwrites none of them. The stream
+// metadata lags behind the uploads and cannot tell.
+func (m *StreamManager) storedSeq(ctx context.Context, streamID string) (int, error) {
+	prefix := fmt.Sprintf("streams/%s/", streamID)
+	names, err := m.storage.ListObjects(ctx, prefix+"chunk-")
+	if err != nil {
+		return 0, err
+	}
+
+	last := make(map[int]int)
+	for _, name := range names {
+		var repId, seq int
+		if _, err := fmt.Sscanf(strings.TrimPrefix(name, prefix), "chunk-%d-%d.m4s", &repId, &seq); err == nil {
+			last[repId] = max(last[repId], seq)
+		}
+	}
+
+	stored, lowest := 0, -1
+	for _, seq := range last {
+		stored = max(stored, seq)
+		if lowest < 0 || seq < lowest {
+			lowest = seq
+		}
+	}
+	if lowest >= 0 && lowest < stored {
+		slog.Warn("Representations end at different segments, continuing after the last",
+			"streamId", streamID,
+			"lowest", lowest,
+			"last", stored,
+		)
+	}
+	return stored, nil
+}
@@ -0,0 +1,31 @@
+package manager
+
+import (
+	"testing"
+
+	"github.com/bitstream/backend-go/internal/config"
+	"github.com/bitstream/backend-go/internal/domain/streaming/model"
+)
+
+func TestResumeAction(t *testing.T) {
+	tests := []struct {
+		requested, fallback string
+		want                model.StreamAction
+	}{
+		{requested: "dash", fallback: "cmaf", want: model.StreamTakeover},
+		{requested: "", fallback: "", want: model.StreamTakeover},
+		{requested: "cmaf", fallback: "dash", want: model.StreamStart},
+		{requested: "llhls", fallback: "dash", want: model.StreamStart},
+		{requested: "", fallback: "llhls", want: model.StreamStart},
+	}
+
+	for _, tt := range tests {
+		m := &StreamManager{config: &config.AppConfig{}}
+		m.config.FFmpeg.OutputMode = tt.fallback
+
+		p := model.StreamPayload{StreamID: "s1", OutputMode: tt.requested}
+		if got := m.resumeAction(p); got != tt.want {
+			t.Errorf("resumeAction(%q, default %q) = %s, want %s", tt.requested, tt.fallback, got, tt.want)
+		}
+	}
+}
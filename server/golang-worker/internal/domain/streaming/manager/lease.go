@@ -0,0 +1,122 @@
+package manager
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/bitstream/backend-go/internal/domain/streaming/lease"
+	"github.com/bitstream/backend-go/internal/domain/streaming/model"
+	"github.com/bitstream/backend-go/pkg/id"
+)
+
+const (
+	reclaimInterval = lease.TTL
+	reclaimBatch    = 20
+	leaseTimeout    = 5 * time.Second
+)
+
+var errLeaseHeld = errors.New("stream lease is held by another worker")
+
+// acquireLease takes the stream's lease for the command p. It returns nil
+// when another worker runs the stream.
+func (m *StreamManager) acquireLease(ctx context.Context, p model.StreamPayload) (*lease.Lease, error) {
+	command, err := json.Marshal(p)
+	if err != nil {
+		return nil, err
+	}
+	return m.leases.Acquire(ctx, p.StreamID, command)
+}
+
+// leaseLost stops a stream whose lease another worker may hold by now,
+// without finalizing it: the stream is that worker's.
+func (m *StreamManager) leaseLost(streamID string) {
+	proc := m.detachProcess(streamID)
+	if proc == nil {
+		return
+	}
+
+	slog.Error("Lost the lease of a running stream, stopping it", "streamId", streamID)
+	go func() {
+		if err := proc.HandOver(); err != nil {
+			slog.Error("Failed to stop stream after losing its lease", "streamId", streamID, "error", err)
+		}
+	}()
+}
+
+// reclaimLeases resumes the streams of workers that died, whose leases
+// expired, until the manager shuts down. Several workers may try the same
+// stream; only the one acquiring its lease starts it.
+func (m *StreamManager) reclaimLeases() {
+	ticker := time.NewTicker(reclaimInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-m.quit:
+			return
+		case <-ticker.C:
+			m.reclaimExpired()
+		}
+	}
+}
+
+func (m *StreamManager) reclaimExpired() {
+	ctx, cancel := context.WithTimeout(context.Background(), reclaimInterval)
+	defer cancel()
+
+	go func() {
+		select {
+		case <-m.quit:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	expired, err := m.queries.ListExpiredStreamLeases(ctx, reclaimBatch)
+	if err != nil {
+		slog.Error("Failed to list expired stream leases", "error", err)
+		return
+	}
+
+	for _, l := range expired {
+		m.mu.Lock()
+		_, running := m.process[l.StreamId]
+		m.mu.Unlock()
+		if running || m.isRecovering(l.StreamId) {
+			continue
+		}
+
+		_, err := m.queries.GetLiveStreamByID(ctx, l.StreamId)
+		if errors.Is(err, sql.ErrNoRows) {
+			// the stream ended while its worker was gone
+			slog.Warn("Finalizing stream of an expired lease", "streamId", l.StreamId, "previousOwner", l.OwnerId, "expiredAt", l.ExpiresAt)
+			m.finalizeAbandoned(ctx, l.StreamId, l.Command)
+			continue
+		}
+		if err != nil {
+			slog.Error("Failed to load stream of expired lease", "streamId", l.StreamId, "error", err)
+			continue
+		}
+
+		var p model.StreamPayload
+		if err := json.Unmarshal(l.Command, &p); err != nil {
+			slog.Error("Failed to decode command of expired lease", "streamId", l.StreamId, "error", err)
+			continue
+		}
+
+		p.EventID = id.New()
+		p.Action = m.resumeAction(p)
+		p.RetryCount = 0
+		p.HandoffID = ""
+		p.OccurredAt = time.Now().UTC().Format(time.RFC3339Nano)
+
+		slog.Warn("Resuming stream of an expired lease", "streamId", l.StreamId, "previousOwner", l.OwnerId, "expiredAt", l.ExpiresAt, "action", p.Action)
+		if err := m.Dispatch(ctx, p); err != nil {
+			slog.Error("Failed to resume stream of expired lease", "streamId", l.StreamId, "error", err)
+		}
+	}
+}
@@ -1,39 +1,116 @@
 package manager
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log/slog"
-	"time"
 
+	"github.com/bitstream/backend-go/internal/domain/streaming/audit"
+	"github.com/bitstream/backend-go/internal/domain/streaming/dedupe"
 	"github.com/bitstream/backend-go/internal/domain/streaming/ffmpeg"
+	"github.com/bitstream/backend-go/internal/domain/streaming/lease"
+	"github.com/bitstream/backend-go/internal/domain/streaming/lifecycle"
 	"github.com/bitstream/backend-go/internal/domain/streaming/model"
 )
 
-func (m *StreamManager) handlePayload(p model.StreamPayload) {
-	var err error
+// stderrTailBytes is how much FFmpeg output a crash event keeps.
+const stderrTailBytes = 4096
+
+// handlePayload applies one command and accepts it as soon as its outcome
+// no longer depends on the Kafka message: a START once FFmpeg runs or its
+// retry is scheduled, a STOP once the process is taken out of service.
+func (m *StreamManager) handlePayload(cmd *command) {
+	p := cmd.payload
+
+	if stale, last := m.isStale(p); stale {
+		slog.Warn("Discarding command older than the last one applied",
+			"streamId", p.StreamID,
+			"action", p.Action,
+			"eventId", p.EventID,
+			"occurredAt", p.OccurredAt,
+			"lastApplied", last,
+		)
+		cmd.accept(nil)
+		return
+	}
+
+	// Kafka redelivers what was not committed before a rebalance
+	key := dedupe.Key{EventID: p.EventID, RetryCount: p.RetryCount}
+	if m.dedupe.Seen(key) {
+		slog.Info("Skipping already handled event", "eventId", p.EventID, "retryCount", p.RetryCount, "streamId", p.StreamID)
+		cmd.accept(nil)
+		return
+	}
 
 	switch p.Action {
-	case model.StreamStart:
-		err = m.startStream(p)
+	case model.StreamStart, model.StreamTakeover:
+		// a stream started now would be cut off by the shutdown; the
+		// uncommitted command goes to the next owner of the partition
+		if m.shuttingDown() {
+			cmd.accept(ErrShuttingDown)
+			return
+		}
+
+		err := m.startStream(p)
+		if err != nil {
+			slog.Error("Failed to handle stream action", "action", p.Action, "error", err, "streamId", p.StreamID)
+			err = m.attemptRetry(p, err)
+		}
+		if err == nil {
+			m.markApplied(p)
+			m.dedupe.Record(key, p.StreamID, string(p.Action))
+		}
+		// otherwise neither started nor retried: the redelivered message
+		// must run, so it stays unrecorded
+		cmd.accept(err)
 	case model.StreamStop:
-		err = m.stopStream(p)
+		m.markApplied(p)
+		m.dedupe.Record(key, p.StreamID, string(p.Action))
+		m.stopStream(p, cmd)
 	default:
 		slog.Warn("Unknown action", "action", p.Action, "streamId", p.StreamID)
-		return
-	}
-
-	if err != nil {
-		slog.Error("Failed to handle stream action", "action", p.Action, "error", err, "streamId", p.StreamID)
-		m.attemptRetry(p, err)
+		cmd.accept(nil)
 	}
 }
 
 func (m *StreamManager) startStream(p model.StreamPayload) error {
+	occurredAt, hasOccurredAt := p.OccurredTime()
+
 	m.mu.Lock()
-	_, exists := m.process[p.StreamID]
+	running, exists := m.process[p.StreamID]
+	servedAt, _ := m.started[p.StreamID].OccurredTime()
 	m.mu.Unlock()
 
+	// a START that is not newer than the one the healthy process serves is
+	// a stale copy; restarting would only cut off viewers
+	if exists && hasOccurredAt && running.Running() && !occurredAt.After(servedAt) {
+		slog.Info("Stream already running for this or a newer START, ignoring",
+			"streamId", p.StreamID,
+			"eventId", p.EventID,
+			"occurredAt", occurredAt,
+			"servingSince", servedAt,
+		)
+		return nil
+	}
+
+	// a handoff's lease moves over once the worker handing over stops
+	var held *lease.Lease
+	if p.Action != model.StreamTakeover || p.HandoffID == "" {
+		ctx, cancel := context.WithTimeout(context.Background(), leaseTimeout)
+		l, err := m.acquireLease(ctx, p)
+		cancel()
+		if err != nil {
+			return fmt.Errorf("failed to acquire stream lease: %w", err)
+		}
+		if l == nil {
+			slog.Info("Stream is run by another worker, ignoring", "streamId", p.StreamID, "eventId", p.EventID)
+			return nil
+		}
+		held = l
+	}
+
 	if exists {
 		slog.Warn("Stream already running, force stopping to restart", "streamId", p.StreamID)
 		if err := m.cleanupProcess(p.StreamID); err != nil {
@@ -41,36 +118,128 @@ func (m *StreamManager) startStream(p model.StreamPayload) error {
 		}
 	}
 
+	m.waitForRecovery(p.StreamID)
+
+	var takeover *ffmpeg.Takeover
+	if p.Action == model.StreamTakeover {
+		takeover = m.takeover(p, &held)
+		ffmpeg.ClearOutputFiles(ffmpeg.GetStreamDirectory(m.config.FFmpeg.OutputDir, p.StreamID))
+	}
+
+	layout := m.layoutFor(p)
+
 	proc, err := ffmpeg.NewStreamProcess(
 		p.StreamID,
 		p.RTMPUrl,
 		m.config.FFmpeg.OutputDir,
 		m.config.Env,
+		layout,
 		m.queries,
-		m.storage,
+		m.uploads,
+		m.events,
+		m.notify,
+		takeover,
 	)
 	if err != nil {
+		if held != nil {
+			m.leases.Release(held)
+		}
 		return err
 	}
 
+	m.events.Record(p.StreamID, audit.ProcessStarted, audit.Fields{
+		"retryCount": p.RetryCount,
+		"outputMode": layout.Mode,
+		"renditions": len(layout.Ladder),
+	})
+	m.notify.Publish(p.StreamID, lifecycle.TranscodeStarted, lifecycle.Data{
+		"retryCount": p.RetryCount,
+		"outputMode": layout.Mode,
+	})
+
+	m.finalizing.Add(1)
+	go func() {
+		defer m.finalizing.Done()
+		<-proc.Finalized()
+		if held != nil {
+			m.leases.Release(held)
+		}
+		if !proc.Relinquished() {
+			m.record(p.StreamID, layout)
+		}
+	}()
+
 	m.mu.Lock()
 	m.process[p.StreamID] = proc
+	m.started[p.StreamID] = p
 	m.mu.Unlock()
 
 	slog.Info(fmt.Sprintf("Stream process %s", string(p.Action)), "streamId", p.StreamID)
 
-	m.wg.Add(1)
+	m.monitors.Add(1)
 	go m.monitorProcess(p, proc)
 
 	return nil
 }
 
-func (m *StreamManager) stopStream(p model.StreamPayload) error {
-	return m.cleanupProcess(p.StreamID)
+// layoutFor is the output layout a command's stream is written in.
+func (m *StreamManager) layoutFor(p model.StreamPayload) ffmpeg.OutputLayout {
+	layout := ffmpeg.NewOutputLayout(
+		m.ladder,
+		ffmpeg.ResolveOutputMode(p.OutputMode, m.config.FFmpeg.OutputMode),
+	)
+	layout.PartTarget = ffmpeg.ResolvePartTarget(m.config.FFmpeg)
+	return layout
+}
+
+// record builds the downloadable recording once the stream's segments are
+// all uploaded. A worker shutdown aborts it.
+func (m *StreamManager) record(streamID string, layout ffmpeg.OutputLayout) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-m.quit:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	streamDir := ffmpeg.GetStreamDirectory(m.config.FFmpeg.OutputDir, streamID)
+	err := m.recorder.Record(ctx, streamID, layout.VideoRepIDs[0], layout.AudioRepID, ffmpeg.LocalSeqOffset(streamDir))
+	if err != nil {
+		slog.Error("Failed to create recording", "streamId", streamID, "error", err)
+	}
+}
+
+// stopStream accepts the STOP once the process no longer serves the stream
+// and then waits for FFmpeg to finish, which can take minutes. Later commands
+// of the stream wait in its mailbox meanwhile.
+func (m *StreamManager) stopStream(p model.StreamPayload, cmd *command) {
+	proc := m.detachProcess(p.StreamID)
+	cmd.accept(nil)
+
+	if proc == nil {
+		return
+	}
+
+	m.mu.Lock()
+	m.stopping[proc] = struct{}{}
+	m.mu.Unlock()
+	defer func() {
+		m.mu.Lock()
+		delete(m.stopping, proc)
+		m.mu.Unlock()
+	}()
+
+	slog.Info("Cleaning up stream process", "streamId", p.StreamID)
+	if err := proc.Stop(); err != nil {
+		slog.Error("Failed to stop stream", "streamId", p.StreamID, "error", err)
+	}
 }
 
 func (m *StreamManager) monitorProcess(p model.StreamPayload, proc *ffmpeg.StreamProcess) {
-	defer m.wg.Done()
+	defer m.monitors.Done()
 
 	<-proc.Done()
 
@@ -82,41 +251,101 @@ func (m *StreamManager) monitorProcess(p model.StreamPayload, proc *ffmpeg.Strea
 
 	err := proc.Error()
 	slog.Error("Stream process exited unexpectedly", "streamId", p.StreamID, "error", err)
+	m.events.Record(p.StreamID, audit.ProcessCrashed, audit.Fields{
+		"exitCode":   proc.ExitCode(),
+		"error":      fmt.Sprint(err),
+		"stderrTail": proc.StderrTail(stderrTailBytes),
+		"retryCount": p.RetryCount,
+	})
+	m.notify.Publish(p.StreamID, lifecycle.TranscodeCrashed, lifecycle.Data{
+		"exitCode":   proc.ExitCode(),
+		"retryCount": p.RetryCount,
+		"maxRetry":   p.MaxRetry,
+	})
 
 	m.cleanupProcess(p.StreamID)
 
-	m.attemptRetry(p, errors.New("process crashed"))
+	if proc.TookOver() {
+		// the stream is this worker's now: a retry resumes it
+		p.HandoffID = ""
+	}
+	_ = m.attemptRetry(p, errors.New("process crashed"))
 }
 
-func (m *StreamManager) attemptRetry(p model.StreamPayload, reason error) {
+// attemptRetry schedules the next attempt of a START, or gives the stream up
+// once its retries are exhausted. It fails only when the retry could not be
+// scheduled.
+func (m *StreamManager) attemptRetry(p model.StreamPayload, reason error) error {
 	if p.Action == model.StreamStop {
-		return
+		return nil
+	}
+
+	// a takeover that did not happen leaves the stream with the worker
+	// handing it over, which ends it
+	if p.HandoffID != "" {
+		return nil
 	}
 
 	if p.RetryCount >= p.MaxRetry {
 		slog.Error("Max retries reached, giving up", "streamId", p.StreamID)
+		m.events.Record(p.StreamID, audit.RetryExhausted, audit.Fields{
+			"retryCount": p.RetryCount,
+			"maxRetry":   p.MaxRetry,
+			"reason":     reason.Error(),
+		})
+		m.notify.Publish(p.StreamID, lifecycle.StreamFailed, lifecycle.Data{
+			"retryCount": p.RetryCount,
+			"reason":     reason.Error(),
+		})
 		_ = m.cleanupProcess(p.StreamID)
-		return
+		return nil
 	}
 
 	p.RetryCount++
-	delay := min(time.Duration(p.RetryCount)*2*time.Second, 30*time.Second)
 
-	slog.Info("Scheduling retry", "streamId", p.StreamID, "retryCount", p.RetryCount, "delay", delay)
+	// the retry goes through a delay topic, so it outlives this process and
+	// no worker sleeps on it
+	value, err := json.Marshal(p)
+	if err != nil {
+		slog.Error("Failed to encode retry", "streamId", p.StreamID, "error", err)
+		return err
+	}
+
+	tier, err := m.retries.Schedule(p.RetryCount, p.StreamID, value)
+	if err != nil {
+		slog.Error("Failed to schedule retry", "streamId", p.StreamID, "retryCount", p.RetryCount, "error", err)
+		return err
+	}
 
-	time.Sleep(delay)
-	m.Dispatch(p)
+	slog.Info("Scheduling retry", "streamId", p.StreamID, "retryCount", p.RetryCount, "delay", tier.Delay, "topic", tier.Topic)
+	m.events.Record(p.StreamID, audit.RetryScheduled, audit.Fields{
+		"retryCount": p.RetryCount,
+		"maxRetry":   p.MaxRetry,
+		"delayMs":    tier.Delay.Milliseconds(),
+		"topic":      tier.Topic,
+		"reason":     reason.Error(),
+	})
+	return nil
 }
 
-func (m *StreamManager) cleanupProcess(streamID string) error {
+// detachProcess removes the stream's process from the manager, so nothing
+// else stops or replaces it, and returns it.
+func (m *StreamManager) detachProcess(streamID string) *ffmpeg.StreamProcess {
 	m.mu.Lock()
+	defer m.mu.Unlock()
+
 	proc, ok := m.process[streamID]
-	if ok {
-		delete(m.process, streamID)
+	if !ok {
+		return nil
 	}
-	m.mu.Unlock()
+	delete(m.process, streamID)
+	delete(m.started, streamID)
+	return proc
+}
 
-	if !ok {
+func (m *StreamManager) cleanupProcess(streamID string) error {
+	proc := m.detachProcess(streamID)
+	if proc == nil {
 		return nil
 	}
 
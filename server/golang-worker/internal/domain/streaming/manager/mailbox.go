@@ -0,0 +1,188 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/bitstream/backend-go/internal/domain/streaming/model"
+)
+
+const (
+	// maxPending is how many commands may wait across all mailboxes before
+	// Dispatch blocks.
+	maxPending = 100
+
+	// appliedRetention is how long the last applied command of a stream
+	// that is no longer running is remembered to reject stale ones.
+	appliedRetention = time.Hour
+)
+
+var ErrShuttingDown = errors.New("stream manager is shutting down")
+
+// command is a queued payload and the outcome its sender waits for.
+type command struct {
+	payload  model.StreamPayload
+	accepted chan error
+	once     sync.Once
+}
+
+func (c *command) accept(err error) {
+	c.once.Do(func() {
+		c.accepted <- err
+	})
+}
+
+// mailbox holds the commands of one stream. They are handled one at a time,
+// in arrival order, by a goroutine that exits once the mailbox is empty.
+type mailbox struct {
+	queue []*command
+}
+
+// Dispatch queues a command behind the earlier commands of its stream, so a
+// STOP is never handled concurrently with, or before, the START it follows,
+// and waits until the command is accepted. While the manager is full it
+// blocks, which holds back the consumer instead of dropping the command.
+// When ctx ends first the command may still run; the redelivered message is
+// then caught by the dedupe store.
+func (m *StreamManager) Dispatch(ctx context.Context, payload model.StreamPayload) error {
+	slog.Info("Dispatching job", "streamId", payload.StreamID, "action", payload.Action)
+
+	select {
+	case m.capacity <- struct{}{}:
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-m.quit:
+		return ErrShuttingDown
+	}
+
+	cmd := &command{payload: payload, accepted: make(chan error, 1)}
+
+	m.mbMu.Lock()
+	box, ok := m.mailboxes[payload.StreamID]
+	if ok {
+		box.queue = append(box.queue, cmd)
+	} else {
+		box = &mailbox{queue: []*command{cmd}}
+		m.mailboxes[payload.StreamID] = box
+
+		m.wg.Add(1)
+		go m.drain(payload.StreamID, box)
+	}
+	m.mbMu.Unlock()
+
+	select {
+	case err := <-cmd.accepted:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (m *StreamManager) drain(streamID string, box *mailbox) {
+	defer m.wg.Done()
+
+	for {
+		m.mbMu.Lock()
+		if len(box.queue) == 0 {
+			delete(m.mailboxes, streamID)
+			m.mbMu.Unlock()
+			return
+		}
+		cmd := box.queue[0]
+		box.queue = box.queue[1:]
+		m.mbMu.Unlock()
+		<-m.capacity
+
+		select {
+		case <-m.quit:
+			cmd.accept(ErrShuttingDown)
+			m.mbMu.Lock()
+			for _, rest := range box.queue {
+				rest.accept(ErrShuttingDown)
+				<-m.capacity
+			}
+			box.queue = nil
+			delete(m.mailboxes, streamID)
+			m.mbMu.Unlock()
+			return
+		case m.slots <- struct{}{}:
+		}
+
+		m.handlePayload(cmd)
+		<-m.slots
+	}
+}
+
+func (m *StreamManager) shuttingDown() bool {
+	select {
+	case <-m.quit:
+		return true
+	default:
+		return false
+	}
+}
+
+// isStale reports whether a command is older than the last one applied to
+// its stream. A retry carries the time of the command it retries, so an
+// equal time is not stale; exact redeliveries are caught by the dedupe store.
+func (m *StreamManager) isStale(p model.StreamPayload) (bool, time.Time) {
+	occurredAt, ok := p.OccurredTime()
+	if !ok {
+		return false, time.Time{}
+	}
+
+	m.mbMu.Lock()
+	defer m.mbMu.Unlock()
+
+	last, applied := m.lastApplied[p.StreamID]
+	return applied && occurredAt.Before(last), last
+}
+
+func (m *StreamManager) markApplied(p model.StreamPayload) {
+	occurredAt, ok := p.OccurredTime()
+	if !ok {
+		return
+	}
+
+	m.mbMu.Lock()
+	defer m.mbMu.Unlock()
+
+	if occurredAt.After(m.lastApplied[p.StreamID]) {
+		m.lastApplied[p.StreamID] = occurredAt
+	}
+}
+
+// forgetApplied drops the last applied command of streams that stopped long
+// enough ago that no stale command for them is still in flight.
+func (m *StreamManager) forgetApplied() {
+	ticker := time.NewTicker(appliedRetention / 6)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-m.quit:
+			return
+		case <-ticker.C:
+		}
+
+		m.mu.Lock()
+		running := make(map[string]bool, len(m.process))
+		for streamID := range m.process {
+			running[streamID] = true
+		}
+		m.mu.Unlock()
+
+		cutoff := time.Now().Add(-appliedRetention)
+
+		m.mbMu.Lock()
+		for streamID, last := range m.lastApplied {
+			if !running[streamID] && last.Before(cutoff) {
+				delete(m.lastApplied, streamID)
+			}
+		}
+		m.mbMu.Unlock()
+	}
+}
@@ -0,0 +1,200 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/bitstream/backend-go/internal/domain/streaming/model"
+)
+
+// newMailboxManager returns a manager that can only queue commands. Its only
+// slot is taken, so nothing is handled until the test frees it.
+func newMailboxManager() *StreamManager {
+	m := &StreamManager{
+		mailboxes:   make(map[string]*mailbox),
+		capacity:    make(chan struct{}, maxPending),
+		slots:       make(chan struct{}, 1),
+		lastApplied: make(map[string]time.Time),
+		quit:        make(chan struct{}),
+	}
+	m.slots <- struct{}{}
+	return m
+}
+
+func payloadAt(streamID, eventID string, at time.Time) model.StreamPayload {
+	return model.StreamPayload{
+		StreamID:   streamID,
+		EventID:    eventID,
+		Action:     model.StreamStart,
+		OccurredAt: at.Format(time.RFC3339Nano),
+	}
+}
+
+// waitFor polls cond, which is checked under the mailbox lock.
+func waitFor(t *testing.T, m *StreamManager, what string, cond func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		m.mbMu.Lock()
+		ok := cond()
+		m.mbMu.Unlock()
+		if ok {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("timed out waiting until %s", what)
+}
+
+func TestIsStale(t *testing.T) {
+	m := newMailboxManager()
+	applied := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	m.markApplied(payloadAt("s1", "e1", applied))
+
+	tests := []struct {
+		name    string
+		payload model.StreamPayload
+		want    bool
+	}{
+		{name: "older", payload: payloadAt("s1", "e0", applied.Add(-time.Millisecond)), want: true},
+		{name: "retry of the applied command", payload: payloadAt("s1", "e1", applied), want: false},
+		{name: "newer", payload: payloadAt("s1", "e2", applied.Add(time.Second)), want: false},
+		{name: "other stream", payload: payloadAt("s2", "e3", applied.Add(-time.Hour)), want: false},
+		{name: "no time", payload: model.StreamPayload{StreamID: "s1", EventID: "e4"}, want: false},
+		{name: "invalid time", payload: model.StreamPayload{StreamID: "s1", EventID: "e5", OccurredAt: "yesterday"}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stale, last := m.isStale(tt.payload)
+			if stale != tt.want {
+				t.Errorf("isStale() = %v, want %v", stale, tt.want)
+			}
+			if stale && !last.Equal(applied) {
+				t.Errorf("isStale() last applied = %v, want %v", last, applied)
+			}
+		})
+	}
+}
+
+func TestMarkAppliedKeepsLatest(t *testing.T) {
+	m := newMailboxManager()
+	latest := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	m.markApplied(payloadAt("s1", "e2", latest))
+	m.markApplied(payloadAt("s1", "e1", latest.Add(-time.Minute)))
+	m.markApplied(model.StreamPayload{StreamID: "s1", EventID: "e3"})
+
+	if got := m.lastApplied["s1"]; !got.Equal(latest) {
+		t.Errorf("last applied = %v, want %v", got, latest)
+	}
+}
+
+func TestDispatchQueuesPerStream(t *testing.T) {
+	m := newMailboxManager()
+	ctx := context.Background()
+
+	// every command is stale, so handling one only accepts it
+	applied := time.Now()
+	m.lastApplied["s1"] = applied
+	m.lastApplied["s2"] = applied
+	older := applied.Add(-time.Minute)
+
+	results := make(chan error, 3)
+	dispatch := func(p model.StreamPayload) {
+		go func() { results <- m.Dispatch(ctx, p) }()
+	}
+
+	dispatch(payloadAt("s1", "first", older))
+	waitFor(t, m, "the first command waits for a slot", func() bool {
+		box := m.mailboxes["s1"]
+		return box != nil && len(box.queue) == 0
+	})
+
+	dispatch(payloadAt("s1", "second", older))
+	dispatch(payloadAt("s2", "other", older))
+	waitFor(t, m, "the second command queues behind the first", func() bool {
+		box := m.mailboxes["s1"]
+		return box != nil && len(box.queue) == 1 && box.queue[0].payload.EventID == "second" &&
+			m.mailboxes["s2"] != nil
+	})
+
+	select {
+	case err := <-results:
+		t.Fatalf("Dispatch returned %v before its command was handled", err)
+	default:
+	}
+
+	<-m.slots
+	for range 3 {
+		select {
+		case err := <-results:
+			if err != nil {
+				t.Errorf("Dispatch() = %v, want nil", err)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("Dispatch did not return once a slot was free")
+		}
+	}
+
+	m.wg.Wait()
+	if len(m.mailboxes) != 0 {
+		t.Errorf("%d mailboxes left once empty", len(m.mailboxes))
+	}
+	if len(m.capacity) != 0 {
+		t.Errorf("%d commands still counted as pending", len(m.capacity))
+	}
+}
+
+func TestDispatchShutdown(t *testing.T) {
+	m := newMailboxManager()
+	ctx := context.Background()
+
+	results := make(chan error, 2)
+	go func() { results <- m.Dispatch(ctx, payloadAt("s1", "first", time.Now())) }()
+	waitFor(t, m, "the first command waits for a slot", func() bool {
+		box := m.mailboxes["s1"]
+		return box != nil && len(box.queue) == 0
+	})
+	go func() { results <- m.Dispatch(ctx, payloadAt("s1", "second", time.Now())) }()
+	waitFor(t, m, "the second command is queued", func() bool {
+		box := m.mailboxes["s1"]
+		return box != nil && len(box.queue) == 1
+	})
+
+	close(m.quit)
+	for range 2 {
+		if err := <-results; !errors.Is(err, ErrShuttingDown) {
+			t.Errorf("Dispatch() = %v, want %v", err, ErrShuttingDown)
+		}
+	}
+
+	m.wg.Wait()
+	if len(m.capacity) != 0 {
+		t.Errorf("%d commands still counted as pending", len(m.capacity))
+	}
+}
+
+func TestDispatchBlocksWhenFull(t *testing.T) {
+	m := newMailboxManager()
+	for range maxPending {
+		m.capacity <- struct{}{}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := m.Dispatch(ctx, payloadAt("s1", "e1", time.Now())); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Dispatch() = %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	close(m.quit)
+	if err := m.Dispatch(context.Background(), payloadAt("s1", "e2", time.Now())); !errors.Is(err, ErrShuttingDown) {
+		t.Errorf("Dispatch() = %v, want %v", err, ErrShuttingDown)
+	}
+	if len(m.mailboxes) != 0 {
+		t.Errorf("a command was queued while the manager was full")
+	}
+}
@@ -1,89 +1,220 @@
 package manager
 
 import (
+	"context"
+	"fmt"
 	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/bitstream/backend-go/internal/config"
 	stream "github.com/bitstream/backend-go/internal/db/generated"
+	"github.com/bitstream/backend-go/internal/domain/streaming/audit"
+	"github.com/bitstream/backend-go/internal/domain/streaming/dedupe"
 	"github.com/bitstream/backend-go/internal/domain/streaming/ffmpeg"
+	"github.com/bitstream/backend-go/internal/domain/streaming/lease"
+	"github.com/bitstream/backend-go/internal/domain/streaming/lifecycle"
 	"github.com/bitstream/backend-go/internal/domain/streaming/model"
+	"github.com/bitstream/backend-go/internal/domain/streaming/recording"
+	"github.com/bitstream/backend-go/internal/kafka/producer"
+	"github.com/bitstream/backend-go/internal/kafka/retry"
 	"github.com/bitstream/backend-go/internal/storage/minio"
+	"github.com/bitstream/backend-go/internal/storage/uploader"
+	"github.com/bitstream/backend-go/pkg/id"
 )
 
+// workerIDFile keeps the generated worker ID in the output directory.
+const workerIDFile = "worker-id"
+
 type StreamManager struct {
-	config  *config.AppConfig
-	queries *stream.Queries
-	storage *minio.Service
+	config   *config.AppConfig
+	workerID string
+	queries  *stream.Queries
+	storage  *minio.Service
+	uploads  *uploader.Pool
+	recorder *recording.Recorder
+	events   *audit.Recorder
+	notify   *lifecycle.Publisher
+	retries  *retry.Scheduler
+	commands *producer.Producer
+	dedupe   *dedupe.Store
+	leases   *lease.Store
+	ladder   []config.RenditionConfig
+
+	process    map[string]*ffmpeg.StreamProcess
+	started    map[string]model.StreamPayload // the command each process serves
+	stopping   map[*ffmpeg.StreamProcess]struct{}
+	mu         sync.Mutex
+	wg         sync.WaitGroup // mailboxes
+	monitors   sync.WaitGroup
+	finalizing sync.WaitGroup
+
+	// mailboxes serialize the commands of each stream; slots bounds how
+	// many streams are handled at once
+	mailboxes   map[string]*mailbox
+	capacity    chan struct{}
+	slots       chan struct{}
+	lastApplied map[string]time.Time
+	mbMu        sync.Mutex
+
+	quit chan struct{}
+
+	// leases are renewed until the streams are finalized, after quit
+	leasesDone chan struct{}
+
+	// recovering holds, per stream, the recovery of what a previous run left
+	// behind; closed once it is done
+	recovering map[string]chan struct{}
 
-	process map[string]*ffmpeg.StreamProcess
-	mu      sync.Mutex
-	wg      sync.WaitGroup
+	gc *GarbageCollector
+}
 
-	actionChan chan model.StreamPayload
-	quit       chan struct{}
+func NewStreamManager(
+	cfg *config.AppConfig,
+	queries *stream.Queries,
+	storage *minio.Service,
+	notify *lifecycle.Publisher,
+	retries *retry.Scheduler,
+	commands *producer.Producer,
+) *StreamManager {
+	workerID := cfg.WorkerID
+	if workerID == "" {
+		workerID = loadWorkerID(cfg.FFmpeg.OutputDir)
+	}
 
-	gc *GarbageCollector
+	m := &StreamManager{
+		config:      cfg,
+		workerID:    workerID,
+		queries:     queries,
+		storage:     storage,
+		uploads:     uploader.NewPool(cfg.Upload, storage),
+		recorder:    recording.NewRecorder(queries, storage, cfg.FFmpeg.OutputDir),
+		events:      audit.NewRecorder(queries),
+		notify:      notify,
+		retries:     retries,
+		commands:    commands,
+		dedupe:      dedupe.NewStore(queries),
+		leases:      lease.NewStore(queries, workerID),
+		ladder:      ffmpeg.ResolveLadder(cfg.FFmpeg),
+		process:     make(map[string]*ffmpeg.StreamProcess),
+		started:     make(map[string]model.StreamPayload),
+		stopping:    make(map[*ffmpeg.StreamProcess]struct{}),
+		mailboxes:   make(map[string]*mailbox),
+		capacity:    make(chan struct{}, maxPending),
+		lastApplied: make(map[string]time.Time),
+		quit:        make(chan struct{}),
+		leasesDone:  make(chan struct{}),
+		recovering:  make(map[string]chan struct{}),
+	}
+	m.gc = NewGarbageCollector(queries, cfg.FFmpeg.OutputDir, m.isRecovering)
+	return m
 }
 
-func NewStreamManager(cfg *config.AppConfig, queries *stream.Queries, storage *minio.Service) *StreamManager {
-	return &StreamManager{
-		config:     cfg,
-		queries:    queries,
-		storage:    storage,
-		process:    make(map[string]*ffmpeg.StreamProcess),
-		actionChan: make(chan model.StreamPayload, 100),
-		quit:       make(chan struct{}),
-		gc:         NewGarbageCollector(queries, cfg.FFmpeg.OutputDir),
+// loadWorkerID names this worker in the rows it shares with other workers.
+// The name is generated once and kept in the output directory, so a worker
+// restarted on it recognises the leases of the streams it left there.
+func loadWorkerID(outputDir string) string {
+	path := filepath.Join(outputDir, workerIDFile)
+	if data, err := os.ReadFile(path); err == nil {
+		if workerID := strings.TrimSpace(string(data)); workerID != "" {
+			return workerID
+		}
+	}
+
+	host, err := os.Hostname()
+	if err != nil {
+		host = "worker"
+	}
+	workerID := fmt.Sprintf("%s-%s", host, id.New())
+
+	err = os.MkdirAll(outputDir, 0755)
+	if err == nil {
+		err = os.WriteFile(path, []byte(workerID+"\n"), 0644)
 	}
+	if err != nil {
+		slog.Warn("Failed to save worker ID, a restart will not recover its streams", "workerId", workerID, "error", err)
+	}
+	return workerID
 }
 
 func (m *StreamManager) Start(workers int) {
-	slog.Info("Starting stream manager", "worker_count", workers)
+	slog.Info("Starting stream manager", "worker_count", workers, "workerId", m.workerID)
 
-	go m.gc.Run()
+	m.slots = make(chan struct{}, workers)
 
-	for i := range workers {
-		m.wg.Add(1)
-		go m.worker(i)
-	}
+	m.uploads.Start()
+	go m.leases.Run(m.leasesDone, m.leaseLost)
+	m.recoverStreams()
+	go m.gc.Run()
+	go m.dedupe.Run(m.quit)
+	go m.forgetApplied()
+	go m.reclaimLeases()
 }
 
-func (m *StreamManager) Shutdown() {
+// Shutdown runs once the consumers are stopped: it hands the running
+// streams over to other workers when enabled, lets the mailboxes finish the
+// command in hand, stops every FFmpeg process, and waits for their segments
+// to be uploaded and the streams finalized. What is not done when ctx ends
+// is left to the upload journals and the next start.
+func (m *StreamManager) Shutdown(ctx context.Context) {
 	close(m.quit)
 
 	m.gc.Stop()
 
-	m.wg.Wait()
-	close(m.actionChan)
-	m.mu.Lock()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
 
-	defer m.mu.Unlock()
-	for _, proc := range m.process {
-		_ = proc.Stop()
-	}
-}
+		m.handOffAll(ctx)
+
+		// a STOP in hand may be waiting minutes for the source to end;
+		// interrupting first lets its mailbox finish
+		m.interruptAll()
+		m.wg.Wait()
+		// and what a START in hand launched meanwhile
+		m.interruptAll()
+
+		m.monitors.Wait()
+		m.finalizing.Wait()
+	}()
 
-func (m *StreamManager) Dispatch(payload model.StreamPayload) {
-	slog.Info("Dispatching job", "streamId", payload.StreamID, "action", payload.Action)
 	select {
-	case m.actionChan <- payload:
-	default:
-		slog.Error("Stream manager queue is full", "streamId", payload.StreamID)
+	case <-done:
+	case <-ctx.Done():
+		slog.Error("Shutdown deadline reached, leaving streams unfinalized", "error", ctx.Err())
 	}
+
+	// the leases of unfinalized streams expire, and other workers resume them
+	close(m.leasesDone)
+
+	m.uploads.Stop()
 }
 
-func (m *StreamManager) worker(id int) {
-	defer m.wg.Done()
-	for {
-		select {
-		case <-m.quit:
-			return
-		case job, ok := <-m.actionChan:
-			if !ok {
-				return
+// interruptAll stops every process that runs or is being stopped, in
+// parallel, and returns once they have exited.
+func (m *StreamManager) interruptAll() {
+	m.mu.Lock()
+	procs := make([]*ffmpeg.StreamProcess, 0, len(m.process)+len(m.stopping))
+	for _, proc := range m.process {
+		procs = append(procs, proc)
+	}
+	for proc := range m.stopping {
+		procs = append(procs, proc)
+	}
+	m.mu.Unlock()
+
+	var wg sync.WaitGroup
+	for _, proc := range procs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := proc.Interrupt(); err != nil {
+				slog.Error("Failed to stop stream on shutdown", "streamId", proc.StreamID, "error", err)
 			}
-			m.handlePayload(job)
-		}
+		}()
 	}
+	wg.Wait()
 }
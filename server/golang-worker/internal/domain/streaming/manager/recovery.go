@@ -0,0 +1,335 @@
+package manager
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+
+	stream "github.com/bitstream/backend-go/internal/db/generated"
+	"github.com/bitstream/backend-go/internal/domain/streaming/ffmpeg"
+	"github.com/bitstream/backend-go/internal/domain/streaming/model"
+	"github.com/bitstream/backend-go/internal/storage/uploader"
+	"github.com/bitstream/backend-go/pkg/id"
+)
+
+// liveStreamsLimit bounds the live streams listed on recovery. When there
+// are more, every recovered stream's source is probed instead.
+const liveStreamsLimit = 1000
+
+// recoverStreams picks up what a previous run of this worker left behind.
+// The streams whose leases it still owns were running when it was killed:
+// what their processes left is uploaded, then each is resumed if its source
+// still publishes, and finalized otherwise. The other stream directories
+// with uploads left are finalized from when their stream ended meanwhile,
+// and only have their uploads replayed otherwise. A stream that starts
+// again meanwhile waits for its recovery first.
+func (m *StreamManager) recoverStreams() {
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-m.quit
+		cancel()
+	}()
+
+	owned := m.ownedLeases(ctx)
+	if len(owned) > 0 {
+		live := m.liveStreams(ctx)
+		for _, l := range owned {
+			done := m.markRecovering(l.StreamId)
+
+			m.finalizing.Add(1)
+			go func() {
+				defer m.finalizing.Done()
+				m.recoverStream(ctx, l, live, done)
+			}()
+		}
+	}
+
+	entries, err := os.ReadDir(m.config.FFmpeg.OutputDir)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			slog.Error("Failed to scan output directory for upload journals", "error", err)
+		}
+		return
+	}
+
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		if _, ok := owned[entry.Name()]; ok {
+			continue
+		}
+		m.recoverDirectory(ctx, entry.Name())
+	}
+}
+
+// recoverDirectory recovers a stream directory whose lease this worker no
+// longer holds. Unless another worker holds it, a stream that ended is
+// finalized from what was left; otherwise only the journaled uploads are
+// replayed.
+func (m *StreamManager) recoverDirectory(ctx context.Context, streamID string) {
+	if !uploader.HasPendingJournal(filepath.Join(m.config.FFmpeg.OutputDir, streamID)) {
+		return
+	}
+
+	_, err := m.queries.GetLiveStreamByID(ctx, streamID)
+	if errors.Is(err, sql.ErrNoRows) {
+		command, err := json.Marshal(model.StreamPayload{StreamID: streamID})
+		if err == nil && m.finalizeAbandoned(ctx, streamID, command) {
+			return
+		}
+	} else if err != nil {
+		slog.Warn("Failed to load stream of recovered directory, only replaying its uploads", "streamId", streamID, "error", err)
+	}
+
+	m.recoverUploads(ctx, streamID)
+}
+
+// finalizeAbandoned finalizes, in the background, a stream that ended while
+// the worker running it was gone, as recoverStream does: what is left of it
+// here is uploaded, its metadata and VOD manifest written, and its recording
+// built. It holds the stream's lease meanwhile, and reports false when
+// another worker holds it.
+func (m *StreamManager) finalizeAbandoned(ctx context.Context, streamID string, command []byte) bool {
+	held, err := m.leases.Acquire(ctx, streamID, command)
+	if err != nil {
+		slog.Error("Failed to acquire lease of abandoned stream", "streamId", streamID, "error", err)
+		return false
+	}
+	if held == nil {
+		return false
+	}
+
+	// the command of an expired lease tells how the stream was written
+	p := model.StreamPayload{StreamID: streamID}
+	if err := json.Unmarshal(command, &p); err != nil {
+		slog.Warn("Failed to decode command of abandoned stream, finalizing with the default layout", "streamId", streamID, "error", err)
+	}
+	layout := m.layoutFor(p)
+
+	done := m.markRecovering(streamID)
+
+	m.finalizing.Add(1)
+	go func() {
+		defer m.finalizing.Done()
+
+		ffmpeg.RecoverStream(
+			streamID,
+			m.config.FFmpeg.OutputDir,
+			layout,
+			m.queries,
+			m.uploads,
+			m.events,
+			m.notify,
+			true,
+		)
+		m.finishRecovery(streamID, done)
+
+		m.leases.Release(held)
+		m.record(streamID, layout)
+	}()
+	return true
+}
+
+// ownedLeases returns the leases this worker holds, by stream. With a
+// stable worker ID they are the streams it was running when it died.
+func (m *StreamManager) ownedLeases(ctx context.Context) map[string]stream.StreamLease {
+	ctx, cancel := context.WithTimeout(ctx, leaseTimeout)
+	defer cancel()
+
+	leases, err := m.queries.ListStreamLeasesByOwner(ctx, m.workerID)
+	if err != nil {
+		slog.Error("Failed to list owned stream leases", "workerId", m.workerID, "error", err)
+		return nil
+	}
+
+	owned := make(map[string]stream.StreamLease, len(leases))
+	for _, l := range leases {
+		owned[l.StreamId] = l
+	}
+	return owned
+}
+
+// liveStreams returns the streams still live, or nil when they could not
+// all be listed.
+func (m *StreamManager) liveStreams(ctx context.Context) map[string]bool {
+	streams, err := m.queries.ListLiveStreams(ctx, liveStreamsLimit)
+	if err != nil {
+		slog.Warn("Failed to list live streams, probing every recovered source", "error", err)
+		return nil
+	}
+	if len(streams) == liveStreamsLimit {
+		return nil
+	}
+
+	live := make(map[string]bool, len(streams))
+	for _, s := range streams {
+		live[s.ID] = true
+	}
+	return live
+}
+
+// recoverStream uploads what the process of a stream this worker was
+// running left behind, then resumes or finalizes the stream.
+func (m *StreamManager) recoverStream(ctx context.Context, l stream.StreamLease, live map[string]bool, done chan struct{}) {
+	recovered := func() { m.finishRecovery(l.StreamId, done) }
+
+	var p model.StreamPayload
+	if err := json.Unmarshal(l.Command, &p); err != nil {
+		slog.Error("Failed to decode command of owned lease", "streamId", l.StreamId, "error", err)
+		m.replayJournal(ctx, l.StreamId)
+		recovered()
+		return
+	}
+
+	// hold the lease again first, or a worker reclaiming it meanwhile would
+	// resume the stream while its segments are still uploaded here
+	held, err := m.leases.Acquire(ctx, p.StreamID, l.Command)
+	if err != nil {
+		slog.Error("Failed to acquire lease of recovered stream, only replaying its uploads", "streamId", p.StreamID, "error", err)
+	} else if held == nil {
+		slog.Warn("Stream was resumed by another worker, only replaying its uploads", "streamId", p.StreamID)
+	}
+	if held == nil {
+		m.replayJournal(ctx, p.StreamID)
+		recovered()
+		return
+	}
+
+	resume := m.sourcePublishing(ctx, p, live)
+	layout := m.layoutFor(p)
+
+	ffmpeg.RecoverStream(
+		p.StreamID,
+		m.config.FFmpeg.OutputDir,
+		layout,
+		m.queries,
+		m.uploads,
+		m.events,
+		m.notify,
+		!resume,
+	)
+	recovered()
+
+	if !resume {
+		m.leases.Release(held)
+		m.record(p.StreamID, layout)
+		return
+	}
+
+	// OccurredAt stays the one of the command that started the stream, so a
+	// command that arrived since wins
+	p.EventID = id.New()
+	p.Action = m.resumeAction(p)
+	p.RetryCount = 0
+	p.HandoffID = ""
+
+	if err := m.Dispatch(ctx, p); err != nil {
+		// the lease expires, and another worker resumes the stream
+		slog.Error("Failed to resume recovered stream", "streamId", p.StreamID, "error", err)
+		return
+	}
+
+	// the resumed stream holds the lease by now, unless a newer command won
+	m.leases.Release(held)
+}
+
+// sourcePublishing reports whether a recovered stream is still live and its
+// RTMP source still publishing, so it is worth resuming.
+func (m *StreamManager) sourcePublishing(ctx context.Context, p model.StreamPayload, live map[string]bool) bool {
+	if live != nil && !live[p.StreamID] {
+		slog.Info("Stream ended while the worker was down", "streamId", p.StreamID)
+		return false
+	}
+
+	if err := ffmpeg.ProbeSource(ctx, p.RTMPUrl); err != nil {
+		slog.Info("Stream source is no longer publishing", "streamId", p.StreamID, "error", err)
+		return false
+	}
+	return true
+}
+
+// recoverUploads replays, in the background, the upload journal a previous
+// run left in a stream directory.
+func (m *StreamManager) recoverUploads(ctx context.Context, streamID string) {
+	streamDir := filepath.Join(m.config.FFmpeg.OutputDir, streamID)
+	if !uploader.HasPendingJournal(streamDir) {
+		return
+	}
+
+	done := m.markRecovering(streamID)
+
+	m.finalizing.Add(1)
+	go func() {
+		defer m.finalizing.Done()
+		defer m.finishRecovery(streamID, done)
+
+		m.replayJournal(ctx, streamID)
+	}()
+}
+
+func (m *StreamManager) replayJournal(ctx context.Context, streamID string) {
+	journal, err := uploader.OpenJournal(filepath.Join(m.config.FFmpeg.OutputDir, streamID))
+	if err != nil {
+		slog.Error("Failed to open upload journal", "streamId", streamID, "error", err)
+		return
+	}
+	if journal.Len() == 0 {
+		return
+	}
+
+	slog.Info("Replaying upload journal", "streamId", streamID, "pending", journal.Len())
+	if remaining := m.uploads.ReplayJournal(ctx, journal); remaining > 0 {
+		slog.Warn("Upload journal not fully drained", "streamId", streamID, "remaining", remaining)
+		return
+	}
+	slog.Info("Upload journal drained", "streamId", streamID)
+}
+
+func (m *StreamManager) markRecovering(streamID string) chan struct{} {
+	done := make(chan struct{})
+	m.mu.Lock()
+	m.recovering[streamID] = done
+	m.mu.Unlock()
+	return done
+}
+
+// finishRecovery lets the commands waiting for the recovery of streamID go
+// ahead.
+func (m *StreamManager) finishRecovery(streamID string, done chan struct{}) {
+	m.mu.Lock()
+	delete(m.recovering, streamID)
+	m.mu.Unlock()
+	close(done)
+}
+
+// isRecovering reports whether what a previous run left of a stream is still
+// being recovered.
+func (m *StreamManager) isRecovering(streamID string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	_, ok := m.recovering[streamID]
+	return ok
+}
+
+// waitForRecovery blocks until the recovery of streamID, if any, has
+// finished, so two writers never share a stream directory.
+func (m *StreamManager) waitForRecovery(streamID string) {
+	m.mu.Lock()
+	done, ok := m.recovering[streamID]
+	m.mu.Unlock()
+
+	if !ok {
+		return
+	}
+
+	slog.Info("Waiting for stream recovery before starting", "streamId", streamID)
+	select {
+	case <-done:
+	case <-m.quit:
+	}
+}
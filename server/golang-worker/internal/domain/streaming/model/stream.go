@@ -1,10 +1,16 @@
 package model
 
+import "time"
+
 type StreamAction string
 
 const (
 	StreamStart StreamAction = "START"
 	StreamStop  StreamAction = "STOP"
+
+	// StreamTakeover continues a running stream on this worker, numbering
+	// segments after the ones already stored. Workers send it to each other.
+	StreamTakeover StreamAction = "TAKEOVER"
 )
 
 type StreamPayload struct {
@@ -14,8 +20,24 @@ type StreamPayload struct {
 
 	RTMPUrl string `json:"rtmpUrl"`
 
+	// OutputMode overrides FFmpegConfig.OutputMode for this stream ("dash", "cmaf" or "llhls").
+	OutputMode string `json:"outputMode"`
+
 	RetryCount int `json:"retryCount"`
 	MaxRetry   int `json:"maxRetry"`
 
 	OccurredAt string `json:"occurredAt"`
+
+	// HandoffID names the StreamHandoff a TAKEOVER completes. A TAKEOVER
+	// without one resumes the stream without waiting for another worker.
+	HandoffID string `json:"handoffId,omitempty"`
+}
+
+// OccurredTime parses OccurredAt, which the API writes as an ISO timestamp.
+func (p StreamPayload) OccurredTime() (time.Time, bool) {
+	t, err := time.Parse(time.RFC3339Nano, p.OccurredAt)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
 }
@@ -0,0 +1,324 @@
+package recording
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	stream "github.com/bitstream/backend-go/internal/db/generated"
+	"github.com/bitstream/backend-go/internal/storage/minio"
+	"github.com/bitstream/backend-go/pkg/id"
+)
+
+const (
+	FileName    = "recording.mp4"
+	contentType = "video/mp4"
+)
+
+// Recorder turns the segments of a finished stream into one progressive
+// MP4 that viewers can download, and records it as a Recording row.
+type Recorder struct {
+	queries   *stream.Queries
+	storage   *minio.Service
+	outputDir string
+}
+
+func NewRecorder(queries *stream.Queries, storage *minio.Service, outputDir string) *Recorder {
+	return &Recorder{
+		queries:   queries,
+		storage:   storage,
+		outputDir: outputDir,
+	}
+}
+
+func remotePath(streamID, filename string) string {
+	return fmt.Sprintf("streams/%s/%s", streamID, filename)
+}
+
+// Record remuxes the stream's top video representation and its audio into
+// a faststart MP4, uploads it next to the segments and inserts the Recording.
+// Segments still on local disk are read from there, the rest from storage.
+// The local ones are numbered localOffset below the stored ones when the
+// process that wrote them took the stream over.
+func (r *Recorder) Record(ctx context.Context, streamID, videoRepID, audioRepID string, localOffset int) error {
+	meta, err := r.queries.GetStreamMeta(ctx, streamID)
+	if errors.Is(err, sql.ErrNoRows) {
+		slog.Info("No stream meta, nothing to record", "streamId", streamID)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load stream meta: %w", err)
+	}
+
+	segments := int(meta.LastSegmentSeq.Int32)
+	if !meta.LastSegmentSeq.Valid || segments <= 0 {
+		slog.Info("Stream has no segments, nothing to record", "streamId", streamID)
+		return nil
+	}
+
+	fileURL := remotePath(streamID, FileName)
+	existing, err := r.queries.GetRecordingsByStream(ctx, streamID)
+	if err != nil {
+		return fmt.Errorf("failed to list recordings: %w", err)
+	}
+	for _, rec := range existing {
+		if rec.FileUrl == fileURL {
+			slog.Info("Stream already recorded", "streamId", streamID)
+			return nil
+		}
+	}
+
+	streamDir := filepath.Join(r.outputDir, streamID)
+	if err := os.MkdirAll(streamDir, 0755); err != nil {
+		return err
+	}
+	workDir, err := os.MkdirTemp(streamDir, "recording-")
+	if err != nil {
+		return fmt.Errorf("failed to create recording directory: %w", err)
+	}
+	defer os.RemoveAll(workDir)
+
+	start := time.Now()
+	slog.Info("Building recording", "streamId", streamID, "segments", segments)
+
+	videoPath := filepath.Join(workDir, "video.mp4")
+	if err := r.concat(ctx, streamID, streamDir, videoRepID, segments, localOffset, videoPath); err != nil {
+		return fmt.Errorf("failed to assemble video track: %w", err)
+	}
+
+	audioPath := filepath.Join(workDir, "audio.mp4")
+	if err := r.concat(ctx, streamID, streamDir, audioRepID, segments, localOffset, audioPath); err != nil {
+		// sources without audio never produce the audio representation
+		slog.Info("Recording without audio track", "streamId", streamID, "reason", err)
+		audioPath = ""
+	}
+
+	outPath := filepath.Join(workDir, FileName)
+	if err := remux(ctx, videoPath, audioPath, outPath); err != nil {
+		return err
+	}
+
+	info, err := os.Stat(outPath)
+	if err != nil {
+		return err
+	}
+
+	if err := r.storage.UploadFile(ctx, outPath, fileURL, contentType, ""); err != nil {
+		return err
+	}
+
+	err = r.queries.CreateRecording(ctx, stream.CreateRecordingParams{
+		ID:       id.New(),
+		StreamId: streamID,
+		FileUrl:  fileURL,
+		Duration: sql.NullInt32{Valid: true, Int32: int32(math.Round(meta.TotalDuration))},
+		Size:     sql.NullInt64{Valid: true, Int64: info.Size()},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create recording: %w", err)
+	}
+
+	slog.Info("Recording created",
+		"streamId", streamID,
+		"fileUrl", fileURL,
+		"size", info.Size(),
+		"took", time.Since(start).Round(time.Millisecond),
+	)
+	return nil
+}
+
+// initRange is a run of media segments that decode with one init segment.
+type initRange struct {
+	init     string
+	from, to int
+}
+
+// initRanges splits the segments of a representation by the init segment
+// they decode with. A takeover that replaced the init segment kept the one
+// before it, named after the last segment it applies to.
+func (r *Recorder) initRanges(ctx context.Context, streamID, repID string, segments int) ([]initRange, error) {
+	prefix := remotePath(streamID, fmt.Sprintf("init-%s-", repID))
+	names, err := r.storage.ListObjects(ctx, prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	var bounds []int
+	for _, name := range names {
+		var seq int
+		if _, err := fmt.Sscanf(strings.TrimPrefix(name, prefix), "%d.mp4", &seq); err == nil && seq > 0 {
+			bounds = append(bounds, seq)
+		}
+	}
+	sort.Ints(bounds)
+
+	var ranges []initRange
+	from := 1
+	for _, seq := range bounds {
+		if seq >= from {
+			ranges = append(ranges, initRange{init: fmt.Sprintf("init-%s-%d.mp4", repID, seq), from: from, to: min(seq, segments)})
+			from = seq + 1
+		}
+	}
+	if from <= segments {
+		ranges = append(ranges, initRange{init: fmt.Sprintf("init-%s.mp4", repID), from: from, to: segments})
+	}
+	return ranges, nil
+}
+
+// concat writes a representation as MP4: the media segments of each of
+// its init ranges after their init segment, which is a valid fragmented
+// MP4, joined into one file when the init segment changed. A missing media
+// segment is skipped so one lost upload does not cost the whole recording.
+func (r *Recorder) concat(ctx context.Context, streamID, streamDir, repID string, segments, localOffset int, dst string) error {
+	ranges, err := r.initRanges(ctx, streamID, repID, segments)
+	if err != nil {
+		return err
+	}
+
+	var parts []string
+	for i, rng := range ranges {
+		part := dst
+		if len(ranges) > 1 {
+			part = fmt.Sprintf("%s.%d.mp4", strings.TrimSuffix(dst, ".mp4"), i)
+		}
+
+		written, err := r.writeRange(ctx, streamID, streamDir, repID, rng, localOffset, part)
+		if err != nil {
+			return err
+		}
+		if written > 0 {
+			parts = append(parts, part)
+		}
+	}
+
+	if len(parts) == 0 {
+		return fmt.Errorf("no segment of representation %s found", repID)
+	}
+	if len(parts) == 1 {
+		if parts[0] == dst {
+			return nil
+		}
+		return os.Rename(parts[0], dst)
+	}
+	return join(ctx, parts, dst)
+}
+
+// writeRange writes the init segment of rng followed by its media segments
+// and returns how many of them were found.
+func (r *Recorder) writeRange(ctx context.Context, streamID, streamDir, repID string, rng initRange, localOffset int, dst string) (int, error) {
+	out, err := os.Create(dst)
+	if err != nil {
+		return 0, err
+	}
+	defer out.Close()
+
+	// only the current init segment can still be on local disk
+	localInit := ""
+	if rng.init == fmt.Sprintf("init-%s.mp4", repID) {
+		localInit = rng.init
+	}
+	if err := r.copySegment(ctx, streamID, streamDir, localInit, rng.init, out); err != nil {
+		return 0, err
+	}
+
+	written := 0
+	for seq := rng.from; seq <= rng.to; seq++ {
+		name := fmt.Sprintf("chunk-%s-%d.m4s", repID, seq)
+
+		// the segments stored before a takeover were never local here
+		localName := ""
+		if seq > localOffset {
+			localName = fmt.Sprintf("chunk-%s-%d.m4s", repID, seq-localOffset)
+		}
+
+		if err := r.copySegment(ctx, streamID, streamDir, localName, name, out); err != nil {
+			if ctx.Err() != nil {
+				return 0, ctx.Err()
+			}
+			slog.Warn("Segment missing from recording", "streamId", streamID, "segment", name, "error", err)
+			continue
+		}
+		written++
+	}
+
+	return written, out.Close()
+}
+
+// copySegment writes the local file localName when there is one, and the
+// stored object name otherwise.
+func (r *Recorder) copySegment(ctx context.Context, streamID, streamDir, localName, name string, w io.Writer) error {
+	if localName != "" {
+		if f, err := os.Open(filepath.Join(streamDir, localName)); err == nil {
+			defer f.Close()
+			_, err = io.Copy(w, f)
+			return err
+		}
+	}
+
+	exists, err := r.storage.ObjectExists(ctx, remotePath(streamID, name))
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("%s not found", name)
+	}
+	return r.storage.DownloadTo(ctx, remotePath(streamID, name), w)
+}
+
+// join concatenates parts that start with different init segments into
+// one MP4.
+func join(ctx context.Context, parts []string, dst string) error {
+	var list strings.Builder
+	for _, part := range parts {
+		fmt.Fprintf(&list, "file '%s'\n", part)
+	}
+	listPath := strings.TrimSuffix(dst, ".mp4") + ".txt"
+	if err := os.WriteFile(listPath, []byte(list.String()), 0644); err != nil {
+		return err
+	}
+
+	args := []string{"-hide_banner", "-loglevel", "error", "-y", "-f", "concat", "-safe", "0", "-i", listPath, "-c", "copy", dst}
+	cmd := exec.CommandContext(ctx, "ffmpeg", args...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to join recording parts: %w: %s", err, stderr.String())
+	}
+	return nil
+}
+
+// remux copies the tracks into a progressive MP4 with the moov box first, so
+// players can start before the whole file is downloaded.
+func remux(ctx context.Context, videoPath, audioPath, outPath string) error {
+	args := []string{"-hide_banner", "-loglevel", "error", "-y", "-i", videoPath}
+	if audioPath != "" {
+		args = append(args, "-i", audioPath)
+	}
+	args = append(args, "-map", "0:v:0")
+	if audioPath != "" {
+		args = append(args, "-map", "1:a:0")
+	}
+	args = append(args, "-c", "copy", "-movflags", "+faststart", outPath)
+
+	cmd := exec.CommandContext(ctx, "ffmpeg", args...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to remux recording: %w: %s", err, stderr.String())
+	}
+	return nil
+}
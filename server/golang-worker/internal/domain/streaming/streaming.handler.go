@@ -3,20 +3,30 @@ package streaming
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"log/slog"
 
 	"github.com/IBM/sarama"
+	"github.com/bitstream/backend-go/internal/domain/streaming/manager"
 	"github.com/bitstream/backend-go/internal/domain/streaming/model"
+	"github.com/bitstream/backend-go/internal/kafka/consumer"
 )
 
 func StreamHandler(ctx context.Context, msg *sarama.ConsumerMessage) error {
 	var payload model.StreamPayload
 
 	if err := json.Unmarshal(msg.Value, &payload); err != nil {
-		return err
+		return consumer.Permanent(err)
 	}
 
-	streamManager.Dispatch(payload)
+	// the offset is committed once this returns, so wait until the
+	// manager has taken the command over
+	if err := streamManager.Dispatch(ctx, payload); err != nil {
+		if errors.Is(err, manager.ErrShuttingDown) {
+			return consumer.Deferred(err)
+		}
+		return err
+	}
 
 	slog.Info(
 		"Stream "+string(payload.Action),
@@ -1,29 +1,72 @@
 package streaming
 
 import (
+	"context"
+	"time"
+
 	stream "github.com/bitstream/backend-go/internal/db/generated"
 	"github.com/bitstream/backend-go/internal/deps"
+	"github.com/bitstream/backend-go/internal/domain/streaming/lifecycle"
+	"github.com/bitstream/backend-go/internal/domain/streaming/llhls"
 	"github.com/bitstream/backend-go/internal/domain/streaming/manager"
 	"github.com/bitstream/backend-go/internal/kafka/consumer"
+	"github.com/bitstream/backend-go/internal/kafka/retry"
 	"github.com/bitstream/backend-go/internal/kafka/topics"
 )
 
-var streamManager *manager.StreamManager
+// retryTiers delay the first retry of a stream by 5s and every later one by 30s.
+var retryTiers = []retry.Tier{
+	{Topic: topics.STREAM_ON_PUBLISH_RETRY_5S, Delay: 5 * time.Second},
+	{Topic: topics.STREAM_ON_PUBLISH_RETRY_30S, Delay: 30 * time.Second},
+}
+
+var (
+	streamManager *manager.StreamManager
+	llhlsServer   *llhls.Server
+	publisher     *lifecycle.Publisher
+)
 
 func Register(d *deps.Deps) {
 	queries := stream.New(d.DB)
-	streamManager = manager.NewStreamManager(d.Config, queries, d.Storage)
+
+	publisher = lifecycle.NewPublisher(d.DB, d.Producer)
+	publisher.Start()
+
+	retries := retry.NewScheduler(d.Producer, retryTiers)
+	streamManager = manager.NewStreamManager(d.Config, queries, d.Storage, publisher, retries, d.Producer)
 	streamManager.Start(5)
 
+	if d.Config.Server.Port > 0 {
+		llhlsServer = llhls.NewServer(d.Config.Server.Port)
+		llhlsServer.Start()
+	}
+
 	consumer.Register(consumer.Registration{
-		Topics:        []string{topics.STREAM_ON_PUBLISH},
-		ConsumerCount: 10,
-		Handler:       StreamHandler,
+		Topics:   []string{topics.STREAM_ON_PUBLISH},
+		Handler:  StreamHandler,
+		DLQTopic: topics.STREAM_ON_PUBLISH_DLQ,
+	})
+
+	consumer.Register(consumer.Registration{
+		Topics:   []string{topics.STREAM_ON_PUBLISH_RETRY_5S, topics.STREAM_ON_PUBLISH_RETRY_30S},
+		Handler:  retry.Delayed(StreamHandler),
+		DLQTopic: topics.STREAM_ON_PUBLISH_DLQ,
 	})
 }
 
-func Shutdown() {
+func Shutdown(ctx context.Context) {
 	if streamManager != nil {
-		streamManager.Shutdown()
+		streamManager.Shutdown(ctx)
+	}
+
+	// after the manager, so the events of finalizing streams still go out
+	if publisher != nil {
+		publisher.Close()
+	}
+
+	if llhlsServer != nil {
+		ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+		_ = llhlsServer.Shutdown(ctx)
 	}
 }
@@ -2,12 +2,74 @@ package consumer
 
 import (
 	"context"
+	"errors"
+	"sync"
+	"time"
 
 	"github.com/IBM/sarama"
+	"github.com/bitstream/backend-go/internal/kafka/producer"
 )
 
 type MessageHandler func(ctx context.Context, msg *sarama.ConsumerMessage) error
 
 type KafkaConsumer struct {
-	handler MessageHandler
+	handler      MessageHandler
+	dlq          *producer.Producer
+	dlqTopic     string
+	maxAttempts  int
+	retryBackoff time.Duration
+
+	mu       sync.Mutex
+	stopping bool
+	stopped  chan struct{}
+	inflight sync.WaitGroup
+}
+
+// permanentError marks a failure that handling the message again cannot fix.
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent wraps err so the message is dead-lettered without being retried,
+// as for a payload that does not decode.
+func Permanent(err error) error {
+	return &permanentError{err: err}
+}
+
+func IsPermanent(err error) bool {
+	var p *permanentError
+	return errors.As(err, &p)
+}
+
+// deferredError marks a message that cannot be handled right now, through
+// no fault of its own.
+type deferredError struct {
+	err error
+}
+
+func (e *deferredError) Error() string { return e.err.Error() }
+func (e *deferredError) Unwrap() error { return e.err }
+
+// Deferred wraps err so the message is left uncommitted, neither retried nor
+// dead-lettered, and read again by the next owner of the partition.
+func Deferred(err error) error {
+	return &deferredError{err: err}
+}
+
+func IsDeferred(err error) bool {
+	var d *deferredError
+	return errors.As(err, &d)
+}
+
+type stoppingKey struct{}
+
+// Stopping returns a channel that is closed once the consumer handling the
+// message of ctx begins to stop. Handlers are not cancelled then, so one
+// that would only wait should return a Deferred error on it instead.
+func Stopping(ctx context.Context) <-chan struct{} {
+	stopped, _ := ctx.Value(stoppingKey{}).(chan struct{})
+	return stopped
 }
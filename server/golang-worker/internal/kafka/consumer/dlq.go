@@ -0,0 +1,58 @@
+package consumer
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/IBM/sarama"
+)
+
+// Headers added to a dead-lettered message. The original headers, key and
+// value are kept, so the message can be replayed as it was.
+const (
+	HeaderError             = "x-dlq-error"
+	HeaderAttempts          = "x-dlq-attempts"
+	HeaderOriginalTopic     = "x-dlq-original-topic"
+	HeaderOriginalPartition = "x-dlq-original-partition"
+	HeaderOriginalOffset    = "x-dlq-original-offset"
+	HeaderFailedAt          = "x-dlq-failed-at"
+)
+
+func (c *KafkaConsumer) deadLetter(msg *sarama.ConsumerMessage, attempts int, cause error) error {
+	headers := make([]sarama.RecordHeader, 0, len(msg.Headers)+6)
+	for _, h := range msg.Headers {
+		if h != nil {
+			headers = append(headers, *h)
+		}
+	}
+
+	headers = append(headers,
+		header(HeaderError, cause.Error()),
+		header(HeaderAttempts, strconv.Itoa(attempts)),
+		header(HeaderOriginalTopic, msg.Topic),
+		header(HeaderOriginalPartition, strconv.Itoa(int(msg.Partition))),
+		header(HeaderOriginalOffset, strconv.FormatInt(msg.Offset, 10)),
+		header(HeaderFailedAt, time.Now().UTC().Format(time.RFC3339)),
+	)
+
+	return c.dlq.PublishMessage(&sarama.ProducerMessage{
+		Topic:   c.dlqTopic,
+		Key:     sarama.ByteEncoder(msg.Key),
+		Value:   sarama.ByteEncoder(msg.Value),
+		Headers: headers,
+	})
+}
+
+func header(key, value string) sarama.RecordHeader {
+	return sarama.RecordHeader{Key: []byte(key), Value: []byte(value)}
+}
+
+// HeaderValue returns the value of the last header named key.
+func HeaderValue(headers []*sarama.RecordHeader, key string) (string, bool) {
+	for i := len(headers) - 1; i >= 0; i-- {
+		if headers[i] != nil && string(headers[i].Key) == key {
+			return string(headers[i].Value), true
+		}
+	}
+	return "", false
+}
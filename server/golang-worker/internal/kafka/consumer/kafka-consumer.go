@@ -3,17 +3,75 @@ package consumer
 import (
 	"context"
 	"log/slog"
+	"time"
 
 	"github.com/IBM/sarama"
+	"github.com/bitstream/backend-go/internal/kafka/producer"
 )
 
-func NewConsumer(handler MessageHandler) *KafkaConsumer {
+const (
+	defaultMaxAttempts  = 3
+	defaultRetryBackoff = time.Second
+)
+
+func NewConsumer(
+	reg Registration,
+	dlq *producer.Producer,
+	maxAttempts int,
+	retryBackoff time.Duration,
+) *KafkaConsumer {
+	if maxAttempts <= 0 {
+		maxAttempts = defaultMaxAttempts
+	}
+	if retryBackoff <= 0 {
+		retryBackoff = defaultRetryBackoff
+	}
+
 	return &KafkaConsumer{
-		handler: handler,
+		handler:      reg.Handler,
+		dlq:          dlq,
+		dlqTopic:     reg.DLQTopic,
+		maxAttempts:  maxAttempts,
+		retryBackoff: retryBackoff,
+		stopped:      make(chan struct{}),
 	}
 }
 
+// Stop makes every claim stop taking messages and waits until the messages
+// already being handled are done and committed. The session stays open
+// meanwhile, so those handlers are not cancelled; the ones only waiting are
+// told through Stopping.
+func (c *KafkaConsumer) Stop() {
+	c.mu.Lock()
+	if !c.stopping {
+		c.stopping = true
+		close(c.stopped)
+	}
+	c.mu.Unlock()
+
+	c.inflight.Wait()
+}
+
+// begin registers a message as in flight, unless the consumer is stopping.
+func (c *KafkaConsumer) begin() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.stopping {
+		return false
+	}
+	c.inflight.Add(1)
+	return true
+}
+
+func (c *KafkaConsumer) isStopping() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.stopping
+}
+
 func (c *KafkaConsumer) Setup(session sarama.ConsumerGroupSession) error {
+	slog.Info("Partitions assigned", "generation", session.GenerationID(), "claims", session.Claims())
 	return nil
 }
 
@@ -26,16 +84,107 @@ func (c *KafkaConsumer) ConsumeClaim(
 	claim sarama.ConsumerGroupClaim,
 ) error {
 
-	for msg := range claim.Messages() {
-		if err := c.handler(context.Background(), msg); err != nil {
-			slog.Error("handle message failed", "error", err.Error())
+	for {
+		var msg *sarama.ConsumerMessage
+		select {
+		case <-c.stopped:
+			// returning ends the session for every claim, so wait for the
+			// others to finish their message first
+			c.inflight.Wait()
+			return nil
+		case m, ok := <-claim.Messages():
+			if !ok {
+				return nil
+			}
+			msg = m
+		}
+
+		if !c.begin() {
+			continue
+		}
+		err := c.process(session, msg)
+		c.inflight.Done()
+		if err != nil {
+			if session.Context().Err() != nil || c.isStopping() {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+// process handles one message and commits its offset, after moving it to
+// the dead letter topic if the handler keeps failing. An error ends the
+// claim without committing, so the message is read again.
+func (c *KafkaConsumer) process(session sarama.ConsumerGroupSession, msg *sarama.ConsumerMessage) error {
+	ctx := context.WithValue(session.Context(), stoppingKey{}, c.stopped)
+	attempts, err := c.handle(ctx, msg)
+	if err != nil {
+		if session.Context().Err() != nil {
+			// rebalance or shutdown: the next owner handles it again
+			return session.Context().Err()
+		}
+		if IsDeferred(err) {
+			slog.Warn("Message deferred", "topic", msg.Topic, "partition", msg.Partition, "offset", msg.Offset, "reason", err.Error())
+			return err
+		}
+
+		slog.Error("handle message failed",
+			"topic", msg.Topic,
+			"partition", msg.Partition,
+			"offset", msg.Offset,
+			"attempts", attempts,
+			"error", err.Error(),
+		)
+
+		if c.dlq == nil || c.dlqTopic == "" {
 			return err
 		}
 
-		session.MarkMessage(msg, "")
-		slog.Info("Message marked", "topic", msg.Topic, "partition", msg.Partition, "offset", msg.Offset)
-		session.Commit()
+		if dlqErr := c.deadLetter(msg, attempts, err); dlqErr != nil {
+			slog.Error("Failed to dead-letter message", "topic", msg.Topic, "offset", msg.Offset, "error", dlqErr)
+			return err
+		}
+		slog.Warn("Message moved to DLQ",
+			"topic", msg.Topic,
+			"partition", msg.Partition,
+			"offset", msg.Offset,
+			"dlq", c.dlqTopic,
+		)
 	}
 
+	session.MarkMessage(msg, "")
+	slog.Info("Message marked", "topic", msg.Topic, "partition", msg.Partition, "offset", msg.Offset)
+	session.Commit()
 	return nil
 }
+
+// handle runs the handler until it succeeds, fails permanently or runs out
+// of attempts, and returns how many attempts were made.
+func (c *KafkaConsumer) handle(ctx context.Context, msg *sarama.ConsumerMessage) (int, error) {
+	var err error
+	for attempt := 1; attempt <= c.maxAttempts; attempt++ {
+		if err = c.handler(ctx, msg); err == nil {
+			return attempt, nil
+		}
+		if IsPermanent(err) || IsDeferred(err) || attempt == c.maxAttempts {
+			return attempt, err
+		}
+
+		slog.Warn("handle message failed, retrying",
+			"topic", msg.Topic,
+			"offset", msg.Offset,
+			"attempt", attempt,
+			"error", err.Error(),
+		)
+
+		select {
+		case <-time.After(c.retryBackoff * time.Duration(attempt)):
+		case <-ctx.Done():
+			return attempt, err
+		case <-Stopping(ctx):
+			return attempt, Deferred(err)
+		}
+	}
+	return c.maxAttempts, err
+}
@@ -1,9 +1,15 @@
 package consumer
 
+// Registration subscribes a handler to topics. Each registration gets one
+// group member; every partition it is assigned is consumed by its own
+// goroutine, in offset order, so messages with the same key stay ordered.
 type Registration struct {
-	Topics        []string
-	Handler       MessageHandler
-	ConsumerCount int
+	Topics  []string
+	Handler MessageHandler
+
+	// DLQTopic receives the messages the handler keeps failing on. Without
+	// one, a failing message stops the claim and is read again.
+	DLQTopic string
 }
 
 var registry []Registration
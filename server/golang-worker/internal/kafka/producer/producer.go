@@ -42,6 +42,13 @@ func (p *Producer) Publish(topic, key string, value []byte, partition ...int32)
 	return err
 }
 
+// PublishMessage sends a message built by the caller, for when headers or
+// the original key have to be kept.
+func (p *Producer) PublishMessage(msg *sarama.ProducerMessage) error {
+	_, _, err := p.producer.SendMessage(msg)
+	return err
+}
+
 func (p *Producer) Close() error {
 	return p.producer.Close()
 }
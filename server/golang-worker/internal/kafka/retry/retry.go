@@ -0,0 +1,104 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/bitstream/backend-go/internal/kafka/consumer"
+	"github.com/bitstream/backend-go/internal/kafka/producer"
+)
+
+// HeaderNotBefore holds the unix time in milliseconds before which a
+// delayed message must not be handled.
+const HeaderNotBefore = "x-not-before"
+
+var errStopping = errors.New("consumer stopping before the retry is due")
+
+// Tier is a topic whose messages are all delayed by the same amount, so they
+// become due in the order they were written.
+type Tier struct {
+	Topic string
+	Delay time.Duration
+}
+
+// Scheduler publishes retries to tiered delay topics, so a pending retry
+// survives a restart and no worker waits for it.
+type Scheduler struct {
+	producer *producer.Producer
+	tiers    []Tier
+}
+
+// NewScheduler takes the tiers ordered by delay. Attempt n goes to the n-th
+// tier, and every later attempt to the last one.
+func NewScheduler(p *producer.Producer, tiers []Tier) *Scheduler {
+	return &Scheduler{
+		producer: p,
+		tiers:    tiers,
+	}
+}
+
+func (s *Scheduler) TierFor(attempt int) Tier {
+	return s.tiers[min(max(attempt, 1), len(s.tiers))-1]
+}
+
+// Schedule publishes value to the tier of attempt, keyed so the retries of
+// one entity stay in order.
+func (s *Scheduler) Schedule(attempt int, key string, value []byte) (Tier, error) {
+	tier := s.TierFor(attempt)
+	notBefore := time.Now().Add(tier.Delay).UnixMilli()
+
+	err := s.producer.PublishMessage(&sarama.ProducerMessage{
+		Topic: tier.Topic,
+		Key:   sarama.StringEncoder(key),
+		Value: sarama.ByteEncoder(value),
+		Headers: []sarama.RecordHeader{{
+			Key:   []byte(HeaderNotBefore),
+			Value: []byte(strconv.FormatInt(notBefore, 10)),
+		}},
+	})
+	return tier, err
+}
+
+// Delayed waits until a message is due before passing it to next. Messages
+// of a tier are due in partition order, so waiting on the head holds back
+// nothing that is due earlier. A rebalance, or the consumer stopping, ends
+// the wait and leaves the message uncommitted to be read again.
+func Delayed(next consumer.MessageHandler) consumer.MessageHandler {
+	return func(ctx context.Context, msg *sarama.ConsumerMessage) error {
+		if wait := untilDue(msg); wait > 0 {
+			slog.Debug("Delaying retry", "topic", msg.Topic, "offset", msg.Offset, "wait", wait)
+
+			timer := time.NewTimer(wait)
+			defer timer.Stop()
+
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-consumer.Stopping(ctx):
+				return consumer.Deferred(errStopping)
+			}
+		}
+
+		return next(ctx, msg)
+	}
+}
+
+func untilDue(msg *sarama.ConsumerMessage) time.Duration {
+	value, ok := consumer.HeaderValue(msg.Headers, HeaderNotBefore)
+	if !ok {
+		return 0
+	}
+
+	notBefore, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		slog.Warn("Invalid not-before header, handling now", "topic", msg.Topic, "offset", msg.Offset, "value", value)
+		return 0
+	}
+
+	return time.Until(time.UnixMilli(notBefore))
+}
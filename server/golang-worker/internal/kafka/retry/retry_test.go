@@ -0,0 +1,193 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/bitstream/backend-go/internal/kafka/consumer"
+)
+
+func TestTierFor(t *testing.T) {
+	tiers := []Tier{
+		{Topic: "retry-5s", Delay: 5 * time.Second},
+		{Topic: "retry-1m", Delay: time.Minute},
+		{Topic: "retry-10m", Delay: 10 * time.Minute},
+	}
+	s := NewScheduler(nil, tiers)
+
+	tests := []struct {
+		attempt int
+		want    string
+	}{
+		{attempt: -1, want: "retry-5s"},
+		{attempt: 0, want: "retry-5s"},
+		{attempt: 1, want: "retry-5s"},
+		{attempt: 2, want: "retry-1m"},
+		{attempt: 3, want: "retry-10m"},
+		{attempt: 4, want: "retry-10m"},
+		{attempt: 100, want: "retry-10m"},
+	}
+
+	for _, tt := range tests {
+		if got := s.TierFor(tt.attempt); got.Topic != tt.want {
+			t.Errorf("TierFor(%d) = %q, want %q", tt.attempt, got.Topic, tt.want)
+		}
+	}
+}
+
+func notBefore(at time.Time) []*sarama.RecordHeader {
+	return []*sarama.RecordHeader{{
+		Key:   []byte(HeaderNotBefore),
+		Value: []byte(strconv.FormatInt(at.UnixMilli(), 10)),
+	}}
+}
+
+func TestUntilDue(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers []*sarama.RecordHeader
+		min     time.Duration
+		max     time.Duration
+	}{
+		{name: "no header", headers: nil},
+		{name: "invalid header", headers: []*sarama.RecordHeader{{Key: []byte(HeaderNotBefore), Value: []byte("soon")}}},
+		{name: "due", headers: notBefore(time.Now().Add(-time.Minute)), min: -2 * time.Minute, max: 0},
+		{name: "not due", headers: notBefore(time.Now().Add(time.Minute)), min: 59 * time.Second, max: time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := untilDue(&sarama.ConsumerMessage{Headers: tt.headers})
+			if got < tt.min || got > tt.max {
+				t.Errorf("untilDue() = %v, want between %v and %v", got, tt.min, tt.max)
+			}
+		})
+	}
+}
+
+func TestDelayed(t *testing.T) {
+	handled := make(chan struct{}, 1)
+	handler := Delayed(func(ctx context.Context, msg *sarama.ConsumerMessage) error {
+		handled <- struct{}{}
+		return nil
+	})
+
+	t.Run("waits until due", func(t *testing.T) {
+		start := time.Now()
+		msg := &sarama.ConsumerMessage{Headers: notBefore(start.Add(50 * time.Millisecond))}
+		if err := handler(context.Background(), msg); err != nil {
+			t.Fatalf("handler() = %v", err)
+		}
+		<-handled
+		if elapsed := time.Since(start); elapsed < 40*time.Millisecond {
+			t.Errorf("handled after %v, before the message was due", elapsed)
+		}
+	})
+
+	t.Run("stops on cancel", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		msg := &sarama.ConsumerMessage{Headers: notBefore(time.Now().Add(time.Hour))}
+		if err := handler(ctx, msg); !errors.Is(err, context.Canceled) {
+			t.Errorf("handler() = %v, want %v", err, context.Canceled)
+		}
+		select {
+		case <-handled:
+			t.Error("message was handled before it was due")
+		default:
+		}
+	})
+}
+
+// fakeSession records what is marked and committed. Other methods are not
+// used by the consumer.
+type fakeSession struct {
+	sarama.ConsumerGroupSession
+	ctx context.Context
+
+	mu        sync.Mutex
+	marked    []int64
+	committed bool
+}
+
+func (s *fakeSession) Context() context.Context { return s.ctx }
+
+func (s *fakeSession) MarkMessage(msg *sarama.ConsumerMessage, metadata string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.marked = append(s.marked, msg.Offset)
+}
+
+func (s *fakeSession) Commit() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.committed = true
+}
+
+type fakeClaim struct {
+	sarama.ConsumerGroupClaim
+	messages chan *sarama.ConsumerMessage
+}
+
+func (c *fakeClaim) Messages() <-chan *sarama.ConsumerMessage { return c.messages }
+
+func TestStopEndsDelay(t *testing.T) {
+	handled := make(chan struct{}, 1)
+	c := consumer.NewConsumer(consumer.Registration{
+		Handler: Delayed(func(ctx context.Context, msg *sarama.ConsumerMessage) error {
+			handled <- struct{}{}
+			return nil
+		}),
+	}, nil, 3, time.Millisecond)
+
+	session := &fakeSession{ctx: context.Background()}
+	claim := &fakeClaim{messages: make(chan *sarama.ConsumerMessage)}
+
+	claimDone := make(chan error, 1)
+	go func() { claimDone <- c.ConsumeClaim(session, claim) }()
+
+	// the claim takes the message and starts waiting for it to be due
+	claim.messages <- &sarama.ConsumerMessage{
+		Topic:   "retry-30s",
+		Offset:  7,
+		Headers: notBefore(time.Now().Add(30 * time.Second)),
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		c.Stop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("Stop waited for the delayed message to be due")
+	}
+	select {
+	case err := <-claimDone:
+		if err != nil {
+			t.Errorf("ConsumeClaim() = %v, want nil", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("ConsumeClaim did not return once stopped")
+	}
+
+	select {
+	case <-handled:
+		t.Error("delayed message was handled before it was due")
+	default:
+	}
+
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	if len(session.marked) != 0 || session.committed {
+		t.Errorf("delayed message was committed: marked %v, committed %v", session.marked, session.committed)
+	}
+}
@@ -6,43 +6,86 @@ import (
 	"time"
 
 	"github.com/IBM/sarama"
+	"github.com/bitstream/backend-go/internal/config"
 	"github.com/bitstream/backend-go/internal/kafka/consumer"
+	"github.com/bitstream/backend-go/internal/kafka/producer"
 )
 
+// ConsumerRunner keeps one group member consuming a registration's topics.
 type ConsumerRunner struct {
 	brokers   []string
 	groupID   string
 	saramaCfg *sarama.Config
+	reg       consumer.Registration
+	handler   *consumer.KafkaConsumer
+
+	cancel context.CancelFunc
+	done   chan struct{}
 }
 
 func NewConsumerRunner(
 	brokers []string,
-	groupID string,
+	cfg config.ConsumerConfig,
 	saramaCfg *sarama.Config,
+	dlq *producer.Producer,
+	reg consumer.Registration,
 ) *ConsumerRunner {
 	return &ConsumerRunner{
 		brokers:   brokers,
-		groupID:   groupID,
+		groupID:   cfg.GroupID,
 		saramaCfg: saramaCfg,
+		reg:       reg,
+		handler:   consumer.NewConsumer(reg, dlq, cfg.MaxAttempts, cfg.RetryBackoff),
+		done:      make(chan struct{}),
 	}
 }
 
-func (r *ConsumerRunner) Run(
-	ctx context.Context,
-	reg consumer.Registration,
-	index int,
-) {
+// Start consumes until ctx ends or Stop is called, reconnecting with
+// backoff.
+func (r *ConsumerRunner) Start(ctx context.Context) {
+	ctx, r.cancel = context.WithCancel(ctx)
+	go r.run(ctx)
+}
+
+// Stop stops taking messages, lets the ones being handled finish and commit,
+// then leaves the group. It gives up waiting when ctx ends.
+func (r *ConsumerRunner) Stop(ctx context.Context) error {
+	drained := make(chan struct{})
+	go func() {
+		r.handler.Stop()
+		close(drained)
+	}()
+
+	select {
+	case <-drained:
+	case <-ctx.Done():
+	}
+	r.cancel()
+
+	select {
+	case <-r.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (r *ConsumerRunner) run(ctx context.Context) {
+	defer close(r.done)
+
 	backoff := time.Second
 
 	for ctx.Err() == nil {
-		if err := r.runOnce(ctx, reg, index); err != nil {
+		if err := r.runOnce(ctx); err != nil {
 			slog.Error("consumer crashed",
-				"index", index,
-				"topics", reg.Topics,
+				"topics", r.reg.Topics,
 				"err", err,
 			)
 
-			time.Sleep(backoff)
+			select {
+			case <-time.After(backoff):
+			case <-ctx.Done():
+			}
 			if backoff < 30*time.Second {
 				backoff *= 2
 			}
@@ -50,11 +93,7 @@ func (r *ConsumerRunner) Run(
 	}
 }
 
-func (r *ConsumerRunner) runOnce(
-	ctx context.Context,
-	reg consumer.Registration,
-	index int,
-) error {
+func (r *ConsumerRunner) runOnce(ctx context.Context) error {
 	group, err := sarama.NewConsumerGroup(
 		r.brokers,
 		r.groupID,
@@ -63,25 +102,23 @@ func (r *ConsumerRunner) runOnce(
 	if err != nil {
 		return err
 	}
+	// closing commits the marked offsets and leaves the group
 	defer group.Close()
 
-	handler := consumer.NewConsumer(reg.Handler)
-
 	slog.Info("consumer started",
 		"group", r.groupID,
-		"index", index,
-		"topics", reg.Topics,
+		"topics", r.reg.Topics,
 	)
 
 	for ctx.Err() == nil {
-		if err := group.Consume(ctx, reg.Topics, handler); err != nil {
+		if err := group.Consume(ctx, r.reg.Topics, r.handler); err != nil {
 			return err
 		}
 	}
 
 	slog.Info("consumer stopped",
 		"group", r.groupID,
-		"index", index,
+		"topics", r.reg.Topics,
 	)
 	return nil
 }
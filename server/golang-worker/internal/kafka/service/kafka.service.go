@@ -8,18 +8,22 @@ import (
 	"github.com/bitstream/backend-go/internal/config"
 	"github.com/bitstream/backend-go/internal/kafka/admin"
 	"github.com/bitstream/backend-go/internal/kafka/consumer"
+	"github.com/bitstream/backend-go/internal/kafka/producer"
 	"github.com/bitstream/backend-go/internal/kafka/runtime"
 )
 
 type KafkaService struct {
 	cfg       config.KafkaConfig
 	saramaCfg *sarama.Config
+	producer  *producer.Producer
+	runners   []*runtime.ConsumerRunner
 }
 
-func NewKafkaService(cfg config.KafkaConfig, saramaCfg *sarama.Config) *KafkaService {
+func NewKafkaService(cfg config.KafkaConfig, saramaCfg *sarama.Config, producer *producer.Producer) *KafkaService {
 	return &KafkaService{
 		cfg:       cfg,
 		saramaCfg: saramaCfg,
+		producer:  producer,
 	}
 }
 
@@ -52,11 +56,33 @@ func (k *KafkaService) startRegistration(
 ) {
 	runner := runtime.NewConsumerRunner(
 		k.cfg.Brokers,
-		k.cfg.Consumer.GroupID,
+		k.cfg.Consumer,
 		k.saramaCfg,
+		k.producer,
+		reg,
 	)
+	runner.Start(ctx)
 
-	for i := 0; i < reg.ConsumerCount; i++ {
-		go runner.Run(ctx, reg, i)
+	k.runners = append(k.runners, runner)
+}
+
+// Shutdown stops every consumer: no new messages are taken, those being
+// handled finish and are committed, then the members leave their groups.
+func (k *KafkaService) Shutdown(ctx context.Context) error {
+	errs := make(chan error, len(k.runners))
+	for _, runner := range k.runners {
+		go func() {
+			errs <- runner.Stop(ctx)
+		}()
 	}
+
+	var err error
+	for range k.runners {
+		if e := <-errs; e != nil {
+			err = e
+		}
+	}
+
+	slog.Info("kafka service stopped", "error", err)
+	return err
 }
@@ -5,6 +5,10 @@ import "github.com/IBM/sarama"
 const (
 	STREAM_ON_PUBLISH     = "stream.on_publish"
 	STREAM_ON_PUBLISH_DLQ = "stream.on_publish.dlq"
+	STREAM_LIFECYCLE      = "stream.lifecycle"
+
+	STREAM_ON_PUBLISH_RETRY_5S  = "stream.on_publish.retry.5s"
+	STREAM_ON_PUBLISH_RETRY_30S = "stream.on_publish.retry.30s"
 )
 
 var TopicDefinitions = map[string]*sarama.TopicDetail{
@@ -16,4 +20,16 @@ var TopicDefinitions = map[string]*sarama.TopicDetail{
 		NumPartitions:     3,
 		ReplicationFactor: 1,
 	},
+	STREAM_ON_PUBLISH_RETRY_5S: {
+		NumPartitions:     3,
+		ReplicationFactor: 1,
+	},
+	STREAM_ON_PUBLISH_RETRY_30S: {
+		NumPartitions:     3,
+		ReplicationFactor: 1,
+	},
+	STREAM_LIFECYCLE: {
+		NumPartitions:     10,
+		ReplicationFactor: 1,
+	},
 }
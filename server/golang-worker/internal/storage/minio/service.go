@@ -3,6 +3,7 @@ package minio
 import (
 	"context"
 	"fmt"
+	"io"
 
 	"github.com/bitstream/backend-go/internal/config"
 	"github.com/minio/minio-go/v7"
@@ -60,9 +61,10 @@ func (s *Service) EnsureBucket(ctx context.Context) error {
 	return nil
 }
 
-func (s *Service) UploadFile(ctx context.Context, localPath, remotePath, contentType string) error {
+func (s *Service) UploadFile(ctx context.Context, localPath, remotePath, contentType, cacheControl string) error {
 	_, err := s.client.FPutObject(ctx, s.bucketName, remotePath, localPath, minio.PutObjectOptions{
-		ContentType: contentType,
+		ContentType:  contentType,
+		CacheControl: cacheControl,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to upload file %s: %w", localPath, err)
@@ -77,3 +79,50 @@ func (s *Service) DeleteObject(ctx context.Context, remotePath string) error {
 	}
 	return nil
 }
+
+func (s *Service) CopyObject(ctx context.Context, srcPath, dstPath string) error {
+	_, err := s.client.CopyObject(ctx,
+		minio.CopyDestOptions{Bucket: s.bucketName, Object: dstPath},
+		minio.CopySrcOptions{Bucket: s.bucketName, Object: srcPath},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to copy object %s to %s: %w", srcPath, dstPath, err)
+	}
+	return nil
+}
+
+// ListObjects returns the names of the objects under prefix.
+func (s *Service) ListObjects(ctx context.Context, prefix string) ([]string, error) {
+	var names []string
+	for obj := range s.client.ListObjects(ctx, s.bucketName, minio.ListObjectsOptions{Prefix: prefix, Recursive: true}) {
+		if obj.Err != nil {
+			return nil, fmt.Errorf("failed to list objects %s: %w", prefix, obj.Err)
+		}
+		names = append(names, obj.Key)
+	}
+	return names, nil
+}
+
+func (s *Service) DownloadTo(ctx context.Context, remotePath string, w io.Writer) error {
+	obj, err := s.client.GetObject(ctx, s.bucketName, remotePath, minio.GetObjectOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to get object %s: %w", remotePath, err)
+	}
+	defer obj.Close()
+
+	if _, err := io.Copy(w, obj); err != nil {
+		return fmt.Errorf("failed to download object %s: %w", remotePath, err)
+	}
+	return nil
+}
+
+func (s *Service) ObjectExists(ctx context.Context, remotePath string) (bool, error) {
+	_, err := s.client.StatObject(ctx, s.bucketName, remotePath, minio.StatObjectOptions{})
+	if err == nil {
+		return true, nil
+	}
+	if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+		return false, nil
+	}
+	return false, fmt.Errorf("failed to stat object %s: %w", remotePath, err)
+}
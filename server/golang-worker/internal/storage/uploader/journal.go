@@ -0,0 +1,189 @@
+package uploader
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+const JournalName = "upload-journal.json"
+
+// Journal is the on-disk list of uploads of one stream that have not been
+// confirmed by storage yet. It lives in the stream directory next to the
+// files it refers to, so it survives worker restarts with them.
+type Journal struct {
+	path string
+
+	mu      sync.Mutex
+	entries map[string]Job
+}
+
+// NewJournal returns an empty journal for dir, ignoring any file already
+// there. It is rewritten on the first change.
+func NewJournal(dir string) *Journal {
+	return &Journal{
+		path:    filepath.Join(dir, JournalName),
+		entries: make(map[string]Job),
+	}
+}
+
+// OpenJournal loads the journal left in dir by a previous run, if any.
+func OpenJournal(dir string) (*Journal, error) {
+	j := NewJournal(dir)
+
+	data, err := os.ReadFile(j.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return j, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read upload journal: %w", err)
+	}
+
+	var jobs []Job
+	if err := json.Unmarshal(data, &jobs); err != nil {
+		return nil, fmt.Errorf("failed to parse upload journal %s: %w", j.path, err)
+	}
+	for _, job := range jobs {
+		j.entries[job.RemotePath] = job
+	}
+
+	return j, nil
+}
+
+// HasPendingJournal reports whether dir holds uploads that never completed.
+func HasPendingJournal(dir string) bool {
+	j, err := OpenJournal(dir)
+	if err != nil {
+		// an unreadable journal may still list unuploaded files
+		return true
+	}
+	return j.Len() > 0
+}
+
+func (j *Journal) Add(job Job) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if existing, ok := j.entries[job.RemotePath]; ok && existing == job {
+		return nil
+	}
+	j.entries[job.RemotePath] = job
+	return j.persistLocked()
+}
+
+func (j *Journal) Remove(remotePath string) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if _, ok := j.entries[remotePath]; !ok {
+		return nil
+	}
+	delete(j.entries, remotePath)
+	return j.persistLocked()
+}
+
+func (j *Journal) Contains(localPath string) bool {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	for _, job := range j.entries {
+		if job.LocalPath == localPath {
+			return true
+		}
+	}
+	return false
+}
+
+func (j *Journal) Pending() []Job {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	jobs := make([]Job, 0, len(j.entries))
+	for _, job := range j.entries {
+		jobs = append(jobs, job)
+	}
+	sort.Slice(jobs, func(a, b int) bool { return jobs[a].RemotePath < jobs[b].RemotePath })
+	return jobs
+}
+
+func (j *Journal) Len() int {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return len(j.entries)
+}
+
+// persistLocked rewrites the journal atomically: write, fsync, rename. An
+// empty journal is removed instead.
+func (j *Journal) persistLocked() error {
+	if len(j.entries) == 0 {
+		if err := os.Remove(j.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		return nil
+	}
+
+	jobs := make([]Job, 0, len(j.entries))
+	for _, job := range j.entries {
+		jobs = append(jobs, job)
+	}
+
+	data, err := json.Marshal(jobs)
+	if err != nil {
+		return err
+	}
+
+	tmp := j.path + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, j.path)
+}
+
+// ReplayJournal re-submits every upload the journal still lists and removes
+// the ones that succeed. Entries whose local file is gone are dropped, there
+// is nothing left to upload. It returns how many entries remain.
+func (p *Pool) ReplayJournal(ctx context.Context, j *Journal) int {
+	queue := p.NewQueue()
+	handle := func(r Result) {
+		if r.Err != nil {
+			slog.Error("Journaled upload failed again", "remotePath", r.Job.RemotePath, "error", r.Err)
+			return
+		}
+		if err := j.Remove(r.Job.RemotePath); err != nil {
+			slog.Error("Failed to update upload journal", "error", err)
+		}
+	}
+
+	for _, job := range j.Pending() {
+		if _, err := os.Stat(job.LocalPath); errors.Is(err, os.ErrNotExist) {
+			slog.Warn("Journaled file no longer exists, dropping", "file", job.LocalPath)
+			_ = j.Remove(job.RemotePath)
+			continue
+		}
+		if err := queue.Enqueue(ctx, job, handle); err != nil {
+			break
+		}
+	}
+	queue.Drain(handle)
+
+	return j.Len()
+}
@@ -0,0 +1,205 @@
+package uploader
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/bitstream/backend-go/internal/config"
+	"github.com/bitstream/backend-go/internal/storage/minio"
+)
+
+// fakeStorage is an S3 endpoint that stores every PUT except those of
+// objects named in reject.
+type fakeStorage struct {
+	mu     sync.Mutex
+	puts   map[string]string
+	reject map[string]bool
+}
+
+func (s *fakeStorage) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if _, ok := r.URL.Query()["location"]; ok {
+		w.Header().Set("Content-Type", "application/xml")
+		io.WriteString(w, `<?xml version="1.0" encoding="UTF-8"?><LocationConstraint xmlns="http://s3.amazonaws.com/doc/2006-03-01/">us-east-1</LocationConstraint>`)
+		return
+	}
+	if r.Method != http.MethodPut {
+		http.NotFound(w, r)
+		return
+	}
+
+	body, _ := io.ReadAll(r.Body)
+	if strings.HasPrefix(r.Header.Get("X-Amz-Content-Sha256"), "STREAMING-") {
+		body = decodeChunked(body)
+	}
+	object := strings.TrimPrefix(r.URL.Path, "/bucket/")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.reject[object] {
+		w.Header().Set("Content-Type", "application/xml")
+		w.WriteHeader(http.StatusForbidden)
+		io.WriteString(w, `<?xml version="1.0" encoding="UTF-8"?><Error><Code>AccessDenied</Code><Message>denied</Message></Error>`)
+		return
+	}
+	s.puts[object] = string(body)
+	w.Header().Set("ETag", `"etag"`)
+}
+
+// decodeChunked strips the signed chunk framing minio uses for uploads over
+// plain http.
+func decodeChunked(body []byte) []byte {
+	var out []byte
+	for {
+		header, rest, ok := bytes.Cut(body, []byte("\r\n"))
+		if !ok {
+			return out
+		}
+		sizeHex, _, _ := bytes.Cut(header, []byte(";"))
+		size, err := strconv.ParseInt(string(sizeHex), 16, 64)
+		if err != nil || size == 0 || int64(len(rest)) < size {
+			return out
+		}
+		out = append(out, rest[:size]...)
+		body = bytes.TrimPrefix(rest[size:], []byte("\r\n"))
+	}
+}
+
+func newTestPool(t *testing.T, storage *fakeStorage) *Pool {
+	t.Helper()
+
+	server := httptest.NewServer(storage)
+	t.Cleanup(server.Close)
+
+	service, err := minio.NewService(config.MinIOConfig{
+		Endpoint:        strings.TrimPrefix(server.URL, "http://"),
+		AccessKeyID:     "access",
+		SecretAccessKey: "secret",
+		BucketName:      "bucket",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pool := NewPool(config.UploadConfig{Workers: 2, MaxAttempts: 1}, service)
+	pool.Start()
+	t.Cleanup(pool.Stop)
+	return pool
+}
+
+func writeFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestJournalPersists(t *testing.T) {
+	dir := t.TempDir()
+
+	j := NewJournal(dir)
+	first := Job{LocalPath: filepath.Join(dir, "chunk-0-1.m4s"), RemotePath: "streams/s/chunk-0-1.m4s"}
+	second := Job{LocalPath: filepath.Join(dir, "chunk-0-2.m4s"), RemotePath: "streams/s/chunk-0-2.m4s"}
+	for _, job := range []Job{second, first} {
+		if err := j.Add(job); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	reopened, err := OpenJournal(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pending := reopened.Pending()
+	if len(pending) != 2 || pending[0] != first || pending[1] != second {
+		t.Errorf("Pending() = %+v, want %+v in remote path order", pending, []Job{first, second})
+	}
+	if !reopened.Contains(first.LocalPath) {
+		t.Errorf("Contains(%q) = false", first.LocalPath)
+	}
+	if !HasPendingJournal(dir) {
+		t.Error("HasPendingJournal() = false with two entries")
+	}
+
+	for _, job := range pending {
+		if err := reopened.Remove(job.RemotePath); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, JournalName)); !os.IsNotExist(err) {
+		t.Errorf("empty journal was not removed: %v", err)
+	}
+	if HasPendingJournal(dir) {
+		t.Error("HasPendingJournal() = true once every entry is removed")
+	}
+}
+
+func TestOpenJournalCorrupt(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, JournalName, "{not json")
+
+	if _, err := OpenJournal(dir); err == nil {
+		t.Error("OpenJournal() succeeded on a corrupt journal")
+	}
+	// it may still list files that were never uploaded
+	if !HasPendingJournal(dir) {
+		t.Error("HasPendingJournal() = false for a corrupt journal")
+	}
+}
+
+func TestReplayJournal(t *testing.T) {
+	storage := &fakeStorage{
+		puts:   make(map[string]string),
+		reject: map[string]bool{"streams/s/chunk-0-3.m4s": true},
+	}
+	pool := newTestPool(t, storage)
+
+	dir := t.TempDir()
+	j := NewJournal(dir)
+	jobs := []Job{
+		{LocalPath: writeFile(t, dir, "init-0.mp4", "init"), RemotePath: "streams/s/init-0.mp4"},
+		{LocalPath: writeFile(t, dir, "chunk-0-1.m4s", "one"), RemotePath: "streams/s/chunk-0-1.m4s"},
+		{LocalPath: filepath.Join(dir, "chunk-0-2.m4s"), RemotePath: "streams/s/chunk-0-2.m4s"},
+		{LocalPath: writeFile(t, dir, "chunk-0-3.m4s", "three"), RemotePath: "streams/s/chunk-0-3.m4s"},
+	}
+	for _, job := range jobs {
+		if err := j.Add(job); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	remaining := pool.ReplayJournal(context.Background(), j)
+
+	// the upload that failed again stays, the missing file is dropped
+	if remaining != 1 {
+		t.Errorf("ReplayJournal() = %d, want 1", remaining)
+	}
+	if pending := j.Pending(); len(pending) != 1 || pending[0] != jobs[3] {
+		t.Errorf("Pending() = %+v, want only %+v", pending, jobs[3])
+	}
+
+	want := map[string]string{
+		"streams/s/init-0.mp4":    "init",
+		"streams/s/chunk-0-1.m4s": "one",
+	}
+	storage.mu.Lock()
+	defer storage.mu.Unlock()
+	if len(storage.puts) != len(want) {
+		t.Errorf("stored %v, want %v", storage.puts, want)
+	}
+	for object, content := range want {
+		if storage.puts[object] != content {
+			t.Errorf("stored %q = %q, want %q", object, storage.puts[object], content)
+		}
+	}
+}
@@ -0,0 +1,296 @@
+package uploader
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"math/rand/v2"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/bitstream/backend-go/internal/config"
+	"github.com/bitstream/backend-go/internal/storage/minio"
+)
+
+const (
+	defaultWorkers           = 16
+	defaultQueueSize         = 256
+	defaultPerStreamInFlight = 4
+	defaultMaxAttempts       = 5
+	defaultRetryBaseDelay    = 500 * time.Millisecond
+	defaultRetryMaxDelay     = 30 * time.Second
+	statsInterval            = 30 * time.Second
+)
+
+// ErrPoolStopped is returned for jobs enqueued once the pool is stopping.
+var ErrPoolStopped = errors.New("upload pool stopped")
+
+type Job struct {
+	LocalPath    string
+	RemotePath   string
+	ContentType  string
+	CacheControl string
+}
+
+type Result struct {
+	Job Job
+	Err error
+}
+
+// Stats are the backpressure counters of the pool. BlockedEnqueues counts
+// enqueue calls that had to wait for a free slot, BlockedTime their total wait.
+type Stats struct {
+	Queued          int64
+	InFlight        int64
+	Completed       int64
+	Failed          int64
+	Retried         int64
+	BlockedEnqueues int64
+	BlockedTime     time.Duration
+}
+
+type poolJob struct {
+	job   Job
+	queue *Queue
+}
+
+// Pool is the process-wide set of upload workers shared by every stream.
+// The job channel is bounded, so memory stays flat however far storage falls
+// behind: producers block instead.
+type Pool struct {
+	storage           *minio.Service
+	workers           int
+	perStreamInFlight int
+	maxAttempts       int
+	retryBaseDelay    time.Duration
+	retryMaxDelay     time.Duration
+
+	jobs   chan poolJob
+	wg     sync.WaitGroup
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	// mu is held for reading while a job is sent, so Stop closes jobs only
+	// once no send is in progress. stopping wakes the sends that wait for
+	// room meanwhile.
+	mu       sync.RWMutex
+	stopped  bool
+	stopping chan struct{}
+
+	queued          atomic.Int64
+	inFlight        atomic.Int64
+	completed       atomic.Int64
+	failed          atomic.Int64
+	retried         atomic.Int64
+	blockedEnqueues atomic.Int64
+	blockedNanos    atomic.Int64
+}
+
+func NewPool(cfg config.UploadConfig, storage *minio.Service) *Pool {
+	workers := cfg.Workers
+	if workers <= 0 {
+		workers = defaultWorkers
+	}
+	queueSize := cfg.QueueSize
+	if queueSize <= 0 {
+		queueSize = defaultQueueSize
+	}
+	perStream := cfg.PerStreamInFlight
+	if perStream <= 0 {
+		perStream = defaultPerStreamInFlight
+	}
+	maxAttempts := cfg.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = defaultMaxAttempts
+	}
+	baseDelay := cfg.RetryBaseDelay
+	if baseDelay <= 0 {
+		baseDelay = defaultRetryBaseDelay
+	}
+	maxDelay := cfg.RetryMaxDelay
+	if maxDelay < baseDelay {
+		maxDelay = max(defaultRetryMaxDelay, baseDelay)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Pool{
+		storage:           storage,
+		workers:           workers,
+		perStreamInFlight: perStream,
+		maxAttempts:       maxAttempts,
+		retryBaseDelay:    baseDelay,
+		retryMaxDelay:     maxDelay,
+		jobs:              make(chan poolJob, queueSize),
+		ctx:               ctx,
+		cancel:            cancel,
+		stopping:          make(chan struct{}),
+	}
+}
+
+func (p *Pool) Start() {
+	slog.Info("Starting upload pool", "workers", p.workers, "queueSize", cap(p.jobs), "perStreamInFlight", p.perStreamInFlight, "maxAttempts", p.maxAttempts)
+
+	for range p.workers {
+		p.wg.Add(1)
+		go p.worker()
+	}
+
+	go p.reportStats()
+}
+
+// Stop waits for queued uploads to finish. Enqueues that come later, or
+// that still wait for room in the queue, fail with ErrPoolStopped; journaled
+// jobs are uploaded on the next start.
+func (p *Pool) Stop() {
+	close(p.stopping)
+
+	p.mu.Lock()
+	p.stopped = true
+	close(p.jobs)
+	p.mu.Unlock()
+
+	p.wg.Wait()
+	p.cancel()
+}
+
+// send hands a job to the workers and reports whether it had to wait for
+// room in the queue.
+func (p *Pool) send(ctx context.Context, pj poolJob) (bool, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if p.stopped {
+		return false, ErrPoolStopped
+	}
+
+	p.queued.Add(1)
+	select {
+	case p.jobs <- pj:
+		return false, nil
+	default:
+	}
+
+	var err error
+	select {
+	case p.jobs <- pj:
+		return true, nil
+	case <-ctx.Done():
+		err = ctx.Err()
+	case <-p.stopping:
+		err = ErrPoolStopped
+	}
+	p.queued.Add(-1)
+	return true, err
+}
+
+func (p *Pool) Stats() Stats {
+	return Stats{
+		Queued:          p.queued.Load(),
+		InFlight:        p.inFlight.Load(),
+		Completed:       p.completed.Load(),
+		Failed:          p.failed.Load(),
+		Retried:         p.retried.Load(),
+		BlockedEnqueues: p.blockedEnqueues.Load(),
+		BlockedTime:     time.Duration(p.blockedNanos.Load()),
+	}
+}
+
+// NewQueue opens the per-stream view of the pool. At most perStreamInFlight
+// uploads of one stream are queued or running at a time, so one stream with a
+// slow disk or huge segments cannot starve the others.
+func (p *Pool) NewQueue() *Queue {
+	return &Queue{
+		pool:    p,
+		slots:   make(chan struct{}, p.perStreamInFlight),
+		results: make(chan Result, p.perStreamInFlight),
+	}
+}
+
+func (p *Pool) worker() {
+	defer p.wg.Done()
+
+	for pj := range p.jobs {
+		p.queued.Add(-1)
+		p.inFlight.Add(1)
+
+		err := p.upload(pj.job)
+
+		p.inFlight.Add(-1)
+		if err != nil {
+			p.failed.Add(1)
+		} else {
+			p.completed.Add(1)
+		}
+
+		pj.queue.complete(Result{Job: pj.job, Err: err})
+	}
+}
+
+// upload stores one object, retrying failed attempts with jittered
+// exponential backoff. A local file that is gone will not come back, so that
+// error is returned at once.
+func (p *Pool) upload(job Job) error {
+	var err error
+	for attempt := 1; ; attempt++ {
+		err = p.storage.UploadFile(context.Background(), job.LocalPath, job.RemotePath, job.ContentType, job.CacheControl)
+		if err == nil || errors.Is(err, os.ErrNotExist) || attempt >= p.maxAttempts {
+			return err
+		}
+
+		delay := p.backoff(attempt)
+		p.retried.Add(1)
+		slog.Warn("Upload failed, retrying",
+			"remotePath", job.RemotePath,
+			"attempt", attempt,
+			"delay", delay.Round(time.Millisecond),
+			"error", err,
+		)
+
+		select {
+		case <-time.After(delay):
+		case <-p.ctx.Done():
+			return err
+		}
+	}
+}
+
+// backoff returns a random delay up to base*2^(attempt-1), capped at the
+// maximum, so uploads failing together do not retry in lockstep.
+func (p *Pool) backoff(attempt int) time.Duration {
+	ceiling := p.retryMaxDelay
+	if shift := attempt - 1; shift < 32 {
+		ceiling = min(p.retryBaseDelay<<shift, p.retryMaxDelay)
+	}
+	return p.retryBaseDelay/2 + rand.N(ceiling-p.retryBaseDelay/2+1)
+}
+
+func (p *Pool) reportStats() {
+	ticker := time.NewTicker(statsInterval)
+	defer ticker.Stop()
+
+	var last Stats
+	for {
+		select {
+		case <-p.ctx.Done():
+			return
+		case <-ticker.C:
+			stats := p.Stats()
+			if stats == last {
+				continue
+			}
+			last = stats
+
+			slog.Info("Upload pool stats",
+				"queued", stats.Queued,
+				"inFlight", stats.InFlight,
+				"completed", stats.Completed,
+				"failed", stats.Failed,
+				"retried", stats.Retried,
+				"blockedEnqueues", stats.BlockedEnqueues,
+				"blockedTime", stats.BlockedTime.Round(time.Millisecond),
+			)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package uploader
+
+import (
+	"context"
+	"time"
+)
+
+// Queue submits the uploads of one stream to the shared pool. Results come
+// back on Results in completion order, which is not submission order, and
+// each received result must be acknowledged with Ack to free its slot.
+//
+// A Queue is meant to be used from a single goroutine.
+type Queue struct {
+	pool *Pool
+
+	slots   chan struct{}
+	results chan Result
+
+	// handling is set while a result handler runs. Jobs it enqueues wait in
+	// deferred for the Enqueue or Drain that called it, instead of recursing
+	// on its stack.
+	handling bool
+	deferred []Job
+}
+
+// Enqueue blocks while the stream already has its share of uploads queued or
+// running, or while the global queue is full. While it waits it hands
+// finished results to handle, since only those free a slot; the results
+// channel is sized to the slots, so workers never block on a slow stream.
+func (q *Queue) Enqueue(ctx context.Context, job Job, handle func(Result)) error {
+	if q.handling {
+		q.deferred = append(q.deferred, job)
+		return nil
+	}
+
+	if err := q.submit(ctx, job, handle); err != nil {
+		return err
+	}
+	return q.flushDeferred(ctx, handle)
+}
+
+// flushDeferred submits the jobs enqueued by result handlers, and those
+// their own results enqueue, until none are left.
+func (q *Queue) flushDeferred(ctx context.Context, handle func(Result)) error {
+	for len(q.deferred) > 0 {
+		job := q.deferred[0]
+		q.deferred = q.deferred[1:]
+		if err := q.submit(ctx, job, handle); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (q *Queue) submit(ctx context.Context, job Job, handle func(Result)) error {
+	start := time.Now()
+	blocked := false
+
+	for acquired := false; !acquired; {
+		select {
+		case q.slots <- struct{}{}:
+			acquired = true
+			continue
+		default:
+		}
+
+		blocked = true
+		select {
+		case q.slots <- struct{}{}:
+			acquired = true
+		case r := <-q.results:
+			q.handle(r, handle)
+		case <-ctx.Done():
+			q.recordBlocked(start)
+			return ctx.Err()
+		}
+	}
+
+	waited, err := q.pool.send(ctx, poolJob{job: job, queue: q})
+	if err != nil {
+		q.Ack()
+		if blocked || waited {
+			q.recordBlocked(start)
+		}
+		return err
+	}
+
+	if blocked || waited {
+		q.recordBlocked(start)
+	}
+	return nil
+}
+
+// Drain hands every outstanding result to handle and returns once the
+// stream has nothing queued or running.
+func (q *Queue) Drain(handle func(Result)) {
+	for q.Pending() > 0 || len(q.deferred) > 0 {
+		if len(q.deferred) > 0 {
+			_ = q.flushDeferred(context.Background(), handle)
+			continue
+		}
+		q.handle(<-q.results, handle)
+	}
+}
+
+func (q *Queue) handle(r Result, handle func(Result)) {
+	q.Ack()
+	q.handling = true
+	defer func() { q.handling = false }()
+	handle(r)
+}
+
+func (q *Queue) Results() <-chan Result {
+	return q.results
+}
+
+// Pending is the number of enqueued uploads whose result has not been
+// acknowledged yet.
+func (q *Queue) Pending() int {
+	return len(q.slots)
+}
+
+func (q *Queue) Ack() {
+	<-q.slots
+}
+
+func (q *Queue) complete(r Result) {
+	q.results <- r
+}
+
+func (q *Queue) recordBlocked(start time.Time) {
+	q.pool.blockedEnqueues.Add(1)
+	q.pool.blockedNanos.Add(int64(time.Since(start)))
+}
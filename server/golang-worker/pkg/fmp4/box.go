@@ -0,0 +1,90 @@
+package fmp4
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+var ErrTruncated = errors.New("fmp4: truncated box")
+
+type Box struct {
+	Type       string
+	Offset     int64
+	Size       int64
+	HeaderSize int64
+}
+
+func (b Box) End() int64 {
+	return b.Offset + b.Size
+}
+
+func (b Box) Payload(data []byte) []byte {
+	return data[b.Offset+b.HeaderSize : b.End()]
+}
+
+// ReadBoxes returns the complete boxes found at the top level of data. A
+// trailing box that is still being written is left out, so callers can parse a
+// file that is growing under them.
+func ReadBoxes(data []byte) []Box {
+	var boxes []Box
+
+	var offset int64
+	for {
+		box, err := readBoxHeader(data, offset)
+		if err != nil {
+			return boxes
+		}
+		boxes = append(boxes, box)
+		offset = box.End()
+	}
+}
+
+func readBoxHeader(data []byte, offset int64) (Box, error) {
+	if int64(len(data))-offset < 8 {
+		return Box{}, ErrTruncated
+	}
+
+	size := int64(binary.BigEndian.Uint32(data[offset:]))
+	boxType := string(data[offset+4 : offset+8])
+	headerSize := int64(8)
+
+	switch size {
+	case 0:
+		size = int64(len(data)) - offset
+	case 1:
+		if int64(len(data))-offset < 16 {
+			return Box{}, ErrTruncated
+		}
+		size = int64(binary.BigEndian.Uint64(data[offset+8:]))
+		headerSize = 16
+	}
+
+	if size < headerSize || offset+size > int64(len(data)) {
+		return Box{}, ErrTruncated
+	}
+
+	return Box{Type: boxType, Offset: offset, Size: size, HeaderSize: headerSize}, nil
+}
+
+// findChild returns the payload of the first direct child of the given type.
+func findChild(payload []byte, boxType string) ([]byte, bool) {
+	for _, box := range ReadBoxes(payload) {
+		if box.Type == boxType {
+			return box.Payload(payload), true
+		}
+	}
+	return nil, false
+}
+
+// findPath walks nested containers, e.g. findPath(data, "moov", "trak").
+func findPath(data []byte, path ...string) ([]byte, bool) {
+	current := data
+	for _, boxType := range path {
+		next, ok := findChild(current, boxType)
+		if !ok {
+			return nil, false
+		}
+		current = next
+	}
+	return current, true
+}
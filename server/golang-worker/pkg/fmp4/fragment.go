@@ -0,0 +1,216 @@
+package fmp4
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+const (
+	tfhdBaseDataOffset         = 0x000001
+	tfhdSampleDescriptionIndex = 0x000002
+	tfhdDefaultSampleDuration  = 0x000008
+	tfhdDefaultSampleSize      = 0x000010
+	tfhdDefaultSampleFlags     = 0x000020
+
+	trunDataOffset       = 0x000001
+	trunFirstSampleFlags = 0x000004
+	trunSampleDuration   = 0x000100
+	trunSampleSize       = 0x000200
+	trunSampleFlags      = 0x000400
+	trunSampleCTO        = 0x000800
+
+	sampleIsNonSync = 0x00010000
+)
+
+var (
+	ErrNoTimescale = errors.New("fmp4: no mdhd timescale in init segment")
+	ErrNoFragments = errors.New("fmp4: no complete fragment in media segment")
+)
+
+// Fragment is one moof+mdat pair, plus any boxes (styp, prft, ...) written
+// before it since the previous fragment.
+type Fragment struct {
+	Offset         int64
+	Size           int64
+	BaseDecodeTime uint64
+	Duration       uint64
+	Independent    bool
+}
+
+// ParseTimescale reads the media timescale of the first track of an init
+// segment.
+func ParseTimescale(init []byte) (uint32, error) {
+	mdhd, ok := findPath(init, "moov", "trak", "mdia", "mdhd")
+	if !ok || len(mdhd) < 4 {
+		return 0, ErrNoTimescale
+	}
+
+	// version 1 uses 64-bit creation/modification times
+	offset := 12
+	if mdhd[0] == 1 {
+		offset = 20
+	}
+	if len(mdhd) < offset+4 {
+		return 0, ErrNoTimescale
+	}
+
+	return binary.BigEndian.Uint32(mdhd[offset:]), nil
+}
+
+// ParseFragments returns the complete fragments of a media segment, starting
+// at offset 0. Data after the last complete mdat is ignored.
+func ParseFragments(data []byte) []Fragment {
+	var fragments []Fragment
+
+	start := int64(0)
+	var pending *Fragment
+
+	for _, box := range ReadBoxes(data) {
+		switch box.Type {
+		case "moof":
+			fragment := parseMoof(box.Payload(data))
+			pending = &fragment
+		case "mdat":
+			if pending == nil {
+				continue
+			}
+			pending.Offset = start
+			pending.Size = box.End() - start
+			fragments = append(fragments, *pending)
+
+			start = box.End()
+			pending = nil
+		}
+	}
+
+	return fragments
+}
+
+// SegmentSpan returns the decode time a media segment starts at and how
+// long it lasts, both in the timescale of its track.
+func SegmentSpan(data []byte) (start, duration uint64, err error) {
+	fragments := ParseFragments(data)
+	if len(fragments) == 0 {
+		return 0, 0, ErrNoFragments
+	}
+
+	first := fragments[0]
+	last := fragments[len(fragments)-1]
+	return first.BaseDecodeTime, last.BaseDecodeTime + last.Duration - first.BaseDecodeTime, nil
+}
+
+func parseMoof(moof []byte) Fragment {
+	var f Fragment
+
+	traf, ok := findChild(moof, "traf")
+	if !ok {
+		return f
+	}
+
+	var defaultDuration, defaultFlags uint32
+	if tfhd, ok := findChild(traf, "tfhd"); ok && len(tfhd) >= 8 {
+		flags := binary.BigEndian.Uint32(tfhd) & 0xffffff
+		offset := 8 // version/flags + track_ID
+		if flags&tfhdBaseDataOffset != 0 {
+			offset += 8
+		}
+		if flags&tfhdSampleDescriptionIndex != 0 {
+			offset += 4
+		}
+		if flags&tfhdDefaultSampleDuration != 0 && len(tfhd) >= offset+4 {
+			defaultDuration = binary.BigEndian.Uint32(tfhd[offset:])
+			offset += 4
+		}
+		if flags&tfhdDefaultSampleSize != 0 {
+			offset += 4
+		}
+		if flags&tfhdDefaultSampleFlags != 0 && len(tfhd) >= offset+4 {
+			defaultFlags = binary.BigEndian.Uint32(tfhd[offset:])
+		}
+	}
+
+	if tfdt, ok := findChild(traf, "tfdt"); ok && len(tfdt) >= 8 {
+		if tfdt[0] == 1 && len(tfdt) >= 12 {
+			f.BaseDecodeTime = binary.BigEndian.Uint64(tfdt[4:])
+		} else {
+			f.BaseDecodeTime = uint64(binary.BigEndian.Uint32(tfdt[4:]))
+		}
+	}
+
+	firstFlags := defaultFlags
+	for _, box := range ReadBoxes(traf) {
+		if box.Type != "trun" {
+			continue
+		}
+		duration, flags, ok := parseTrun(box.Payload(traf), defaultDuration, defaultFlags)
+		if !ok {
+			continue
+		}
+		if f.Duration == 0 {
+			firstFlags = flags
+		}
+		f.Duration += duration
+	}
+
+	f.Independent = firstFlags&sampleIsNonSync == 0
+	return f
+}
+
+// parseTrun returns the summed sample durations of a track run and the
+// flags of its first sample.
+func parseTrun(trun []byte, defaultDuration, defaultFlags uint32) (uint64, uint32, bool) {
+	if len(trun) < 8 {
+		return 0, 0, false
+	}
+
+	flags := binary.BigEndian.Uint32(trun) & 0xffffff
+	count := binary.BigEndian.Uint32(trun[4:])
+	offset := 8
+
+	if flags&trunDataOffset != 0 {
+		offset += 4
+	}
+
+	firstFlags := defaultFlags
+	hasFirstFlags := false
+	if flags&trunFirstSampleFlags != 0 {
+		if len(trun) < offset+4 {
+			return 0, 0, false
+		}
+		firstFlags = binary.BigEndian.Uint32(trun[offset:])
+		hasFirstFlags = true
+		offset += 4
+	}
+
+	var total uint64
+	for i := uint32(0); i < count; i++ {
+		duration := defaultDuration
+
+		if flags&trunSampleDuration != 0 {
+			if len(trun) < offset+4 {
+				return 0, 0, false
+			}
+			duration = binary.BigEndian.Uint32(trun[offset:])
+			offset += 4
+		}
+		if flags&trunSampleSize != 0 {
+			offset += 4
+		}
+		if flags&trunSampleFlags != 0 {
+			if len(trun) < offset+4 {
+				return 0, 0, false
+			}
+			if i == 0 && !hasFirstFlags {
+				firstFlags = binary.BigEndian.Uint32(trun[offset:])
+			}
+			offset += 4
+		}
+		if flags&trunSampleCTO != 0 {
+			offset += 4
+		}
+
+		total += uint64(duration)
+	}
+
+	return total, firstFlags, true
+}
@@ -0,0 +1,140 @@
+package fmp4
+
+import (
+	"encoding/binary"
+	"errors"
+	"testing"
+)
+
+func box(boxType string, payload ...[]byte) []byte {
+	size := 8
+	for _, p := range payload {
+		size += len(p)
+	}
+
+	b := binary.BigEndian.AppendUint32(nil, uint32(size))
+	b = append(b, boxType...)
+	for _, p := range payload {
+		b = append(b, p...)
+	}
+	return b
+}
+
+func u32(values ...uint32) []byte {
+	var b []byte
+	for _, v := range values {
+		b = binary.BigEndian.AppendUint32(b, v)
+	}
+	return b
+}
+
+func u64(v uint64) []byte {
+	return binary.BigEndian.AppendUint64(nil, v)
+}
+
+func TestParseTimescale(t *testing.T) {
+	mdhdV0 := box("mdhd", u32(0, 1, 2, 90000, 0))
+	mdhdV1 := box("mdhd", u32(1<<24), u64(1), u64(2), u32(48000), u64(0))
+
+	tests := []struct {
+		name    string
+		init    []byte
+		want    uint32
+		wantErr error
+	}{
+		{
+			name: "version 0",
+			init: append(box("ftyp", []byte("iso6")), box("moov", box("trak", box("mdia", mdhdV0)))...),
+			want: 90000,
+		},
+		{
+			name: "version 1",
+			init: box("moov", box("trak", box("mdia", mdhdV1))),
+			want: 48000,
+		},
+		{
+			name:    "no mdhd",
+			init:    box("moov", box("trak", box("mdia"))),
+			wantErr: ErrNoTimescale,
+		},
+		{
+			name:    "truncated mdhd",
+			init:    box("moov", box("trak", box("mdia", box("mdhd", u32(0, 1))))),
+			wantErr: ErrNoTimescale,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseTimescale(tt.init)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParseTimescale() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseTimescale() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseFragments(t *testing.T) {
+	// three samples of the default duration, the first one a sync sample
+	keyframe := box("moof", box("traf",
+		box("tfhd", u32(tfhdDefaultSampleDuration, 1, 3000)),
+		box("tfdt", u32(1<<24), u64(90000)),
+		box("trun", u32(trunDataOffset|trunFirstSampleFlags, 3, 0, 0)),
+	))
+	// per-sample durations and flags, the first one not a sync sample
+	delta := box("moof", box("traf",
+		box("tfhd", u32(0, 1)),
+		box("tfdt", u32(0, 99000)),
+		box("trun", u32(trunSampleDuration|trunSampleFlags, 2, 1000, sampleIsNonSync, 2000, sampleIsNonSync)),
+	))
+	mdat := box("mdat", []byte("samples"))
+
+	var data []byte
+	data = append(data, box("styp", []byte("msdh"))...)
+	data = append(data, keyframe...)
+	data = append(data, mdat...)
+	firstEnd := len(data)
+	data = append(data, delta...)
+	data = append(data, mdat...)
+	secondEnd := len(data)
+
+	// a fragment still being written is left out
+	data = append(data, box("moof", box("traf"))...)
+	data = append(data, mdat[:6]...)
+
+	got := ParseFragments(data)
+	want := []Fragment{
+		{Offset: 0, Size: int64(firstEnd), BaseDecodeTime: 90000, Duration: 9000, Independent: true},
+		{Offset: int64(firstEnd), Size: int64(secondEnd - firstEnd), BaseDecodeTime: 99000, Duration: 3000, Independent: false},
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("ParseFragments() returned %d fragments, want %d: %+v", len(got), len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("fragment %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+
+	start, duration, err := SegmentSpan(data)
+	if err != nil {
+		t.Fatalf("SegmentSpan() error = %v", err)
+	}
+	if start != 90000 || duration != 12000 {
+		t.Errorf("SegmentSpan() = %d, %d, want 90000, 12000", start, duration)
+	}
+}
+
+func TestParseFragmentsIncomplete(t *testing.T) {
+	moof := box("moof", box("traf", box("tfdt", u32(0, 0))))
+	if got := ParseFragments(moof); len(got) != 0 {
+		t.Errorf("ParseFragments() = %+v, want no fragment without its mdat", got)
+	}
+	if _, _, err := SegmentSpan(moof); !errors.Is(err, ErrNoFragments) {
+		t.Errorf("SegmentSpan() error = %v, want %v", err, ErrNoFragments)
+	}
+}
@@ -1,4 +1,10 @@
-import { IsNotEmpty, IsNumber, IsString } from 'class-validator';
+import {
+  IsIn,
+  IsNotEmpty,
+  IsNumber,
+  IsOptional,
+  IsString,
+} from 'class-validator';
 import { KafkaEventBase, KafkaRetryBase } from 'src/infrastructure/kafka/types';
 
 export class StreamStartedPayload
@@ -24,4 +30,28 @@ export class StreamStartedPayload
   @IsString()
   @IsNotEmpty()
   action: 'START' | 'STOP';
+
+  @IsOptional()
+  @IsIn(['dash', 'cmaf', 'llhls'])
+  outputMode?: 'dash' | 'cmaf' | 'llhls';
+}
+
+export type StreamLifecycleType =
+  | 'TRANSCODE_STARTED'
+  | 'STREAM_PLAYABLE'
+  | 'TRANSCODE_CRASHED'
+  | 'STREAM_FAILED'
+  | 'VOD_FINALIZED';
+
+export class StreamLifecyclePayload extends KafkaEventBase {
+  @IsString()
+  @IsNotEmpty()
+  streamId: string;
+
+  @IsString()
+  @IsNotEmpty()
+  type: StreamLifecycleType;
+
+  @IsOptional()
+  data?: Record<string, unknown>;
 }
@@ -77,3 +77,23 @@ export type Recording = Prisma.RecordingModel
  * 
  */
 export type ViewerSession = Prisma.ViewerSessionModel
+/**
+ * Model Outbox
+ * 
+ */
+export type Outbox = Prisma.OutboxModel
+/**
+ * Model ProcessedEvent
+ * 
+ */
+export type ProcessedEvent = Prisma.ProcessedEventModel
+/**
+ * Model StreamHandoff
+ * 
+ */
+export type StreamHandoff = Prisma.StreamHandoffModel
+/**
+ * Model StreamLease
+ * 
+ */
+export type StreamLease = Prisma.StreamLeaseModel
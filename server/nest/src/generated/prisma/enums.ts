@@ -32,7 +32,15 @@ export const StreamEventType = {
   STREAM_STOP: 'STREAM_STOP',
   STREAM_CONNECT: 'STREAM_CONNECT',
   STREAM_DISCONNECT: 'STREAM_DISCONNECT',
-  INVALID_KEY: 'INVALID_KEY'
+  INVALID_KEY: 'INVALID_KEY',
+  TRANSCODE_CRASH: 'TRANSCODE_CRASH',
+  UPLOAD_FAILED: 'UPLOAD_FAILED',
+  RETRY_SCHEDULED: 'RETRY_SCHEDULED',
+  RETRY_EXHAUSTED: 'RETRY_EXHAUSTED',
+  STOP_STAGE: 'STOP_STAGE',
+  FINALIZED: 'FINALIZED',
+  HANDED_OVER: 'HANDED_OVER',
+  TAKEN_OVER: 'TAKEN_OVER'
 } as const
 
 export type StreamEventType = (typeof StreamEventType)[keyof typeof StreamEventType]
@@ -20,7 +20,7 @@ const config: runtime.GetPrismaClientConfig = {
   "clientVersion": "7.3.0",
   "engineVersion": "9d6ad21cbbceab97458517b147a6a09ff43aa735",
   "activeProvider": "postgresql",
-  "inlineSchema": "// This is your Prisma schema file,\n// learn more about it in the docs: https://pris.ly/d/prisma-schema\n\n// Looking for ways to speed up your queries, or scale easily with your serverless or edge functions?\n// Try Prisma Accelerate: https://pris.ly/cli/accelerate-init\n\ngenerator client {\n  provider     = \"prisma-client\"\n  output       = \"../src/generated/prisma\"\n  moduleFormat = \"cjs\"\n}\n\ndatasource db {\n  provider = \"postgresql\"\n}\n\nenum UserRole {\n  ADMIN\n  STREAMER\n  VIEWER\n}\n\nenum StreamVisibility {\n  PUBLIC\n  PRIVATE\n  UNLISTED\n}\n\nenum StreamEventType {\n  STREAM_START\n  STREAM_STOP\n  STREAM_CONNECT\n  STREAM_DISCONNECT\n  INVALID_KEY\n}\n\nenum ProviderType {\n  CREDENTIALS\n  GOOGLE\n  DISCORD\n}\n\nmodel User {\n  id     String   @id @default(cuid())\n  avatar String?\n  name   String?  @default(dbgenerated(\"('user_' || substring(md5(random()::text), 1, 8))\"))\n  email  String   @unique\n  role   UserRole @default(VIEWER)\n\n  accounts Account[]\n  streams  Stream[]\n\n  createdAt DateTime @default(now())\n  updatedAt DateTime @updatedAt\n\n  @@index([role])\n}\n\nmodel Account {\n  id                String       @id @default(cuid())\n  userId            String\n  provider          ProviderType\n  providerAccountId String\n  isVerified        Boolean      @default(false)\n\n  password String?\n\n  user User @relation(fields: [userId], references: [id], onDelete: Cascade)\n\n  @@unique([provider, providerAccountId])\n  @@index([userId])\n}\n\nmodel Stream {\n  id     String @id @default(cuid())\n  userId String\n  user   User   @relation(fields: [userId], references: [id])\n\n  title       String\n  description String?\n  isLive      Boolean          @default(false)\n  visibility  StreamVisibility @default(PUBLIC)\n\n  ingestKey StreamKey?\n  meta      StreamMeta?\n\n  events StreamEvent[]\n\n  recordings Recording[]\n\n  startedAt DateTime?\n  endedAt   DateTime?\n\n  createdAt      DateTime        @default(now())\n  updatedAt      DateTime        @updatedAt\n  viewerSessions ViewerSession[]\n\n  @@index([userId])\n  @@index([isLive])\n  @@index([visibility])\n  @@index([createdAt])\n}\n\nmodel StreamMeta {\n  id       String @id @default(cuid())\n  streamId String @unique\n  stream   Stream @relation(fields: [streamId], references: [id], onDelete: Cascade)\n\n  totalDuration  Float @default(0)\n  segmentCount   Int   @default(0)\n  lastSegmentSeq Int   @default(0)\n\n  // DASH Mathematical Metadata\n  segmentDuration Int     @default(2)\n  timescale       Int     @default(1000)\n  videoRepId      String  @default(\"0\")\n  audioRepId      String  @default(\"1\")\n  basePath        String? // e.g., \"streams/streamId\"\n\n  createdAt DateTime @default(now())\n  updatedAt DateTime @default(now())\n\n  @@index([streamId])\n}\n\nmodel StreamKey {\n  id       String @id @default(cuid())\n  streamId String @unique\n  stream   Stream @relation(fields: [streamId], references: [id])\n\n  keyHash   String\n  isActive  Boolean   @default(true)\n  expiresAt DateTime?\n\n  lastUsedAt DateTime?\n  createdAt  DateTime  @default(now())\n\n  @@index([streamId])\n  @@index([isActive])\n}\n\nmodel StreamEvent {\n  id       String @id @default(cuid())\n  streamId String\n  stream   Stream @relation(fields: [streamId], references: [id])\n\n  type    StreamEventType\n  payload Json?\n\n  createdAt DateTime @default(now())\n\n  @@index([streamId])\n  @@index([type])\n  @@index([createdAt])\n  @@index([streamId, createdAt])\n}\n\nmodel Recording {\n  id       String @id @default(cuid())\n  streamId String\n  stream   Stream @relation(fields: [streamId], references: [id])\n\n  fileUrl  String\n  duration Int?\n  size     BigInt?\n\n  createdAt DateTime @default(now())\n\n  @@index([streamId])\n  @@index([createdAt])\n}\n\nmodel ViewerSession {\n  id       String @id @default(cuid())\n  streamId String\n  stream   Stream @relation(fields: [streamId], references: [id])\n\n  ip        String\n  userAgent String?\n  startedAt DateTime  @default(now())\n  endedAt   DateTime?\n\n  @@index([streamId])\n  @@index([startedAt])\n}\n",
+  "inlineSchema": "// This is your Prisma schema file,\n// learn more about it in the docs: https://pris.ly/d/prisma-schema\n\n// Looking for ways to speed up your queries, or scale easily with your serverless or edge functions?\n// Try Prisma Accelerate: https://pris.ly/cli/accelerate-init\n\ngenerator client {\n  provider     = \"prisma-client\"\n  output       = \"../src/generated/prisma\"\n  moduleFormat = \"cjs\"\n}\n\ndatasource db {\n  provider = \"postgresql\"\n}\n\nenum UserRole {\n  ADMIN\n  STREAMER\n  VIEWER\n}\n\nenum StreamVisibility {\n  PUBLIC\n  PRIVATE\n  UNLISTED\n}\n\nenum StreamEventType {\n  STREAM_START\n  STREAM_STOP\n  STREAM_CONNECT\n  STREAM_DISCONNECT\n  INVALID_KEY\n\n  // worker lifecycle\n  TRANSCODE_CRASH\n  UPLOAD_FAILED\n  RETRY_SCHEDULED\n  RETRY_EXHAUSTED\n  STOP_STAGE\n  FINALIZED\n  HANDED_OVER\n  TAKEN_OVER\n}\n\nenum ProviderType {\n  CREDENTIALS\n  GOOGLE\n  DISCORD\n}\n\nmodel User {\n  id     String   @id @default(cuid())\n  avatar String?\n  name   String?  @default(dbgenerated(\"('user_' || substring(md5(random()::text), 1, 8))\"))\n  email  String   @unique\n  role   UserRole @default(VIEWER)\n\n  accounts Account[]\n  streams  Stream[]\n\n  createdAt DateTime @default(now())\n  updatedAt DateTime @updatedAt\n\n  @@index([role])\n}\n\nmodel Account {\n  id                String       @id @default(cuid())\n  userId            String\n  provider          ProviderType\n  providerAccountId String\n  isVerified        Boolean      @default(false)\n\n  password String?\n\n  user User @relation(fields: [userId], references: [id], onDelete: Cascade)\n\n  @@unique([provider, providerAccountId])\n  @@index([userId])\n}\n\nmodel Stream {\n  id     String @id @default(cuid())\n  userId String\n  user   User   @relation(fields: [userId], references: [id])\n\n  title       String\n  description String?\n  isLive      Boolean          @default(false)\n  visibility  StreamVisibility @default(PUBLIC)\n\n  ingestKey StreamKey?\n  meta      StreamMeta?\n\n  events StreamEvent[]\n\n  recordings Recording[]\n\n  startedAt DateTime?\n  endedAt   DateTime?\n\n  createdAt      DateTime        @default(now())\n  updatedAt      DateTime        @updatedAt\n  viewerSessions ViewerSession[]\n\n  @@index([userId])\n  @@index([isLive])\n  @@index([visibility])\n  @@index([createdAt])\n}\n\nmodel StreamMeta {\n  id       String @id @default(cuid())\n  streamId String @unique\n  stream   Stream @relation(fields: [streamId], references: [id], onDelete: Cascade)\n\n  totalDuration  Float   @default(0)\n  segmentCount   Int     @default(0)\n  lastSegmentSeq Int     @default(0)\n\n  // DASH Mathematical Metadata\n  segmentDuration Int    @default(2)\n  timescale       Int    @default(1000)\n  videoRepId      String @default(\"0\")\n  audioRepId      String @default(\"1\")\n  basePath        String?\n\n  // static MPD written by the worker at end of stream\n  vodManifestPath String?\n\n  // representations the worker encodes, for manifests built without the MPD\n  renditions Json?\n\n  createdAt DateTime @default(now())\n  updatedAt DateTime @default(now())\n\n  @@index([streamId])\n}\n\nmodel StreamKey {\n  id       String @id @default(cuid())\n  streamId String @unique\n  stream   Stream @relation(fields: [streamId], references: [id])\n\n  keyHash   String\n  isActive  Boolean   @default(true)\n  expiresAt DateTime?\n\n  lastUsedAt DateTime?\n  createdAt  DateTime  @default(now())\n\n  @@index([streamId])\n  @@index([isActive])\n}\n\nmodel StreamEvent {\n  id       String @id @default(cuid())\n  streamId String\n  stream   Stream @relation(fields: [streamId], references: [id])\n\n  type    StreamEventType\n  payload Json?\n\n  createdAt DateTime @default(now())\n\n  @@index([streamId])\n  @@index([type])\n  @@index([createdAt])\n  @@index([streamId, createdAt])\n}\n\nmodel Recording {\n  id       String @id @default(cuid())\n  streamId String\n  stream   Stream @relation(fields: [streamId], references: [id])\n\n  fileUrl  String\n  duration Int?\n  size     BigInt?\n\n  createdAt DateTime @default(now())\n\n  @@index([streamId])\n  @@index([createdAt])\n}\n\nmodel ViewerSession {\n  id       String @id @default(cuid())\n  streamId String\n  stream   Stream @relation(fields: [streamId], references: [id])\n\n  ip        String\n  userAgent String?\n  startedAt DateTime  @default(now())\n  endedAt   DateTime?\n\n  @@index([streamId])\n  @@index([startedAt])\n}\n\nmodel Outbox {\n  id      String @id @default(cuid())\n  topic   String\n  key     String\n  payload Json\n\n  attempts  Int     @default(0)\n  lastError String?\n\n  createdAt DateTime  @default(now())\n  sentAt    DateTime?\n\n  @@index([sentAt, createdAt])\n}\n\nmodel ProcessedEvent {\n  eventId    String\n  retryCount Int    @default(0)\n  streamId   String\n  action     String\n\n  processedAt DateTime @default(now())\n\n  @@id([eventId, retryCount])\n  @@index([processedAt])\n}\n\nmodel StreamHandoff {\n  id       String @id\n  streamId String\n\n  fromWorker String\n  toWorker   String?\n  status     String  @default(\"REQUESTED\")\n  resumeSeq  Int?\n\n  createdAt DateTime @default(now())\n  updatedAt DateTime @default(now())\n\n  @@index([streamId])\n}\n\nmodel StreamLease {\n  streamId String @id\n  ownerId  String\n  command  Json\n\n  acquiredAt  DateTime @default(now())\n  heartbeatAt DateTime @default(now())\n  expiresAt   DateTime\n\n  @@index([expiresAt])\n}\n",
   "runtimeDataModel": {
     "models": {},
     "enums": {},
@@ -28,7 +28,7 @@ const config: runtime.GetPrismaClientConfig = {
   }
 }
 
-config.runtimeDataModel = JSON.parse("{\"models\":{\"User\":{\"fields\":[{\"name\":\"id\",\"kind\":\"scalar\",\"type\":\"String\"},{\"name\":\"avatar\",\"kind\":\"scalar\",\"type\":\"String\"},{\"name\":\"name\",\"kind\":\"scalar\",\"type\":\"String\"},{\"name\":\"email\",\"kind\":\"scalar\",\"type\":\"String\"},{\"name\":\"role\",\"kind\":\"enum\",\"type\":\"UserRole\"},{\"name\":\"accounts\",\"kind\":\"object\",\"type\":\"Account\",\"relationName\":\"AccountToUser\"},{\"name\":\"streams\",\"kind\":\"object\",\"type\":\"Stream\",\"relationName\":\"StreamToUser\"},{\"name\":\"createdAt\",\"kind\":\"scalar\",\"type\":\"DateTime\"},{\"name\":\"updatedAt\",\"kind\":\"scalar\",\"type\":\"DateTime\"}],\"dbName\":null},\"Account\":{\"fields\":[{\"name\":\"id\",\"kind\":\"scalar\",\"type\":\"String\"},{\"name\":\"userId\",\"kind\":\"scalar\",\"type\":\"String\"},{\"name\":\"provider\",\"kind\":\"enum\",\"type\":\"ProviderType\"},{\"name\":\"providerAccountId\",\"kind\":\"scalar\",\"type\":\"String\"},{\"name\":\"isVerified\",\"kind\":\"scalar\",\"type\":\"Boolean\"},{\"name\":\"password\",\"kind\":\"scalar\",\"type\":\"String\"},{\"name\":\"user\",\"kind\":\"object\",\"type\":\"User\",\"relationName\":\"AccountToUser\"}],\"dbName\":null},\"Stream\":{\"fields\":[{\"name\":\"id\",\"kind\":\"scalar\",\"type\":\"String\"},{\"name\":\"userId\",\"kind\":\"scalar\",\"type\":\"String\"},{\"name\":\"user\",\"kind\":\"object\",\"type\":\"User\",\"relationName\":\"StreamToUser\"},{\"name\":\"title\",\"kind\":\"scalar\",\"type\":\"String\"},{\"name\":\"description\",\"kind\":\"scalar\",\"type\":\"String\"},{\"name\":\"isLive\",\"kind\":\"scalar\",\"type\":\"Boolean\"},{\"name\":\"visibility\",\"kind\":\"enum\",\"type\":\"StreamVisibility\"},{\"name\":\"ingestKey\",\"kind\":\"object\",\"type\":\"StreamKey\",\"relationName\":\"StreamToStreamKey\"},{\"name\":\"meta\",\"kind\":\"object\",\"type\":\"StreamMeta\",\"relationName\":\"StreamToStreamMeta\"},{\"name\":\"events\",\"kind\":\"object\",\"type\":\"StreamEvent\",\"relationName\":\"StreamToStreamEvent\"},{\"name\":\"recordings\",\"kind\":\"object\",\"type\":\"Recording\",\"relationName\":\"RecordingToStream\"},{\"name\":\"startedAt\",\"kind\":\"scalar\",\"type\":\"DateTime\"},{\"name\":\"endedAt\",\"kind\":\"scalar\",\"type\":\"DateTime\"},{\"name\":\"createdAt\",\"kind\":\"scalar\",\"type\":\"DateTime\"},{\"name\":\"updatedAt\",\"kind\":\"scalar\",\"type\":\"DateTime\"},{\"name\":\"viewerSessions\",\"kind\":\"object\",\"type\":\"ViewerSession\",\"relationName\":\"StreamToViewerSession\"}],\"dbName\":null},\"StreamMeta\":{\"fields\":[{\"name\":\"id\",\"kind\":\"scalar\",\"type\":\"String\"},{\"name\":\"streamId\",\"kind\":\"scalar\",\"type\":\"String\"},{\"name\":\"stream\",\"kind\":\"object\",\"type\":\"Stream\",\"relationName\":\"StreamToStreamMeta\"},{\"name\":\"totalDuration\",\"kind\":\"scalar\",\"type\":\"Float\"},{\"name\":\"segmentCount\",\"kind\":\"scalar\",\"type\":\"Int\"},{\"name\":\"lastSegmentSeq\",\"kind\":\"scalar\",\"type\":\"Int\"},{\"name\":\"segmentDuration\",\"kind\":\"scalar\",\"type\":\"Int\"},{\"name\":\"timescale\",\"kind\":\"scalar\",\"type\":\"Int\"},{\"name\":\"videoRepId\",\"kind\":\"scalar\",\"type\":\"String\"},{\"name\":\"audioRepId\",\"kind\":\"scalar\",\"type\":\"String\"},{\"name\":\"basePath\",\"kind\":\"scalar\",\"type\":\"String\"},{\"name\":\"createdAt\",\"kind\":\"scalar\",\"type\":\"DateTime\"},{\"name\":\"updatedAt\",\"kind\":\"scalar\",\"type\":\"DateTime\"}],\"dbName\":null},\"StreamKey\":{\"fields\":[{\"name\":\"id\",\"kind\":\"scalar\",\"type\":\"String\"},{\"name\":\"streamId\",\"kind\":\"scalar\",\"type\":\"String\"},{\"name\":\"stream\",\"kind\":\"object\",\"type\":\"Stream\",\"relationName\":\"StreamToStreamKey\"},{\"name\":\"keyHash\",\"kind\":\"scalar\",\"type\":\"String\"},{\"name\":\"isActive\",\"kind\":\"scalar\",\"type\":\"Boolean\"},{\"name\":\"expiresAt\",\"kind\":\"scalar\",\"type\":\"DateTime\"},{\"name\":\"lastUsedAt\",\"kind\":\"scalar\",\"type\":\"DateTime\"},{\"name\":\"createdAt\",\"kind\":\"scalar\",\"type\":\"DateTime\"}],\"dbName\":null},\"StreamEvent\":{\"fields\":[{\"name\":\"id\",\"kind\":\"scalar\",\"type\":\"String\"},{\"name\":\"streamId\",\"kind\":\"scalar\",\"type\":\"String\"},{\"name\":\"stream\",\"kind\":\"object\",\"type\":\"Stream\",\"relationName\":\"StreamToStreamEvent\"},{\"name\":\"type\",\"kind\":\"enum\",\"type\":\"StreamEventType\"},{\"name\":\"payload\",\"kind\":\"scalar\",\"type\":\"Json\"},{\"name\":\"createdAt\",\"kind\":\"scalar\",\"type\":\"DateTime\"}],\"dbName\":null},\"Recording\":{\"fields\":[{\"name\":\"id\",\"kind\":\"scalar\",\"type\":\"String\"},{\"name\":\"streamId\",\"kind\":\"scalar\",\"type\":\"String\"},{\"name\":\"stream\",\"kind\":\"object\",\"type\":\"Stream\",\"relationName\":\"RecordingToStream\"},{\"name\":\"fileUrl\",\"kind\":\"scalar\",\"type\":\"String\"},{\"name\":\"duration\",\"kind\":\"scalar\",\"type\":\"Int\"},{\"name\":\"size\",\"kind\":\"scalar\",\"type\":\"BigInt\"},{\"name\":\"createdAt\",\"kind\":\"scalar\",\"type\":\"DateTime\"}],\"dbName\":null},\"ViewerSession\":{\"fields\":[{\"name\":\"id\",\"kind\":\"scalar\",\"type\":\"String\"},{\"name\":\"streamId\",\"kind\":\"scalar\",\"type\":\"String\"},{\"name\":\"stream\",\"kind\":\"object\",\"type\":\"Stream\",\"relationName\":\"StreamToViewerSession\"},{\"name\":\"ip\",\"kind\":\"scalar\",\"type\":\"String\"},{\"name\":\"userAgent\",\"kind\":\"scalar\",\"type\":\"String\"},{\"name\":\"startedAt\",\"kind\":\"scalar\",\"type\":\"DateTime\"},{\"name\":\"endedAt\",\"kind\":\"scalar\",\"type\":\"DateTime\"}],\"dbName\":null}},\"enums\":{},\"types\":{}}")
+config.runtimeDataModel = JSON.parse("{\"models\":{\"User\":{\"fields\":[{\"name\":\"id\",\"kind\":\"scalar\",\"type\":\"String\"},{\"name\":\"avatar\",\"kind\":\"scalar\",\"type\":\"String\"},{\"name\":\"name\",\"kind\":\"scalar\",\"type\":\"String\"},{\"name\":\"email\",\"kind\":\"scalar\",\"type\":\"String\"},{\"name\":\"role\",\"kind\":\"enum\",\"type\":\"UserRole\"},{\"name\":\"accounts\",\"kind\":\"object\",\"type\":\"Account\",\"relationName\":\"AccountToUser\"},{\"name\":\"streams\",\"kind\":\"object\",\"type\":\"Stream\",\"relationName\":\"StreamToUser\"},{\"name\":\"createdAt\",\"kind\":\"scalar\",\"type\":\"DateTime\"},{\"name\":\"updatedAt\",\"kind\":\"scalar\",\"type\":\"DateTime\"}],\"dbName\":null},\"Account\":{\"fields\":[{\"name\":\"id\",\"kind\":\"scalar\",\"type\":\"String\"},{\"name\":\"userId\",\"kind\":\"scalar\",\"type\":\"String\"},{\"name\":\"provider\",\"kind\":\"enum\",\"type\":\"ProviderType\"},{\"name\":\"providerAccountId\",\"kind\":\"scalar\",\"type\":\"String\"},{\"name\":\"isVerified\",\"kind\":\"scalar\",\"type\":\"Boolean\"},{\"name\":\"password\",\"kind\":\"scalar\",\"type\":\"String\"},{\"name\":\"user\",\"kind\":\"object\",\"type\":\"User\",\"relationName\":\"AccountToUser\"}],\"dbName\":null},\"Stream\":{\"fields\":[{\"name\":\"id\",\"kind\":\"scalar\",\"type\":\"String\"},{\"name\":\"userId\",\"kind\":\"scalar\",\"type\":\"String\"},{\"name\":\"user\",\"kind\":\"object\",\"type\":\"User\",\"relationName\":\"StreamToUser\"},{\"name\":\"title\",\"kind\":\"scalar\",\"type\":\"String\"},{\"name\":\"description\",\"kind\":\"scalar\",\"type\":\"String\"},{\"name\":\"isLive\",\"kind\":\"scalar\",\"type\":\"Boolean\"},{\"name\":\"visibility\",\"kind\":\"enum\",\"type\":\"StreamVisibility\"},{\"name\":\"ingestKey\",\"kind\":\"object\",\"type\":\"StreamKey\",\"relationName\":\"StreamToStreamKey\"},{\"name\":\"meta\",\"kind\":\"object\",\"type\":\"StreamMeta\",\"relationName\":\"StreamToStreamMeta\"},{\"name\":\"events\",\"kind\":\"object\",\"type\":\"StreamEvent\",\"relationName\":\"StreamToStreamEvent\"},{\"name\":\"recordings\",\"kind\":\"object\",\"type\":\"Recording\",\"relationName\":\"RecordingToStream\"},{\"name\":\"startedAt\",\"kind\":\"scalar\",\"type\":\"DateTime\"},{\"name\":\"endedAt\",\"kind\":\"scalar\",\"type\":\"DateTime\"},{\"name\":\"createdAt\",\"kind\":\"scalar\",\"type\":\"DateTime\"},{\"name\":\"updatedAt\",\"kind\":\"scalar\",\"type\":\"DateTime\"},{\"name\":\"viewerSessions\",\"kind\":\"object\",\"type\":\"ViewerSession\",\"relationName\":\"StreamToViewerSession\"}],\"dbName\":null},\"StreamMeta\":{\"fields\":[{\"name\":\"id\",\"kind\":\"scalar\",\"type\":\"String\"},{\"name\":\"streamId\",\"kind\":\"scalar\",\"type\":\"String\"},{\"name\":\"stream\",\"kind\":\"object\",\"type\":\"Stream\",\"relationName\":\"StreamToStreamMeta\"},{\"name\":\"totalDuration\",\"kind\":\"scalar\",\"type\":\"Float\"},{\"name\":\"segmentCount\",\"kind\":\"scalar\",\"type\":\"Int\"},{\"name\":\"lastSegmentSeq\",\"kind\":\"scalar\",\"type\":\"Int\"},{\"name\":\"segmentDuration\",\"kind\":\"scalar\",\"type\":\"Int\"},{\"name\":\"timescale\",\"kind\":\"scalar\",\"type\":\"Int\"},{\"name\":\"videoRepId\",\"kind\":\"scalar\",\"type\":\"String\"},{\"name\":\"audioRepId\",\"kind\":\"scalar\",\"type\":\"String\"},{\"name\":\"basePath\",\"kind\":\"scalar\",\"type\":\"String\"},{\"name\":\"vodManifestPath\",\"kind\":\"scalar\",\"type\":\"String\"},{\"name\":\"renditions\",\"kind\":\"scalar\",\"type\":\"Json\"},{\"name\":\"createdAt\",\"kind\":\"scalar\",\"type\":\"DateTime\"},{\"name\":\"updatedAt\",\"kind\":\"scalar\",\"type\":\"DateTime\"}],\"dbName\":null},\"StreamKey\":{\"fields\":[{\"name\":\"id\",\"kind\":\"scalar\",\"type\":\"String\"},{\"name\":\"streamId\",\"kind\":\"scalar\",\"type\":\"String\"},{\"name\":\"stream\",\"kind\":\"object\",\"type\":\"Stream\",\"relationName\":\"StreamToStreamKey\"},{\"name\":\"keyHash\",\"kind\":\"scalar\",\"type\":\"String\"},{\"name\":\"isActive\",\"kind\":\"scalar\",\"type\":\"Boolean\"},{\"name\":\"expiresAt\",\"kind\":\"scalar\",\"type\":\"DateTime\"},{\"name\":\"lastUsedAt\",\"kind\":\"scalar\",\"type\":\"DateTime\"},{\"name\":\"createdAt\",\"kind\":\"scalar\",\"type\":\"DateTime\"}],\"dbName\":null},\"StreamEvent\":{\"fields\":[{\"name\":\"id\",\"kind\":\"scalar\",\"type\":\"String\"},{\"name\":\"streamId\",\"kind\":\"scalar\",\"type\":\"String\"},{\"name\":\"stream\",\"kind\":\"object\",\"type\":\"Stream\",\"relationName\":\"StreamToStreamEvent\"},{\"name\":\"type\",\"kind\":\"enum\",\"type\":\"StreamEventType\"},{\"name\":\"payload\",\"kind\":\"scalar\",\"type\":\"Json\"},{\"name\":\"createdAt\",\"kind\":\"scalar\",\"type\":\"DateTime\"}],\"dbName\":null},\"Recording\":{\"fields\":[{\"name\":\"id\",\"kind\":\"scalar\",\"type\":\"String\"},{\"name\":\"streamId\",\"kind\":\"scalar\",\"type\":\"String\"},{\"name\":\"stream\",\"kind\":\"object\",\"type\":\"Stream\",\"relationName\":\"RecordingToStream\"},{\"name\":\"fileUrl\",\"kind\":\"scalar\",\"type\":\"String\"},{\"name\":\"duration\",\"kind\":\"scalar\",\"type\":\"Int\"},{\"name\":\"size\",\"kind\":\"scalar\",\"type\":\"BigInt\"},{\"name\":\"createdAt\",\"kind\":\"scalar\",\"type\":\"DateTime\"}],\"dbName\":null},\"ViewerSession\":{\"fields\":[{\"name\":\"id\",\"kind\":\"scalar\",\"type\":\"String\"},{\"name\":\"streamId\",\"kind\":\"scalar\",\"type\":\"String\"},{\"name\":\"stream\",\"kind\":\"object\",\"type\":\"Stream\",\"relationName\":\"StreamToViewerSession\"},{\"name\":\"ip\",\"kind\":\"scalar\",\"type\":\"String\"},{\"name\":\"userAgent\",\"kind\":\"scalar\",\"type\":\"String\"},{\"name\":\"startedAt\",\"kind\":\"scalar\",\"type\":\"DateTime\"},{\"name\":\"endedAt\",\"kind\":\"scalar\",\"type\":\"DateTime\"}],\"dbName\":null},\"Outbox\":{\"fields\":[{\"name\":\"id\",\"kind\":\"scalar\",\"type\":\"String\"},{\"name\":\"topic\",\"kind\":\"scalar\",\"type\":\"String\"},{\"name\":\"key\",\"kind\":\"scalar\",\"type\":\"String\"},{\"name\":\"payload\",\"kind\":\"scalar\",\"type\":\"Json\"},{\"name\":\"attempts\",\"kind\":\"scalar\",\"type\":\"Int\"},{\"name\":\"lastError\",\"kind\":\"scalar\",\"type\":\"String\"},{\"name\":\"createdAt\",\"kind\":\"scalar\",\"type\":\"DateTime\"},{\"name\":\"sentAt\",\"kind\":\"scalar\",\"type\":\"DateTime\"}],\"dbName\":null},\"ProcessedEvent\":{\"fields\":[{\"name\":\"eventId\",\"kind\":\"scalar\",\"type\":\"String\"},{\"name\":\"retryCount\",\"kind\":\"scalar\",\"type\":\"Int\"},{\"name\":\"streamId\",\"kind\":\"scalar\",\"type\":\"String\"},{\"name\":\"action\",\"kind\":\"scalar\",\"type\":\"String\"},{\"name\":\"processedAt\",\"kind\":\"scalar\",\"type\":\"DateTime\"}],\"dbName\":null},\"StreamHandoff\":{\"fields\":[{\"name\":\"id\",\"kind\":\"scalar\",\"type\":\"String\"},{\"name\":\"streamId\",\"kind\":\"scalar\",\"type\":\"String\"},{\"name\":\"fromWorker\",\"kind\":\"scalar\",\"type\":\"String\"},{\"name\":\"toWorker\",\"kind\":\"scalar\",\"type\":\"String\"},{\"name\":\"status\",\"kind\":\"scalar\",\"type\":\"String\"},{\"name\":\"resumeSeq\",\"kind\":\"scalar\",\"type\":\"Int\"},{\"name\":\"createdAt\",\"kind\":\"scalar\",\"type\":\"DateTime\"},{\"name\":\"updatedAt\",\"kind\":\"scalar\",\"type\":\"DateTime\"}],\"dbName\":null},\"StreamLease\":{\"fields\":[{\"name\":\"streamId\",\"kind\":\"scalar\",\"type\":\"String\"},{\"name\":\"ownerId\",\"kind\":\"scalar\",\"type\":\"String\"},{\"name\":\"command\",\"kind\":\"scalar\",\"type\":\"Json\"},{\"name\":\"acquiredAt\",\"kind\":\"scalar\",\"type\":\"DateTime\"},{\"name\":\"heartbeatAt\",\"kind\":\"scalar\",\"type\":\"DateTime\"},{\"name\":\"expiresAt\",\"kind\":\"scalar\",\"type\":\"DateTime\"}],\"dbName\":null}},\"enums\":{},\"types\":{}}")
 
 async function decodeBase64AsWasm(wasmBase64: string): Promise<WebAssembly.Module> {
   const { Buffer } = await import('node:buffer')
@@ -255,6 +255,46 @@ export interface PrismaClient<
     * ```
     */
   get viewerSession(): Prisma.ViewerSessionDelegate<ExtArgs, { omit: OmitOpts }>;
+
+  /**
+   * `prisma.outbox`: Exposes CRUD operations for the **Outbox** model.
+    * Example usage:
+    * ```ts
+    * // Fetch zero or more Outboxes
+    * const outboxes = await prisma.outbox.findMany()
+    * ```
+    */
+  get outbox(): Prisma.OutboxDelegate<ExtArgs, { omit: OmitOpts }>;
+
+  /**
+   * `prisma.processedEvent`: Exposes CRUD operations for the **ProcessedEvent** model.
+    * Example usage:
+    * ```ts
+    * // Fetch zero or more ProcessedEvents
+    * const processedEvents = await prisma.processedEvent.findMany()
+    * ```
+    */
+  get processedEvent(): Prisma.ProcessedEventDelegate<ExtArgs, { omit: OmitOpts }>;
+
+  /**
+   * `prisma.streamHandoff`: Exposes CRUD operations for the **StreamHandoff** model.
+    * Example usage:
+    * ```ts
+    * // Fetch zero or more StreamHandoffs
+    * const streamHandoffs = await prisma.streamHandoff.findMany()
+    * ```
+    */
+  get streamHandoff(): Prisma.StreamHandoffDelegate<ExtArgs, { omit: OmitOpts }>;
+
+  /**
+   * `prisma.streamLease`: Exposes CRUD operations for the **StreamLease** model.
+    * Example usage:
+    * ```ts
+    * // Fetch zero or more StreamLeases
+    * const streamLeases = await prisma.streamLease.findMany()
+    * ```
+    */
+  get streamLease(): Prisma.StreamLeaseDelegate<ExtArgs, { omit: OmitOpts }>;
 }
 
 export function getPrismaClientClass(): PrismaClientConstructor {
@@ -58,7 +58,11 @@ export const ModelName = {
   StreamKey: 'StreamKey',
   StreamEvent: 'StreamEvent',
   Recording: 'Recording',
-  ViewerSession: 'ViewerSession'
+  ViewerSession: 'ViewerSession',
+  Outbox: 'Outbox',
+  ProcessedEvent: 'ProcessedEvent',
+  StreamHandoff: 'StreamHandoff',
+  StreamLease: 'StreamLease'
 } as const
 
 export type ModelName = (typeof ModelName)[keyof typeof ModelName]
@@ -129,6 +133,8 @@ export const StreamMetaScalarFieldEnum = {
   videoRepId: 'videoRepId',
   audioRepId: 'audioRepId',
   basePath: 'basePath',
+  vodManifestPath: 'vodManifestPath',
+  renditions: 'renditions',
   createdAt: 'createdAt',
   updatedAt: 'updatedAt'
 } as const
@@ -184,6 +190,57 @@ export const ViewerSessionScalarFieldEnum = {
 export type ViewerSessionScalarFieldEnum = (typeof ViewerSessionScalarFieldEnum)[keyof typeof ViewerSessionScalarFieldEnum]
 
 
+export const OutboxScalarFieldEnum = {
+  id: 'id',
+  topic: 'topic',
+  key: 'key',
+  payload: 'payload',
+  attempts: 'attempts',
+  lastError: 'lastError',
+  createdAt: 'createdAt',
+  sentAt: 'sentAt'
+} as const
+
+export type OutboxScalarFieldEnum = (typeof OutboxScalarFieldEnum)[keyof typeof OutboxScalarFieldEnum]
+
+
+export const ProcessedEventScalarFieldEnum = {
+  eventId: 'eventId',
+  retryCount: 'retryCount',
+  streamId: 'streamId',
+  action: 'action',
+  processedAt: 'processedAt'
+} as const
+
+export type ProcessedEventScalarFieldEnum = (typeof ProcessedEventScalarFieldEnum)[keyof typeof ProcessedEventScalarFieldEnum]
+
+
+export const StreamHandoffScalarFieldEnum = {
+  id: 'id',
+  streamId: 'streamId',
+  fromWorker: 'fromWorker',
+  toWorker: 'toWorker',
+  status: 'status',
+  resumeSeq: 'resumeSeq',
+  createdAt: 'createdAt',
+  updatedAt: 'updatedAt'
+} as const
+
+export type StreamHandoffScalarFieldEnum = (typeof StreamHandoffScalarFieldEnum)[keyof typeof StreamHandoffScalarFieldEnum]
+
+
+export const StreamLeaseScalarFieldEnum = {
+  streamId: 'streamId',
+  ownerId: 'ownerId',
+  command: 'command',
+  acquiredAt: 'acquiredAt',
+  heartbeatAt: 'heartbeatAt',
+  expiresAt: 'expiresAt'
+} as const
+
+export type StreamLeaseScalarFieldEnum = (typeof StreamLeaseScalarFieldEnum)[keyof typeof StreamLeaseScalarFieldEnum]
+
+
 export const SortOrder = {
   asc: 'asc',
   desc: 'desc'
@@ -192,6 +249,13 @@ export const SortOrder = {
 export type SortOrder = (typeof SortOrder)[keyof typeof SortOrder]
 
 
+export const JsonNullValueInput = {
+  JsonNull: JsonNull
+} as const
+
+export type JsonNullValueInput = (typeof JsonNullValueInput)[keyof typeof JsonNullValueInput]
+
+
 export const NullableJsonNullValueInput = {
   DbNull: DbNull,
   JsonNull: JsonNull
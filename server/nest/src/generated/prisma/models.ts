@@ -16,4 +16,8 @@ export type * from './models/StreamKey.js'
 export type * from './models/StreamEvent.js'
 export type * from './models/Recording.js'
 export type * from './models/ViewerSession.js'
+export type * from './models/Outbox.js'
+export type * from './models/ProcessedEvent.js'
+export type * from './models/StreamHandoff.js'
+export type * from './models/StreamLease.js'
 export type * from './commonInputTypes.js'
\ No newline at end of file
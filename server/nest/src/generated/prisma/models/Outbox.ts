@@ -0,0 +1,1266 @@
+
+/* !!! This is code generated by Prisma. Do not edit directly. !!! */
+/* eslint-disable */
+// biome-ignore-all lint: generated file
+// @ts-nocheck 
+/*
+ * This file exports the `Outbox` model and its related types.
+ *
+ * 🟢 You can import this file directly.
+ */
+import type * as runtime from "@prisma/client/runtime/client"
+import type * as $Enums from "../enums.js"
+import type * as Prisma from "../internal/prismaNamespace.js"
+
+/**
+ * Model Outbox
+ * 
+ */
+export type OutboxModel = runtime.Types.Result.DefaultSelection<Prisma.$OutboxPayload>
+
+export type AggregateOutbox = {
+  _count: OutboxCountAggregateOutputType | null
+  _avg: OutboxAvgAggregateOutputType | null
+  _sum: OutboxSumAggregateOutputType | null
+  _min: OutboxMinAggregateOutputType | null
+  _max: OutboxMaxAggregateOutputType | null
+}
+
+export type OutboxAvgAggregateOutputType = {
+  attempts: number | null
+}
+
+export type OutboxSumAggregateOutputType = {
+  attempts: number | null
+}
+
+export type OutboxMinAggregateOutputType = {
+  id: string | null
+  topic: string | null
+  key: string | null
+  attempts: number | null
+  lastError: string | null
+  createdAt: Date | null
+  sentAt: Date | null
+}
+
+export type OutboxMaxAggregateOutputType = {
+  id: string | null
+  topic: string | null
+  key: string | null
+  attempts: number | null
+  lastError: string | null
+  createdAt: Date | null
+  sentAt: Date | null
+}
+
+export type OutboxCountAggregateOutputType = {
+  id: number
+  topic: number
+  key: number
+  payload: number
+  attempts: number
+  lastError: number
+  createdAt: number
+  sentAt: number
+  _all: number
+}
+
+
+export type OutboxAvgAggregateInputType = {
+  attempts?: true
+}
+
+export type OutboxSumAggregateInputType = {
+  attempts?: true
+}
+
+export type OutboxMinAggregateInputType = {
+  id?: true
+  topic?: true
+  key?: true
+  attempts?: true
+  lastError?: true
+  createdAt?: true
+  sentAt?: true
+}
+
+export type OutboxMaxAggregateInputType = {
+  id?: true
+  topic?: true
+  key?: true
+  attempts?: true
+  lastError?: true
+  createdAt?: true
+  sentAt?: true
+}
+
+export type OutboxCountAggregateInputType = {
+  id?: true
+  topic?: true
+  key?: true
+  payload?: true
+  attempts?: true
+  lastError?: true
+  createdAt?: true
+  sentAt?: true
+  _all?: true
+}
+
+export type OutboxAggregateArgs<ExtArgs extends runtime.Types.Extensions.InternalArgs = runtime.Types.Extensions.DefaultArgs> = {
+  /**
+   * Filter which Outbox to aggregate.
+   */
+  where?: Prisma.OutboxWhereInput
+  /**
+   * {@link https://www.prisma.io/docs/concepts/components/prisma-client/sorting Sorting Docs}
+   * 
+   * Determine the order of Outboxes to fetch.
+   */
+  orderBy?: Prisma.OutboxOrderByWithRelationInput | Prisma.OutboxOrderByWithRelationInput[]
+  /**
+   * {@link https://www.prisma.io/docs/concepts/components/prisma-client/pagination#cursor-based-pagination Cursor Docs}
+   * 
+   * Sets the start position
+   */
+  cursor?: Prisma.OutboxWhereUniqueInput
+  /**
+   * {@link https://www.prisma.io/docs/concepts/components/prisma-client/pagination Pagination Docs}
+   * 
+   * Take `±n` Outboxes from the position of the cursor.
+   */
+  take?: number
+  /**
+   * {@link https://www.prisma.io/docs/concepts/components/prisma-client/pagination Pagination Docs}
+   * 
+   * Skip the first `n` Outboxes.
+   */
+  skip?: number
+  /**
+   * {@link https://www.prisma.io/docs/concepts/components/prisma-client/aggregations Aggregation Docs}
+   * 
+   * Count returned Outboxes
+  **/
+  _count?: true | OutboxCountAggregateInputType
+  /**
+   * {@link https://www.prisma.io/docs/concepts/components/prisma-client/aggregations Aggregation Docs}
+   * 
+   * Select which fields to average
+  **/
+  _avg?: OutboxAvgAggregateInputType
+  /**
+   * {@link https://www.prisma.io/docs/concepts/components/prisma-client/aggregations Aggregation Docs}
+   * 
+   * Select which fields to sum
+  **/
+  _sum?: OutboxSumAggregateInputType
+  /**
+   * {@link https://www.prisma.io/docs/concepts/components/prisma-client/aggregations Aggregation Docs}
+   * 
+   * Select which fields to find the minimum value
+  **/
+  _min?: OutboxMinAggregateInputType
+  /**
+   * {@link https://www.prisma.io/docs/concepts/components/prisma-client/aggregations Aggregation Docs}
+   * 
+   * Select which fields to find the maximum value
+  **/
+  _max?: OutboxMaxAggregateInputType
+}
+
+export type GetOutboxAggregateType<T extends OutboxAggregateArgs> = {
+      [P in keyof T & keyof AggregateOutbox]: P extends '_count' | 'count'
+    ? T[P] extends true
+      ? number
+      : Prisma.GetScalarType<T[P], AggregateOutbox[P]>
+    : Prisma.GetScalarType<T[P], AggregateOutbox[P]>
+}
+
+
+
+
+export type OutboxGroupByArgs<ExtArgs extends runtime.Types.Extensions.InternalArgs = runtime.Types.Extensions.DefaultArgs> = {
+  where?: Prisma.OutboxWhereInput
+  orderBy?: Prisma.OutboxOrderByWithAggregationInput | Prisma.OutboxOrderByWithAggregationInput[]
+  by: Prisma.OutboxScalarFieldEnum[] | Prisma.OutboxScalarFieldEnum
+  having?: Prisma.OutboxScalarWhereWithAggregatesInput
+  take?: number
+  skip?: number
+  _count?: OutboxCountAggregateInputType | true
+  _avg?: OutboxAvgAggregateInputType
+  _sum?: OutboxSumAggregateInputType
+  _min?: OutboxMinAggregateInputType
+  _max?: OutboxMaxAggregateInputType
+}
+
+export type OutboxGroupByOutputType = {
+  id: string
+  topic: string
+  key: string
+  payload: runtime.JsonValue
+  attempts: number
+  lastError: string | null
+  createdAt: Date
+  sentAt: Date | null
+  _count: OutboxCountAggregateOutputType | null
+  _avg: OutboxAvgAggregateOutputType | null
+  _sum: OutboxSumAggregateOutputType | null
+  _min: OutboxMinAggregateOutputType | null
+  _max: OutboxMaxAggregateOutputType | null
+}
+
+type GetOutboxGroupByPayload<T extends OutboxGroupByArgs> = Prisma.PrismaPromise<
+  Array<
+    Prisma.PickEnumerable<OutboxGroupByOutputType, T['by']> &
+      {
+        [P in ((keyof T) & (keyof OutboxGroupByOutputType))]: P extends '_count'
+          ? T[P] extends boolean
+            ? number
+            : Prisma.GetScalarType<T[P], OutboxGroupByOutputType[P]>
+          : Prisma.GetScalarType<T[P], OutboxGroupByOutputType[P]>
+      }
+    >
+  >
+
+
+
+export type OutboxWhereInput = {
+  AND?: Prisma.OutboxWhereInput | Prisma.OutboxWhereInput[]
+  OR?: Prisma.OutboxWhereInput[]
+  NOT?: Prisma.OutboxWhereInput | Prisma.OutboxWhereInput[]
+  id?: Prisma.StringFilter<"Outbox"> | string
+  topic?: Prisma.StringFilter<"Outbox"> | string
+  key?: Prisma.StringFilter<"Outbox"> | string
+  payload?: Prisma.JsonFilter<"Outbox">
+  attempts?: Prisma.IntFilter<"Outbox"> | number
+  lastError?: Prisma.StringNullableFilter<"Outbox"> | string | null
+  createdAt?: Prisma.DateTimeFilter<"Outbox"> | Date | string
+  sentAt?: Prisma.DateTimeNullableFilter<"Outbox"> | Date | string | null
+}
+
+export type OutboxOrderByWithRelationInput = {
+  id?: Prisma.SortOrder
+  topic?: Prisma.SortOrder
+  key?: Prisma.SortOrder
+  payload?: Prisma.SortOrder
+  attempts?: Prisma.SortOrder
+  lastError?: Prisma.SortOrderInput | Prisma.SortOrder
+  createdAt?: Prisma.SortOrder
+  sentAt?: Prisma.SortOrderInput | Prisma.SortOrder
+}
+
+export type OutboxWhereUniqueInput = Prisma.AtLeast<{
+  id?: string
+  AND?: Prisma.OutboxWhereInput | Prisma.OutboxWhereInput[]
+  OR?: Prisma.OutboxWhereInput[]
+  NOT?: Prisma.OutboxWhereInput | Prisma.OutboxWhereInput[]
+  topic?: Prisma.StringFilter<"Outbox"> | string
+  key?: Prisma.StringFilter<"Outbox"> | string
+  payload?: Prisma.JsonFilter<"Outbox">
+  attempts?: Prisma.IntFilter<"Outbox"> | number
+  lastError?: Prisma.StringNullableFilter<"Outbox"> | string | null
+  createdAt?: Prisma.DateTimeFilter<"Outbox"> | Date | string
+  sentAt?: Prisma.DateTimeNullableFilter<"Outbox"> | Date | string | null
+}, "id">
+
+export type OutboxOrderByWithAggregationInput = {
+  id?: Prisma.SortOrder
+  topic?: Prisma.SortOrder
+  key?: Prisma.SortOrder
+  payload?: Prisma.SortOrder
+  attempts?: Prisma.SortOrder
+  lastError?: Prisma.SortOrderInput | Prisma.SortOrder
+  createdAt?: Prisma.SortOrder
+  sentAt?: Prisma.SortOrderInput | Prisma.SortOrder
+  _count?: Prisma.OutboxCountOrderByAggregateInput
+  _avg?: Prisma.OutboxAvgOrderByAggregateInput
+  _max?: Prisma.OutboxMaxOrderByAggregateInput
+  _min?: Prisma.OutboxMinOrderByAggregateInput
+  _sum?: Prisma.OutboxSumOrderByAggregateInput
+}
+
+export type OutboxScalarWhereWithAggregatesInput = {
+  AND?: Prisma.OutboxScalarWhereWithAggregatesInput | Prisma.OutboxScalarWhereWithAggregatesInput[]
+  OR?: Prisma.OutboxScalarWhereWithAggregatesInput[]
+  NOT?: Prisma.OutboxScalarWhereWithAggregatesInput | Prisma.OutboxScalarWhereWithAggregatesInput[]
+  id?: Prisma.StringWithAggregatesFilter<"Outbox"> | string
+  topic?: Prisma.StringWithAggregatesFilter<"Outbox"> | string
+  key?: Prisma.StringWithAggregatesFilter<"Outbox"> | string
+  payload?: Prisma.JsonWithAggregatesFilter<"Outbox">
+  attempts?: Prisma.IntWithAggregatesFilter<"Outbox"> | number
+  lastError?: Prisma.StringNullableWithAggregatesFilter<"Outbox"> | string | null
+  createdAt?: Prisma.DateTimeWithAggregatesFilter<"Outbox"> | Date | string
+  sentAt?: Prisma.DateTimeNullableWithAggregatesFilter<"Outbox"> | Date | string | null
+}
+
+export type OutboxCreateInput = {
+  id?: string
+  topic: string
+  key: string
+  payload: Prisma.JsonNullValueInput | runtime.InputJsonValue
+  attempts?: number
+  lastError?: string | null
+  createdAt?: Date | string
+  sentAt?: Date | string | null
+}
+
+export type OutboxUncheckedCreateInput = {
+  id?: string
+  topic: string
+  key: string
+  payload: Prisma.JsonNullValueInput | runtime.InputJsonValue
+  attempts?: number
+  lastError?: string | null
+  createdAt?: Date | string
+  sentAt?: Date | string | null
+}
+
+export type OutboxUpdateInput = {
+  id?: Prisma.StringFieldUpdateOperationsInput | string
+  topic?: Prisma.StringFieldUpdateOperationsInput | string
+  key?: Prisma.StringFieldUpdateOperationsInput | string
+  payload?: Prisma.JsonNullValueInput | runtime.InputJsonValue
+  attempts?: Prisma.IntFieldUpdateOperationsInput | number
+  lastError?: Prisma.NullableStringFieldUpdateOperationsInput | string | null
+  createdAt?: Prisma.DateTimeFieldUpdateOperationsInput | Date | string
+  sentAt?: Prisma.NullableDateTimeFieldUpdateOperationsInput | Date | string | null
+}
+
+export type OutboxUncheckedUpdateInput = {
+  id?: Prisma.StringFieldUpdateOperationsInput | string
+  topic?: Prisma.StringFieldUpdateOperationsInput | string
+  key?: Prisma.StringFieldUpdateOperationsInput | string
+  payload?: Prisma.JsonNullValueInput | runtime.InputJsonValue
+  attempts?: Prisma.IntFieldUpdateOperationsInput | number
+  lastError?: Prisma.NullableStringFieldUpdateOperationsInput | string | null
+  createdAt?: Prisma.DateTimeFieldUpdateOperationsInput | Date | string
+  sentAt?: Prisma.NullableDateTimeFieldUpdateOperationsInput | Date | string | null
+}
+
+export type OutboxCreateManyInput = {
+  id?: string
+  topic: string
+  key: string
+  payload: Prisma.JsonNullValueInput | runtime.InputJsonValue
+  attempts?: number
+  lastError?: string | null
+  createdAt?: Date | string
+  sentAt?: Date | string | null
+}
+
+export type OutboxUpdateManyMutationInput = {
+  id?: Prisma.StringFieldUpdateOperationsInput | string
+  topic?: Prisma.StringFieldUpdateOperationsInput | string
+  key?: Prisma.StringFieldUpdateOperationsInput | string
+  payload?: Prisma.JsonNullValueInput | runtime.InputJsonValue
+  attempts?: Prisma.IntFieldUpdateOperationsInput | number
+  lastError?: Prisma.NullableStringFieldUpdateOperationsInput | string | null
+  createdAt?: Prisma.DateTimeFieldUpdateOperationsInput | Date | string
+  sentAt?: Prisma.NullableDateTimeFieldUpdateOperationsInput | Date | string | null
+}
+
+export type OutboxUncheckedUpdateManyInput = {
+  id?: Prisma.StringFieldUpdateOperationsInput | string
+  topic?: Prisma.StringFieldUpdateOperationsInput | string
+  key?: Prisma.StringFieldUpdateOperationsInput | string
+  payload?: Prisma.JsonNullValueInput | runtime.InputJsonValue
+  attempts?: Prisma.IntFieldUpdateOperationsInput | number
+  lastError?: Prisma.NullableStringFieldUpdateOperationsInput | string | null
+  createdAt?: Prisma.DateTimeFieldUpdateOperationsInput | Date | string
+  sentAt?: Prisma.NullableDateTimeFieldUpdateOperationsInput | Date | string | null
+}
+
+export type OutboxCountOrderByAggregateInput = {
+  id?: Prisma.SortOrder
+  topic?: Prisma.SortOrder
+  key?: Prisma.SortOrder
+  payload?: Prisma.SortOrder
+  attempts?: Prisma.SortOrder
+  lastError?: Prisma.SortOrder
+  createdAt?: Prisma.SortOrder
+  sentAt?: Prisma.SortOrder
+}
+
+export type OutboxAvgOrderByAggregateInput = {
+  attempts?: Prisma.SortOrder
+}
+
+export type OutboxMaxOrderByAggregateInput = {
+  id?: Prisma.SortOrder
+  topic?: Prisma.SortOrder
+  key?: Prisma.SortOrder
+  attempts?: Prisma.SortOrder
+  lastError?: Prisma.SortOrder
+  createdAt?: Prisma.SortOrder
+  sentAt?: Prisma.SortOrder
+}
+
+export type OutboxMinOrderByAggregateInput = {
+  id?: Prisma.SortOrder
+  topic?: Prisma.SortOrder
+  key?: Prisma.SortOrder
+  attempts?: Prisma.SortOrder
+  lastError?: Prisma.SortOrder
+  createdAt?: Prisma.SortOrder
+  sentAt?: Prisma.SortOrder
+}
+
+export type OutboxSumOrderByAggregateInput = {
+  attempts?: Prisma.SortOrder
+}
+
+
+
+export type OutboxSelect<ExtArgs extends runtime.Types.Extensions.InternalArgs = runtime.Types.Extensions.DefaultArgs> = runtime.Types.Extensions.GetSelect<{
+  id?: boolean
+  topic?: boolean
+  key?: boolean
+  payload?: boolean
+  attempts?: boolean
+  lastError?: boolean
+  createdAt?: boolean
+  sentAt?: boolean
+}, ExtArgs["result"]["outbox"]>
+
+export type OutboxSelectCreateManyAndReturn<ExtArgs extends runtime.Types.Extensions.InternalArgs = runtime.Types.Extensions.DefaultArgs> = runtime.Types.Extensions.GetSelect<{
+  id?: boolean
+  topic?: boolean
+  key?: boolean
+  payload?: boolean
+  attempts?: boolean
+  lastError?: boolean
+  createdAt?: boolean
+  sentAt?: boolean
+}, ExtArgs["result"]["outbox"]>
+
+export type OutboxSelectUpdateManyAndReturn<ExtArgs extends runtime.Types.Extensions.InternalArgs = runtime.Types.Extensions.DefaultArgs> = runtime.Types.Extensions.GetSelect<{
+  id?: boolean
+  topic?: boolean
+  key?: boolean
+  payload?: boolean
+  attempts?: boolean
+  lastError?: boolean
+  createdAt?: boolean
+  sentAt?: boolean
+}, ExtArgs["result"]["outbox"]>
+
+export type OutboxSelectScalar = {
+  id?: boolean
+  topic?: boolean
+  key?: boolean
+  payload?: boolean
+  attempts?: boolean
+  lastError?: boolean
+  createdAt?: boolean
+  sentAt?: boolean
+}
+
+export type OutboxOmit<ExtArgs extends runtime.Types.Extensions.InternalArgs = runtime.Types.Extensions.DefaultArgs> = runtime.Types.Extensions.GetOmit<"id" | "topic" | "key" | "payload" | "attempts" | "lastError" | "createdAt" | "sentAt", ExtArgs["result"]["outbox"]>
+
+export type $OutboxPayload<ExtArgs extends runtime.Types.Extensions.InternalArgs = runtime.Types.Extensions.DefaultArgs> = {
+  name: "Outbox"
+  objects: {}
+  scalars: runtime.Types.Extensions.GetPayloadResult<{
+    id: string
+    topic: string
+    key: string
+    payload: runtime.JsonValue
+    attempts: number
+    lastError: string | null
+    createdAt: Date
+    sentAt: Date | null
+  }, ExtArgs["result"]["outbox"]>
+  composites: {}
+}
+
+export type OutboxGetPayload<S extends boolean | null | undefined | OutboxDefaultArgs> = runtime.Types.Result.GetResult<Prisma.$OutboxPayload, S>
+
+export type OutboxCountArgs<ExtArgs extends runtime.Types.Extensions.InternalArgs = runtime.Types.Extensions.DefaultArgs> =
+  Omit<OutboxFindManyArgs, 'select' | 'include' | 'distinct' | 'omit'> & {
+    select?: OutboxCountAggregateInputType | true
+  }
+
+export interface OutboxDelegate<ExtArgs extends runtime.Types.Extensions.InternalArgs = runtime.Types.Extensions.DefaultArgs, GlobalOmitOptions = {}> {
+  [K: symbol]: { types: Prisma.TypeMap<ExtArgs>['model']['Outbox'], meta: { name: 'Outbox' } }
+  /**
+   * Find zero or one Outbox that matches the filter.
+   * @param {OutboxFindUniqueArgs} args - Arguments to find a Outbox
+   * @example
+   * // Get one Outbox
+   * const outbox = await prisma.outbox.findUnique({
+   *   where: {
+   *     // ... provide filter here
+   *   }
+   * })
+   */
+  findUnique<T extends OutboxFindUniqueArgs>(args: Prisma.SelectSubset<T, OutboxFindUniqueArgs<ExtArgs>>): Prisma.Prisma__OutboxClient<runtime.Types.Result.GetResult<Prisma.$OutboxPayload<ExtArgs>, T, "findUnique", GlobalOmitOptions> | null, null, ExtArgs, GlobalOmitOptions>
+
+  /**
+   * Find one Outbox that matches the filter or throw an error with `error.code='P2025'`
+   * if no matches were found.
+   * @param {OutboxFindUniqueOrThrowArgs} args - Arguments to find a Outbox
+   * @example
+   * // Get one Outbox
+   * const outbox = await prisma.outbox.findUniqueOrThrow({
+   *   where: {
+   *     // ... provide filter here
+   *   }
+   * })
+   */
+  findUniqueOrThrow<T extends OutboxFindUniqueOrThrowArgs>(args: Prisma.SelectSubset<T, OutboxFindUniqueOrThrowArgs<ExtArgs>>): Prisma.Prisma__OutboxClient<runtime.Types.Result.GetResult<Prisma.$OutboxPayload<ExtArgs>, T, "findUniqueOrThrow", GlobalOmitOptions>, never, ExtArgs, GlobalOmitOptions>
+
+  /**
+   * Find the first Outbox that matches the filter.
+   * Note, that providing `undefined` is treated as the value not being there.
+   * Read more here: https://pris.ly/d/null-undefined
+   * @param {OutboxFindFirstArgs} args - Arguments to find a Outbox
+   * @example
+   * // Get one Outbox
+   * const outbox = await prisma.outbox.findFirst({
+   *   where: {
+   *     // ... provide filter here
+   *   }
+   * })
+   */
+  findFirst<T extends OutboxFindFirstArgs>(args?: Prisma.SelectSubset<T, OutboxFindFirstArgs<ExtArgs>>): Prisma.Prisma__OutboxClient<runtime.Types.Result.GetResult<Prisma.$OutboxPayload<ExtArgs>, T, "findFirst", GlobalOmitOptions> | null, null, ExtArgs, GlobalOmitOptions>
+
+  /**
+   * Find the first Outbox that matches the filter or
+   * throw `PrismaKnownClientError` with `P2025` code if no matches were found.
+   * Note, that providing `undefined` is treated as the value not being there.
+   * Read more here: https://pris.ly/d/null-undefined
+   * @param {OutboxFindFirstOrThrowArgs} args - Arguments to find a Outbox
+   * @example
+   * // Get one Outbox
+   * const outbox = await prisma.outbox.findFirstOrThrow({
+   *   where: {
+   *     // ... provide filter here
+   *   }
+   * })
+   */
+  findFirstOrThrow<T extends OutboxFindFirstOrThrowArgs>(args?: Prisma.SelectSubset<T, OutboxFindFirstOrThrowArgs<ExtArgs>>): Prisma.Prisma__OutboxClient<runtime.Types.Result.GetResult<Prisma.$OutboxPayload<ExtArgs>, T, "findFirstOrThrow", GlobalOmitOptions>, never, ExtArgs, GlobalOmitOptions>
+
+  /**
+   * Find zero or more Outboxes that matches the filter.
+   * Note, that providing `undefined` is treated as the value not being there.
+   * Read more here: https://pris.ly/d/null-undefined
+   * @param {OutboxFindManyArgs} args - Arguments to filter and select certain fields only.
+   * @example
+   * // Get all Outboxes
+   * const outboxes = await prisma.outbox.findMany()
+   * 
+   * // Get first 10 Outboxes
+   * const outboxes = await prisma.outbox.findMany({ take: 10 })
+   * 
+   * // Only select the `id`
+   * const outboxWithIdOnly = await prisma.outbox.findMany({ select: { id: true } })
+   * 
+   */
+  findMany<T extends OutboxFindManyArgs>(args?: Prisma.SelectSubset<T, OutboxFindManyArgs<ExtArgs>>): Prisma.PrismaPromise<runtime.Types.Result.GetResult<Prisma.$OutboxPayload<ExtArgs>, T, "findMany", GlobalOmitOptions>>
+
+  /**
+   * Create a Outbox.
+   * @param {OutboxCreateArgs} args - Arguments to create a Outbox.
+   * @example
+   * // Create one Outbox
+   * const Outbox = await prisma.outbox.create({
+   *   data: {
+   *     // ... data to create a Outbox
+   *   }
+   * })
+   * 
+   */
+  create<T extends OutboxCreateArgs>(args: Prisma.SelectSubset<T, OutboxCreateArgs<ExtArgs>>): Prisma.Prisma__OutboxClient<runtime.Types.Result.GetResult<Prisma.$OutboxPayload<ExtArgs>, T, "create", GlobalOmitOptions>, never, ExtArgs, GlobalOmitOptions>
+
+  /**
+   * Create many Outboxes.
+   * @param {OutboxCreateManyArgs} args - Arguments to create many Outboxes.
+   * @example
+   * // Create many Outboxes
+   * const outbox = await prisma.outbox.createMany({
+   *   data: [
+   *     // ... provide data here
+   *   ]
+   * })
+   *     
+   */
+  createMany<T extends OutboxCreateManyArgs>(args?: Prisma.SelectSubset<T, OutboxCreateManyArgs<ExtArgs>>): Prisma.PrismaPromise<Prisma.BatchPayload>
+
+  /**
+   * Create many Outboxes and returns the data saved in the database.
+   * @param {OutboxCreateManyAndReturnArgs} args - Arguments to create many Outboxes.
+   * @example
+   * // Create many Outboxes
+   * const outbox = await prisma.outbox.createManyAndReturn({
+   *   data: [
+   *     // ... provide data here
+   *   ]
+   * })
+   * 
+   * // Create many Outboxes and only return the `id`
+   * const outboxWithIdOnly = await prisma.outbox.createManyAndReturn({
+   *   select: { id: true },
+   *   data: [
+   *     // ... provide data here
+   *   ]
+   * })
+   * Note, that providing `undefined` is treated as the value not being there.
+   * Read more here: https://pris.ly/d/null-undefined
+   * 
+   */
+  createManyAndReturn<T extends OutboxCreateManyAndReturnArgs>(args?: Prisma.SelectSubset<T, OutboxCreateManyAndReturnArgs<ExtArgs>>): Prisma.PrismaPromise<runtime.Types.Result.GetResult<Prisma.$OutboxPayload<ExtArgs>, T, "createManyAndReturn", GlobalOmitOptions>>
+
+  /**
+   * Delete a Outbox.
+   * @param {OutboxDeleteArgs} args - Arguments to delete one Outbox.
+   * @example
+   * // Delete one Outbox
+   * const Outbox = await prisma.outbox.delete({
+   *   where: {
+   *     // ... filter to delete one Outbox
+   *   }
+   * })
+   * 
+   */
+  delete<T extends OutboxDeleteArgs>(args: Prisma.SelectSubset<T, OutboxDeleteArgs<ExtArgs>>): Prisma.Prisma__OutboxClient<runtime.Types.Result.GetResult<Prisma.$OutboxPayload<ExtArgs>, T, "delete", GlobalOmitOptions>, never, ExtArgs, GlobalOmitOptions>
+
+  /**
+   * Update one Outbox.
+   * @param {OutboxUpdateArgs} args - Arguments to update one Outbox.
+   * @example
+   * // Update one Outbox
+   * const outbox = await prisma.outbox.update({
+   *   where: {
+   *     // ... provide filter here
+   *   },
+   *   data: {
+   *     // ... provide data here
+   *   }
+   * })
+   * 
+   */
+  update<T extends OutboxUpdateArgs>(args: Prisma.SelectSubset<T, OutboxUpdateArgs<ExtArgs>>): Prisma.Prisma__OutboxClient<runtime.Types.Result.GetResult<Prisma.$OutboxPayload<ExtArgs>, T, "update", GlobalOmitOptions>, never, ExtArgs, GlobalOmitOptions>
+
+  /**
+   * Delete zero or more Outboxes.
+   * @param {OutboxDeleteManyArgs} args - Arguments to filter Outboxes to delete.
+   * @example
+   * // Delete a few Outboxes
+   * const { count } = await prisma.outbox.deleteMany({
+   *   where: {
+   *     // ... provide filter here
+   *   }
+   * })
+   * 
+   */
+  deleteMany<T extends OutboxDeleteManyArgs>(args?: Prisma.SelectSubset<T, OutboxDeleteManyArgs<ExtArgs>>): Prisma.PrismaPromise<Prisma.BatchPayload>
+
+  /**
+   * Update zero or more Outboxes.
+   * Note, that providing `undefined` is treated as the value not being there.
+   * Read more here: https://pris.ly/d/null-undefined
+   * @param {OutboxUpdateManyArgs} args - Arguments to update one or more rows.
+   * @example
+   * // Update many Outboxes
+   * const outbox = await prisma.outbox.updateMany({
+   *   where: {
+   *     // ... provide filter here
+   *   },
+   *   data: {
+   *     // ... provide data here
+   *   }
+   * })
+   * 
+   */
+  updateMany<T extends OutboxUpdateManyArgs>(args: Prisma.SelectSubset<T, OutboxUpdateManyArgs<ExtArgs>>): Prisma.PrismaPromise<Prisma.BatchPayload>
+
+  /**
+   * Update zero or more Outboxes and returns the data updated in the database.
+   * @param {OutboxUpdateManyAndReturnArgs} args - Arguments to update many Outboxes.
+   * @example
+   * // Update many Outboxes
+   * const outbox = await prisma.outbox.updateManyAndReturn({
+   *   where: {
+   *     // ... provide filter here
+   *   },
+   *   data: [
+   *     // ... provide data here
+   *   ]
+   * })
+   * 
+   * // Update zero or more Outboxes and only return the `id`
+   * const outboxWithIdOnly = await prisma.outbox.updateManyAndReturn({
+   *   select: { id: true },
+   *   where: {
+   *     // ... provide filter here
+   *   },
+   *   data: [
+   *     // ... provide data here
+   *   ]
+   * })
+   * Note, that providing `undefined` is treated as the value not being there.
+   * Read more here: https://pris.ly/d/null-undefined
+   * 
+   */
+  updateManyAndReturn<T extends OutboxUpdateManyAndReturnArgs>(args: Prisma.SelectSubset<T, OutboxUpdateManyAndReturnArgs<ExtArgs>>): Prisma.PrismaPromise<runtime.Types.Result.GetResult<Prisma.$OutboxPayload<ExtArgs>, T, "updateManyAndReturn", GlobalOmitOptions>>
+
+  /**
+   * Create or update one Outbox.
+   * @param {OutboxUpsertArgs} args - Arguments to update or create a Outbox.
+   * @example
+   * // Update or create a Outbox
+   * const outbox = await prisma.outbox.upsert({
+   *   create: {
+   *     // ... data to create a Outbox
+   *   },
+   *   update: {
+   *     // ... in case it already exists, update
+   *   },
+   *   where: {
+   *     // ... the filter for the Outbox we want to update
+   *   }
+   * })
+   */
+  upsert<T extends OutboxUpsertArgs>(args: Prisma.SelectSubset<T, OutboxUpsertArgs<ExtArgs>>): Prisma.Prisma__OutboxClient<runtime.Types.Result.GetResult<Prisma.$OutboxPayload<ExtArgs>, T, "upsert", GlobalOmitOptions>, never, ExtArgs, GlobalOmitOptions>
+
+
+  /**
+   * Count the number of Outboxes.
+   * Note, that providing `undefined` is treated as the value not being there.
+   * Read more here: https://pris.ly/d/null-undefined
+   * @param {OutboxCountArgs} args - Arguments to filter Outboxes to count.
+   * @example
+   * // Count the number of Outboxes
+   * const count = await prisma.outbox.count({
+   *   where: {
+   *     // ... the filter for the Outboxes we want to count
+   *   }
+   * })
+  **/
+  count<T extends OutboxCountArgs>(
+    args?: Prisma.Subset<T, OutboxCountArgs>,
+  ): Prisma.PrismaPromise<
+    T extends runtime.Types.Utils.Record<'select', any>
+      ? T['select'] extends true
+        ? number
+        : Prisma.GetScalarType<T['select'], OutboxCountAggregateOutputType>
+      : number
+  >
+
+  /**
+   * Allows you to perform aggregations operations on a Outbox.
+   * Note, that providing `undefined` is treated as the value not being there.
+   * Read more here: https://pris.ly/d/null-undefined
+   * @param {OutboxAggregateArgs} args - Select which aggregations you would like to apply and on what fields.
+   * @example
+   * // Ordered by age ascending
+   * // Where email contains prisma.io
+   * // Limited to the 10 users
+   * const aggregations = await prisma.user.aggregate({
+   *   _avg: {
+   *     age: true,
+   *   },
+   *   where: {
+   *     email: {
+   *       contains: "prisma.io",
+   *     },
+   *   },
+   *   orderBy: {
+   *     age: "asc",
+   *   },
+   *   take: 10,
+   * })
+  **/
+  aggregate<T extends OutboxAggregateArgs>(args: Prisma.Subset<T, OutboxAggregateArgs>): Prisma.PrismaPromise<GetOutboxAggregateType<T>>
+
+  /**
+   * Group by Outbox.
+   * Note, that providing `undefined` is treated as the value not being there.
+   * Read more here: https://pris.ly/d/null-undefined
+   * @param {OutboxGroupByArgs} args - Group by arguments.
+   * @example
+   * // Group by city, order by createdAt, get count
+   * const result = await prisma.user.groupBy({
+   *   by: ['city', 'createdAt'],
+   *   orderBy: {
+   *     createdAt: true
+   *   },
+   *   _count: {
+   *     _all: true
+   *   },
+   * })
+   * 
+  **/
+  groupBy<
+    T extends OutboxGroupByArgs,
+    HasSelectOrTake extends Prisma.Or<
+      Prisma.Extends<'skip', Prisma.Keys<T>>,
+      Prisma.Extends<'take', Prisma.Keys<T>>
+    >,
+    OrderByArg extends Prisma.True extends HasSelectOrTake
+      ? { orderBy: OutboxGroupByArgs['orderBy'] }
+      : { orderBy?: OutboxGroupByArgs['orderBy'] },
+    OrderFields extends Prisma.ExcludeUnderscoreKeys<Prisma.Keys<Prisma.MaybeTupleToUnion<T['orderBy']>>>,
+    ByFields extends Prisma.MaybeTupleToUnion<T['by']>,
+    ByValid extends Prisma.Has<ByFields, OrderFields>,
+    HavingFields extends Prisma.GetHavingFields<T['having']>,
+    HavingValid extends Prisma.Has<ByFields, HavingFields>,
+    ByEmpty extends T['by'] extends never[] ? Prisma.True : Prisma.False,
+    InputErrors extends ByEmpty extends Prisma.True
+    ? `Error: "by" must not be empty.`
+    : HavingValid extends Prisma.False
+    ? {
+        [P in HavingFields]: P extends ByFields
+          ? never
+          : P extends string
+          ? `Error: Field "${P}" used in "having" needs to be provided in "by".`
+          : [
+              Error,
+              'Field ',
+              P,
+              ` in "having" needs to be provided in "by"`,
+            ]
+      }[HavingFields]
+    : 'take' extends Prisma.Keys<T>
+    ? 'orderBy' extends Prisma.Keys<T>
+      ? ByValid extends Prisma.True
+        ? {}
+        : {
+            [P in OrderFields]: P extends ByFields
+              ? never
+              : `Error: Field "${P}" in "orderBy" needs to be provided in "by"`
+          }[OrderFields]
+      : 'Error: If you provide "take", you also need to provide "orderBy"'
+    : 'skip' extends Prisma.Keys<T>
+    ? 'orderBy' extends Prisma.Keys<T>
+      ? ByValid extends Prisma.True
+        ? {}
+        : {
+            [P in OrderFields]: P extends ByFields
+              ? never
+              : `Error: Field "${P}" in "orderBy" needs to be provided in "by"`
+          }[OrderFields]
+      : 'Error: If you provide "skip", you also need to provide "orderBy"'
+    : ByValid extends Prisma.True
+    ? {}
+    : {
+        [P in OrderFields]: P extends ByFields
+          ? never
+          : `Error: Field "${P}" in "orderBy" needs to be provided in "by"`
+      }[OrderFields]
+  >(args: Prisma.SubsetIntersection<T, OutboxGroupByArgs, OrderByArg> & InputErrors): {} extends InputErrors ? GetOutboxGroupByPayload<T> : Prisma.PrismaPromise<InputErrors>
+/**
+ * Fields of the Outbox model
+ */
+readonly fields: OutboxFieldRefs;
+}
+
+/**
+ * The delegate class that acts as a "Promise-like" for Outbox.
+ * Why is this prefixed with `Prisma__`?
+ * Because we want to prevent naming conflicts as mentioned in
+ * https://github.com/prisma/prisma-client-js/issues/707
+ */
+export interface Prisma__OutboxClient<T, Null = never, ExtArgs extends runtime.Types.Extensions.InternalArgs = runtime.Types.Extensions.DefaultArgs, GlobalOmitOptions = {}> extends Prisma.PrismaPromise<T> {
+  readonly [Symbol.toStringTag]: "PrismaPromise"
+  /**
+   * Attaches callbacks for the resolution and/or rejection of the Promise.
+   * @param onfulfilled The callback to execute when the Promise is resolved.
+   * @param onrejected The callback to execute when the Promise is rejected.
+   * @returns A Promise for the completion of which ever callback is executed.
+   */
+  then<TResult1 = T, TResult2 = never>(onfulfilled?: ((value: T) => TResult1 | PromiseLike<TResult1>) | undefined | null, onrejected?: ((reason: any) => TResult2 | PromiseLike<TResult2>) | undefined | null): runtime.Types.Utils.JsPromise<TResult1 | TResult2>
+  /**
+   * Attaches a callback for only the rejection of the Promise.
+   * @param onrejected The callback to execute when the Promise is rejected.
+   * @returns A Promise for the completion of the callback.
+   */
+  catch<TResult = never>(onrejected?: ((reason: any) => TResult | PromiseLike<TResult>) | undefined | null): runtime.Types.Utils.JsPromise<T | TResult>
+  /**
+   * Attaches a callback that is invoked when the Promise is settled (fulfilled or rejected). The
+   * resolved value cannot be modified from the callback.
+   * @param onfinally The callback to execute when the Promise is settled (fulfilled or rejected).
+   * @returns A Promise for the completion of the callback.
+   */
+  finally(onfinally?: (() => void) | undefined | null): runtime.Types.Utils.JsPromise<T>
+}
+
+
+
+
+/**
+ * Fields of the Outbox model
+ */
+export interface OutboxFieldRefs {
+  readonly id: Prisma.FieldRef<"Outbox", 'String'>
+  readonly topic: Prisma.FieldRef<"Outbox", 'String'>
+  readonly key: Prisma.FieldRef<"Outbox", 'String'>
+  readonly payload: Prisma.FieldRef<"Outbox", 'Json'>
+  readonly attempts: Prisma.FieldRef<"Outbox", 'Int'>
+  readonly lastError: Prisma.FieldRef<"Outbox", 'String'>
+  readonly createdAt: Prisma.FieldRef<"Outbox", 'DateTime'>
+  readonly sentAt: Prisma.FieldRef<"Outbox", 'DateTime'>
+}
+    
+
+// Custom InputTypes
+/**
+ * Outbox findUnique
+ */
+export type OutboxFindUniqueArgs<ExtArgs extends runtime.Types.Extensions.InternalArgs = runtime.Types.Extensions.DefaultArgs> = {
+  /**
+   * Select specific fields to fetch from the Outbox
+   */
+  select?: Prisma.OutboxSelect<ExtArgs> | null
+  /**
+   * Omit specific fields from the Outbox
+   */
+  omit?: Prisma.OutboxOmit<ExtArgs> | null
+  /**
+   * Filter, which Outbox to fetch.
+   */
+  where: Prisma.OutboxWhereUniqueInput
+}
+
+/**
+ * Outbox findUniqueOrThrow
+ */
+export type OutboxFindUniqueOrThrowArgs<ExtArgs extends runtime.Types.Extensions.InternalArgs = runtime.Types.Extensions.DefaultArgs> = {
+  /**
+   * Select specific fields to fetch from the Outbox
+   */
+  select?: Prisma.OutboxSelect<ExtArgs> | null
+  /**
+   * Omit specific fields from the Outbox
+   */
+  omit?: Prisma.OutboxOmit<ExtArgs> | null
+  /**
+   * Filter, which Outbox to fetch.
+   */
+  where: Prisma.OutboxWhereUniqueInput
+}
+
+/**
+ * Outbox findFirst
+ */
+export type OutboxFindFirstArgs<ExtArgs extends runtime.Types.Extensions.InternalArgs = runtime.Types.Extensions.DefaultArgs> = {
+  /**
+   * Select specific fields to fetch from the Outbox
+   */
+  select?: Prisma.OutboxSelect<ExtArgs> | null
+  /**
+   * Omit specific fields from the Outbox
+   */
+  omit?: Prisma.OutboxOmit<ExtArgs> | null
+  /**
+   * Filter, which Outbox to fetch.
+   */
+  where?: Prisma.OutboxWhereInput
+  /**
+   * {@link https://www.prisma.io/docs/concepts/components/prisma-client/sorting Sorting Docs}
+   * 
+   * Determine the order of Outboxes to fetch.
+   */
+  orderBy?: Prisma.OutboxOrderByWithRelationInput | Prisma.OutboxOrderByWithRelationInput[]
+  /**
+   * {@link https://www.prisma.io/docs/concepts/components/prisma-client/pagination#cursor-based-pagination Cursor Docs}
+   * 
+   * Sets the position for searching for Outboxes.
+   */
+  cursor?: Prisma.OutboxWhereUniqueInput
+  /**
+   * {@link https://www.prisma.io/docs/concepts/components/prisma-client/pagination Pagination Docs}
+   * 
+   * Take `±n` Outboxes from the position of the cursor.
+   */
+  take?: number
+  /**
+   * {@link https://www.prisma.io/docs/concepts/components/prisma-client/pagination Pagination Docs}
+   * 
+   * Skip the first `n` Outboxes.
+   */
+  skip?: number
+  /**
+   * {@link https://www.prisma.io/docs/concepts/components/prisma-client/distinct Distinct Docs}
+   * 
+   * Filter by unique combinations of Outboxes.
+   */
+  distinct?: Prisma.OutboxScalarFieldEnum | Prisma.OutboxScalarFieldEnum[]
+}
+
+/**
+ * Outbox findFirstOrThrow
+ */
+export type OutboxFindFirstOrThrowArgs<ExtArgs extends runtime.Types.Extensions.InternalArgs = runtime.Types.Extensions.DefaultArgs> = {
+  /**
+   * Select specific fields to fetch from the Outbox
+   */
+  select?: Prisma.OutboxSelect<ExtArgs> | null
+  /**
+   * Omit specific fields from the Outbox
+   */
+  omit?: Prisma.OutboxOmit<ExtArgs> | null
+  /**
+   * Filter, which Outbox to fetch.
+   */
+  where?: Prisma.OutboxWhereInput
+  /**
+   * {@link https://www.prisma.io/docs/concepts/components/prisma-client/sorting Sorting Docs}
+   * 
+   * Determine the order of Outboxes to fetch.
+   */
+  orderBy?: Prisma.OutboxOrderByWithRelationInput | Prisma.OutboxOrderByWithRelationInput[]
+  /**
+   * {@link https://www.prisma.io/docs/concepts/components/prisma-client/pagination#cursor-based-pagination Cursor Docs}
+   * 
+   * Sets the position for searching for Outboxes.
+   */
+  cursor?: Prisma.OutboxWhereUniqueInput
+  /**
+   * {@link https://www.prisma.io/docs/concepts/components/prisma-client/pagination Pagination Docs}
+   * 
+   * Take `±n` Outboxes from the position of the cursor.
+   */
+  take?: number
+  /**
+   * {@link https://www.prisma.io/docs/concepts/components/prisma-client/pagination Pagination Docs}
+   * 
+   * Skip the first `n` Outboxes.
+   */
+  skip?: number
+  /**
+   * {@link https://www.prisma.io/docs/concepts/components/prisma-client/distinct Distinct Docs}
+   * 
+   * Filter by unique combinations of Outboxes.
+   */
+  distinct?: Prisma.OutboxScalarFieldEnum | Prisma.OutboxScalarFieldEnum[]
+}
+
+/**
+ * Outbox findMany
+ */
+export type OutboxFindManyArgs<ExtArgs extends runtime.Types.Extensions.InternalArgs = runtime.Types.Extensions.DefaultArgs> = {
+  /**
+   * Select specific fields to fetch from the Outbox
+   */
+  select?: Prisma.OutboxSelect<ExtArgs> | null
+  /**
+   * Omit specific fields from the Outbox
+   */
+  omit?: Prisma.OutboxOmit<ExtArgs> | null
+  /**
+   * Filter, which Outboxes to fetch.
+   */
+  where?: Prisma.OutboxWhereInput
+  /**
+   * {@link https://www.prisma.io/docs/concepts/components/prisma-client/sorting Sorting Docs}
+   * 
+   * Determine the order of Outboxes to fetch.
+   */
+  orderBy?: Prisma.OutboxOrderByWithRelationInput | Prisma.OutboxOrderByWithRelationInput[]
+  /**
+   * {@link https://www.prisma.io/docs/concepts/components/prisma-client/pagination#cursor-based-pagination Cursor Docs}
+   * 
+   * Sets the position for listing Outboxes.
+   */
+  cursor?: Prisma.OutboxWhereUniqueInput
+  /**
+   * {@link https://www.prisma.io/docs/concepts/components/prisma-client/pagination Pagination Docs}
+   * 
+   * Take `±n` Outboxes from the position of the cursor.
+   */
+  take?: number
+  /**
+   * {@link https://www.prisma.io/docs/concepts/components/prisma-client/pagination Pagination Docs}
+   * 
+   * Skip the first `n` Outboxes.
+   */
+  skip?: number
+  distinct?: Prisma.OutboxScalarFieldEnum | Prisma.OutboxScalarFieldEnum[]
+}
+
+/**
+ * Outbox create
+ */
+export type OutboxCreateArgs<ExtArgs extends runtime.Types.Extensions.InternalArgs = runtime.Types.Extensions.DefaultArgs> = {
+  /**
+   * Select specific fields to fetch from the Outbox
+   */
+  select?: Prisma.OutboxSelect<ExtArgs> | null
+  /**
+   * Omit specific fields from the Outbox
+   */
+  omit?: Prisma.OutboxOmit<ExtArgs> | null
+  /**
+   * The data needed to create a Outbox.
+   */
+  data: Prisma.XOR<Prisma.OutboxCreateInput, Prisma.OutboxUncheckedCreateInput>
+}
+
+/**
+ * Outbox createMany
+ */
+export type OutboxCreateManyArgs<ExtArgs extends runtime.Types.Extensions.InternalArgs = runtime.Types.Extensions.DefaultArgs> = {
+  /**
+   * The data used to create many Outboxes.
+   */
+  data: Prisma.OutboxCreateManyInput | Prisma.OutboxCreateManyInput[]
+  skipDuplicates?: boolean
+}
+
+/**
+ * Outbox createManyAndReturn
+ */
+export type OutboxCreateManyAndReturnArgs<ExtArgs extends runtime.Types.Extensions.InternalArgs = runtime.Types.Extensions.DefaultArgs> = {
+  /**
+   * Select specific fields to fetch from the Outbox
+   */
+  select?: Prisma.OutboxSelectCreateManyAndReturn<ExtArgs> | null
+  /**
+   * Omit specific fields from the Outbox
+   */
+  omit?: Prisma.OutboxOmit<ExtArgs> | null
+  /**
+   * The data used to create many Outboxes.
+   */
+  data: Prisma.OutboxCreateManyInput | Prisma.OutboxCreateManyInput[]
+  skipDuplicates?: boolean
+}
+
+/**
+ * Outbox update
+ */
+export type OutboxUpdateArgs<ExtArgs extends runtime.Types.Extensions.InternalArgs = runtime.Types.Extensions.DefaultArgs> = {
+  /**
+   * Select specific fields to fetch from the Outbox
+   */
+  select?: Prisma.OutboxSelect<ExtArgs> | null
+  /**
+   * Omit specific fields from the Outbox
+   */
+  omit?: Prisma.OutboxOmit<ExtArgs> | null
+  /**
+   * The data needed to update a Outbox.
+   */
+  data: Prisma.XOR<Prisma.OutboxUpdateInput, Prisma.OutboxUncheckedUpdateInput>
+  /**
+   * Choose, which Outbox to update.
+   */
+  where: Prisma.OutboxWhereUniqueInput
+}
+
+/**
+ * Outbox updateMany
+ */
+export type OutboxUpdateManyArgs<ExtArgs extends runtime.Types.Extensions.InternalArgs = runtime.Types.Extensions.DefaultArgs> = {
+  /**
+   * The data used to update Outboxes.
+   */
+  data: Prisma.XOR<Prisma.OutboxUpdateManyMutationInput, Prisma.OutboxUncheckedUpdateManyInput>
+  /**
+   * Filter which Outboxes to update
+   */
+  where?: Prisma.OutboxWhereInput
+  /**
+   * Limit how many Outboxes to update.
+   */
+  limit?: number
+}
+
+/**
+ * Outbox updateManyAndReturn
+ */
+export type OutboxUpdateManyAndReturnArgs<ExtArgs extends runtime.Types.Extensions.InternalArgs = runtime.Types.Extensions.DefaultArgs> = {
+  /**
+   * Select specific fields to fetch from the Outbox
+   */
+  select?: Prisma.OutboxSelectUpdateManyAndReturn<ExtArgs> | null
+  /**
+   * Omit specific fields from the Outbox
+   */
+  omit?: Prisma.OutboxOmit<ExtArgs> | null
+  /**
+   * The data used to update Outboxes.
+   */
+  data: Prisma.XOR<Prisma.OutboxUpdateManyMutationInput, Prisma.OutboxUncheckedUpdateManyInput>
+  /**
+   * Filter which Outboxes to update
+   */
+  where?: Prisma.OutboxWhereInput
+  /**
+   * Limit how many Outboxes to update.
+   */
+  limit?: number
+}
+
+/**
+ * Outbox upsert
+ */
+export type OutboxUpsertArgs<ExtArgs extends runtime.Types.Extensions.InternalArgs = runtime.Types.Extensions.DefaultArgs> = {
+  /**
+   * Select specific fields to fetch from the Outbox
+   */
+  select?: Prisma.OutboxSelect<ExtArgs> | null
+  /**
+   * Omit specific fields from the Outbox
+   */
+  omit?: Prisma.OutboxOmit<ExtArgs> | null
+  /**
+   * The filter to search for the Outbox to update in case it exists.
+   */
+  where: Prisma.OutboxWhereUniqueInput
+  /**
+   * In case the Outbox found by the `where` argument doesn't exist, create a new Outbox with this data.
+   */
+  create: Prisma.XOR<Prisma.OutboxCreateInput, Prisma.OutboxUncheckedCreateInput>
+  /**
+   * In case the Outbox was found with the provided `where` argument, update it with this data.
+   */
+  update: Prisma.XOR<Prisma.OutboxUpdateInput, Prisma.OutboxUncheckedUpdateInput>
+}
+
+/**
+ * Outbox delete
+ */
+export type OutboxDeleteArgs<ExtArgs extends runtime.Types.Extensions.InternalArgs = runtime.Types.Extensions.DefaultArgs> = {
+  /**
+   * Select specific fields to fetch from the Outbox
+   */
+  select?: Prisma.OutboxSelect<ExtArgs> | null
+  /**
+   * Omit specific fields from the Outbox
+   */
+  omit?: Prisma.OutboxOmit<ExtArgs> | null
+  /**
+   * Filter which Outbox to delete.
+   */
+  where: Prisma.OutboxWhereUniqueInput
+}
+
+/**
+ * Outbox deleteMany
+ */
+export type OutboxDeleteManyArgs<ExtArgs extends runtime.Types.Extensions.InternalArgs = runtime.Types.Extensions.DefaultArgs> = {
+  /**
+   * Filter which Outboxes to delete
+   */
+  where?: Prisma.OutboxWhereInput
+  /**
+   * Limit how many Outboxes to delete.
+   */
+  limit?: number
+}
+
+/**
+ * Outbox without action
+ */
+export type OutboxDefaultArgs<ExtArgs extends runtime.Types.Extensions.InternalArgs = runtime.Types.Extensions.DefaultArgs> = {
+  /**
+   * Select specific fields to fetch from the Outbox
+   */
+  select?: Prisma.OutboxSelect<ExtArgs> | null
+  /**
+   * Omit specific fields from the Outbox
+   */
+  omit?: Prisma.OutboxOmit<ExtArgs> | null
+}
@@ -0,0 +1,1272 @@
+
+/* !!! This is code generated by Prisma. Do not edit directly. !!! */
+/* eslint-disable */
+// biome-ignore-all lint: generated file
+// @ts-nocheck 
+/*
+ * This file exports the `StreamHandoff` model and its related types.
+ *
+ * 🟢 You can import this file directly.
+ */
+import type * as runtime from "@prisma/client/runtime/client"
+import type * as $Enums from "../enums.js"
+import type * as Prisma from "../internal/prismaNamespace.js"
+
+/**
+ * Model StreamHandoff
+ * 
+ */
+export type StreamHandoffModel = runtime.Types.Result.DefaultSelection<Prisma.$StreamHandoffPayload>
+
+export type AggregateStreamHandoff = {
+  _count: StreamHandoffCountAggregateOutputType | null
+  _avg: StreamHandoffAvgAggregateOutputType | null
+  _sum: StreamHandoffSumAggregateOutputType | null
+  _min: StreamHandoffMinAggregateOutputType | null
+  _max: StreamHandoffMaxAggregateOutputType | null
+}
+
+export type StreamHandoffAvgAggregateOutputType = {
+  resumeSeq: number | null
+}
+
+export type StreamHandoffSumAggregateOutputType = {
+  resumeSeq: number | null
+}
+
+export type StreamHandoffMinAggregateOutputType = {
+  id: string | null
+  streamId: string | null
+  fromWorker: string | null
+  toWorker: string | null
+  status: string | null
+  resumeSeq: number | null
+  createdAt: Date | null
+  updatedAt: Date | null
+}
+
+export type StreamHandoffMaxAggregateOutputType = {
+  id: string | null
+  streamId: string | null
+  fromWorker: string | null
+  toWorker: string | null
+  status: string | null
+  resumeSeq: number | null
+  createdAt: Date | null
+  updatedAt: Date | null
+}
+
+export type StreamHandoffCountAggregateOutputType = {
+  id: number
+  streamId: number
+  fromWorker: number
+  toWorker: number
+  status: number
+  resumeSeq: number
+  createdAt: number
+  updatedAt: number
+  _all: number
+}
+
+
+export type StreamHandoffAvgAggregateInputType = {
+  resumeSeq?: true
+}
+
+export type StreamHandoffSumAggregateInputType = {
+  resumeSeq?: true
+}
+
+export type StreamHandoffMinAggregateInputType = {
+  id?: true
+  streamId?: true
+  fromWorker?: true
+  toWorker?: true
+  status?: true
+  resumeSeq?: true
+  createdAt?: true
+  updatedAt?: true
+}
+
+export type StreamHandoffMaxAggregateInputType = {
+  id?: true
+  streamId?: true
+  fromWorker?: true
+  toWorker?: true
+  status?: true
+  resumeSeq?: true
+  createdAt?: true
+  updatedAt?: true
+}
+
+export type StreamHandoffCountAggregateInputType = {
+  id?: true
+  streamId?: true
+  fromWorker?: true
+  toWorker?: true
+  status?: true
+  resumeSeq?: true
+  createdAt?: true
+  updatedAt?: true
+  _all?: true
+}
+
+export type StreamHandoffAggregateArgs<ExtArgs extends runtime.Types.Extensions.InternalArgs = runtime.Types.Extensions.DefaultArgs> = {
+  /**
+   * Filter which StreamHandoff to aggregate.
+   */
+  where?: Prisma.StreamHandoffWhereInput
+  /**
+   * {@link https://www.prisma.io/docs/concepts/components/prisma-client/sorting Sorting Docs}
+   * 
+   * Determine the order of StreamHandoffs to fetch.
+   */
+  orderBy?: Prisma.StreamHandoffOrderByWithRelationInput | Prisma.StreamHandoffOrderByWithRelationInput[]
+  /**
+   * {@link https://www.prisma.io/docs/concepts/components/prisma-client/pagination#cursor-based-pagination Cursor Docs}
+   * 
+   * Sets the start position
+   */
+  cursor?: Prisma.StreamHandoffWhereUniqueInput
+  /**
+   * {@link https://www.prisma.io/docs/concepts/components/prisma-client/pagination Pagination Docs}
+   * 
+   * Take `±n` StreamHandoffs from the position of the cursor.
+   */
+  take?: number
+  /**
+   * {@link https://www.prisma.io/docs/concepts/components/prisma-client/pagination Pagination Docs}
+   * 
+   * Skip the first `n` StreamHandoffs.
+   */
+  skip?: number
+  /**
+   * {@link https://www.prisma.io/docs/concepts/components/prisma-client/aggregations Aggregation Docs}
+   * 
+   * Count returned StreamHandoffs
+  **/
+  _count?: true | StreamHandoffCountAggregateInputType
+  /**
+   * {@link https://www.prisma.io/docs/concepts/components/prisma-client/aggregations Aggregation Docs}
+   * 
+   * Select which fields to average
+  **/
+  _avg?: StreamHandoffAvgAggregateInputType
+  /**
+   * {@link https://www.prisma.io/docs/concepts/components/prisma-client/aggregations Aggregation Docs}
+   * 
+   * Select which fields to sum
+  **/
+  _sum?: StreamHandoffSumAggregateInputType
+  /**
+   * {@link https://www.prisma.io/docs/concepts/components/prisma-client/aggregations Aggregation Docs}
+   * 
+   * Select which fields to find the minimum value
+  **/
+  _min?: StreamHandoffMinAggregateInputType
+  /**
+   * {@link https://www.prisma.io/docs/concepts/components/prisma-client/aggregations Aggregation Docs}
+   * 
+   * Select which fields to find the maximum value
+  **/
+  _max?: StreamHandoffMaxAggregateInputType
+}
+
+export type GetStreamHandoffAggregateType<T extends StreamHandoffAggregateArgs> = {
+      [P in keyof T & keyof AggregateStreamHandoff]: P extends '_count' | 'count'
+    ? T[P] extends true
+      ? number
+      : Prisma.GetScalarType<T[P], AggregateStreamHandoff[P]>
+    : Prisma.GetScalarType<T[P], AggregateStreamHandoff[P]>
+}
+
+
+
+
+export type StreamHandoffGroupByArgs<ExtArgs extends runtime.Types.Extensions.InternalArgs = runtime.Types.Extensions.DefaultArgs> = {
+  where?: Prisma.StreamHandoffWhereInput
+  orderBy?: Prisma.StreamHandoffOrderByWithAggregationInput | Prisma.StreamHandoffOrderByWithAggregationInput[]
+  by: Prisma.StreamHandoffScalarFieldEnum[] | Prisma.StreamHandoffScalarFieldEnum
+  having?: Prisma.StreamHandoffScalarWhereWithAggregatesInput
+  take?: number
+  skip?: number
+  _count?: StreamHandoffCountAggregateInputType | true
+  _avg?: StreamHandoffAvgAggregateInputType
+  _sum?: StreamHandoffSumAggregateInputType
+  _min?: StreamHandoffMinAggregateInputType
+  _max?: StreamHandoffMaxAggregateInputType
+}
+
+export type StreamHandoffGroupByOutputType = {
+  id: string
+  streamId: string
+  fromWorker: string
+  toWorker: string | null
+  status: string
+  resumeSeq: number | null
+  createdAt: Date
+  updatedAt: Date
+  _count: StreamHandoffCountAggregateOutputType | null
+  _avg: StreamHandoffAvgAggregateOutputType | null
+  _sum: StreamHandoffSumAggregateOutputType | null
+  _min: StreamHandoffMinAggregateOutputType | null
+  _max: StreamHandoffMaxAggregateOutputType | null
+}
+
+type GetStreamHandoffGroupByPayload<T extends StreamHandoffGroupByArgs> = Prisma.PrismaPromise<
+  Array<
+    Prisma.PickEnumerable<StreamHandoffGroupByOutputType, T['by']> &
+      {
+        [P in ((keyof T) & (keyof StreamHandoffGroupByOutputType))]: P extends '_count'
+          ? T[P] extends boolean
+            ? number
+            : Prisma.GetScalarType<T[P], StreamHandoffGroupByOutputType[P]>
+          : Prisma.GetScalarType<T[P], StreamHandoffGroupByOutputType[P]>
+      }
+    >
+  >
+
+
+
+export type StreamHandoffWhereInput = {
+  AND?: Prisma.StreamHandoffWhereInput | Prisma.StreamHandoffWhereInput[]
+  OR?: Prisma.StreamHandoffWhereInput[]
+  NOT?: Prisma.StreamHandoffWhereInput | Prisma.StreamHandoffWhereInput[]
+  id?: Prisma.StringFilter<"StreamHandoff"> | string
+  streamId?: Prisma.StringFilter<"StreamHandoff"> | string
+  fromWorker?: Prisma.StringFilter<"StreamHandoff"> | string
+  toWorker?: Prisma.StringNullableFilter<"StreamHandoff"> | string | null
+  status?: Prisma.StringFilter<"StreamHandoff"> | string
+  resumeSeq?: Prisma.IntNullableFilter<"StreamHandoff"> | number | null
+  createdAt?: Prisma.DateTimeFilter<"StreamHandoff"> | Date | string
+  updatedAt?: Prisma.DateTimeFilter<"StreamHandoff"> | Date | string
+}
+
+export type StreamHandoffOrderByWithRelationInput = {
+  id?: Prisma.SortOrder
+  streamId?: Prisma.SortOrder
+  fromWorker?: Prisma.SortOrder
+  toWorker?: Prisma.SortOrderInput | Prisma.SortOrder
+  status?: Prisma.SortOrder
+  resumeSeq?: Prisma.SortOrderInput | Prisma.SortOrder
+  createdAt?: Prisma.SortOrder
+  updatedAt?: Prisma.SortOrder
+}
+
+export type StreamHandoffWhereUniqueInput = Prisma.AtLeast<{
+  id?: string
+  AND?: Prisma.StreamHandoffWhereInput | Prisma.StreamHandoffWhereInput[]
+  OR?: Prisma.StreamHandoffWhereInput[]
+  NOT?: Prisma.StreamHandoffWhereInput | Prisma.StreamHandoffWhereInput[]
+  streamId?: Prisma.StringFilter<"StreamHandoff"> | string
+  fromWorker?: Prisma.StringFilter<"StreamHandoff"> | string
+  toWorker?: Prisma.StringNullableFilter<"StreamHandoff"> | string | null
+  status?: Prisma.StringFilter<"StreamHandoff"> | string
+  resumeSeq?: Prisma.IntNullableFilter<"StreamHandoff"> | number | null
+  createdAt?: Prisma.DateTimeFilter<"StreamHandoff"> | Date | string
+  updatedAt?: Prisma.DateTimeFilter<"StreamHandoff"> | Date | string
+}, "id">
+
+export type StreamHandoffOrderByWithAggregationInput = {
+  id?: Prisma.SortOrder
+  streamId?: Prisma.SortOrder
+  fromWorker?: Prisma.SortOrder
+  toWorker?: Prisma.SortOrderInput | Prisma.SortOrder
+  status?: Prisma.SortOrder
+  resumeSeq?: Prisma.SortOrderInput | Prisma.SortOrder
+  createdAt?: Prisma.SortOrder
+  updatedAt?: Prisma.SortOrder
+  _count?: Prisma.StreamHandoffCountOrderByAggregateInput
+  _avg?: Prisma.StreamHandoffAvgOrderByAggregateInput
+  _max?: Prisma.StreamHandoffMaxOrderByAggregateInput
+  _min?: Prisma.StreamHandoffMinOrderByAggregateInput
+  _sum?: Prisma.StreamHandoffSumOrderByAggregateInput
+}
+
+export type StreamHandoffScalarWhereWithAggregatesInput = {
+  AND?: Prisma.StreamHandoffScalarWhereWithAggregatesInput | Prisma.StreamHandoffScalarWhereWithAggregatesInput[]
+  OR?: Prisma.StreamHandoffScalarWhereWithAggregatesInput[]
+  NOT?: Prisma.StreamHandoffScalarWhereWithAggregatesInput | Prisma.StreamHandoffScalarWhereWithAggregatesInput[]
+  id?: Prisma.StringWithAggregatesFilter<"StreamHandoff"> | string
+  streamId?: Prisma.StringWithAggregatesFilter<"StreamHandoff"> | string
+  fromWorker?: Prisma.StringWithAggregatesFilter<"StreamHandoff"> | string
+  toWorker?: Prisma.StringNullableWithAggregatesFilter<"StreamHandoff"> | string | null
+  status?: Prisma.StringWithAggregatesFilter<"StreamHandoff"> | string
+  resumeSeq?: Prisma.IntNullableWithAggregatesFilter<"StreamHandoff"> | number | null
+  createdAt?: Prisma.DateTimeWithAggregatesFilter<"StreamHandoff"> | Date | string
+  updatedAt?: Prisma.DateTimeWithAggregatesFilter<"StreamHandoff"> | Date | string
+}
+
+export type StreamHandoffCreateInput = {
+  id: string
+  streamId: string
+  fromWorker: string
+  toWorker?: string | null
+  status?: string
+  resumeSeq?: number | null
+  createdAt?: Date | string
+  updatedAt?: Date | string
+}
+
+export type StreamHandoffUncheckedCreateInput = {
+  id: string
+  streamId: string
+  fromWorker: string
+  toWorker?: string | null
+  status?: string
+  resumeSeq?: number | null
+  createdAt?: Date | string
+  updatedAt?: Date | string
+}
+
+export type StreamHandoffUpdateInput = {
+  id?: Prisma.StringFieldUpdateOperationsInput | string
+  streamId?: Prisma.StringFieldUpdateOperationsInput | string
+  fromWorker?: Prisma.StringFieldUpdateOperationsInput | string
+  toWorker?: Prisma.NullableStringFieldUpdateOperationsInput | string | null
+  status?: Prisma.StringFieldUpdateOperationsInput | string
+  resumeSeq?: Prisma.NullableIntFieldUpdateOperationsInput | number | null
+  createdAt?: Prisma.DateTimeFieldUpdateOperationsInput | Date | string
+  updatedAt?: Prisma.DateTimeFieldUpdateOperationsInput | Date | string
+}
+
+export type StreamHandoffUncheckedUpdateInput = {
+  id?: Prisma.StringFieldUpdateOperationsInput | string
+  streamId?: Prisma.StringFieldUpdateOperationsInput | string
+  fromWorker?: Prisma.StringFieldUpdateOperationsInput | string
+  toWorker?: Prisma.NullableStringFieldUpdateOperationsInput | string | null
+  status?: Prisma.StringFieldUpdateOperationsInput | string
+  resumeSeq?: Prisma.NullableIntFieldUpdateOperationsInput | number | null
+  createdAt?: Prisma.DateTimeFieldUpdateOperationsInput | Date | string
+  updatedAt?: Prisma.DateTimeFieldUpdateOperationsInput | Date | string
+}
+
+export type StreamHandoffCreateManyInput = {
+  id: string
+  streamId: string
+  fromWorker: string
+  toWorker?: string | null
+  status?: string
+  resumeSeq?: number | null
+  createdAt?: Date | string
+  updatedAt?: Date | string
+}
+
+export type StreamHandoffUpdateManyMutationInput = {
+  id?: Prisma.StringFieldUpdateOperationsInput | string
+  streamId?: Prisma.StringFieldUpdateOperationsInput | string
+  fromWorker?: Prisma.StringFieldUpdateOperationsInput | string
+  toWorker?: Prisma.NullableStringFieldUpdateOperationsInput | string | null
+  status?: Prisma.StringFieldUpdateOperationsInput | string
+  resumeSeq?: Prisma.NullableIntFieldUpdateOperationsInput | number | null
+  createdAt?: Prisma.DateTimeFieldUpdateOperationsInput | Date | string
+  updatedAt?: Prisma.DateTimeFieldUpdateOperationsInput | Date | string
+}
+
+export type StreamHandoffUncheckedUpdateManyInput = {
+  id?: Prisma.StringFieldUpdateOperationsInput | string
+  streamId?: Prisma.StringFieldUpdateOperationsInput | string
+  fromWorker?: Prisma.StringFieldUpdateOperationsInput | string
+  toWorker?: Prisma.NullableStringFieldUpdateOperationsInput | string | null
+  status?: Prisma.StringFieldUpdateOperationsInput | string
+  resumeSeq?: Prisma.NullableIntFieldUpdateOperationsInput | number | null
+  createdAt?: Prisma.DateTimeFieldUpdateOperationsInput | Date | string
+  updatedAt?: Prisma.DateTimeFieldUpdateOperationsInput | Date | string
+}
+
+export type StreamHandoffCountOrderByAggregateInput = {
+  id?: Prisma.SortOrder
+  streamId?: Prisma.SortOrder
+  fromWorker?: Prisma.SortOrder
+  toWorker?: Prisma.SortOrder
+  status?: Prisma.SortOrder
+  resumeSeq?: Prisma.SortOrder
+  createdAt?: Prisma.SortOrder
+  updatedAt?: Prisma.SortOrder
+}
+
+export type StreamHandoffAvgOrderByAggregateInput = {
+  resumeSeq?: Prisma.SortOrder
+}
+
+export type StreamHandoffMaxOrderByAggregateInput = {
+  id?: Prisma.SortOrder
+  streamId?: Prisma.SortOrder
+  fromWorker?: Prisma.SortOrder
+  toWorker?: Prisma.SortOrder
+  status?: Prisma.SortOrder
+  resumeSeq?: Prisma.SortOrder
+  createdAt?: Prisma.SortOrder
+  updatedAt?: Prisma.SortOrder
+}
+
+export type StreamHandoffMinOrderByAggregateInput = {
+  id?: Prisma.SortOrder
+  streamId?: Prisma.SortOrder
+  fromWorker?: Prisma.SortOrder
+  toWorker?: Prisma.SortOrder
+  status?: Prisma.SortOrder
+  resumeSeq?: Prisma.SortOrder
+  createdAt?: Prisma.SortOrder
+  updatedAt?: Prisma.SortOrder
+}
+
+export type StreamHandoffSumOrderByAggregateInput = {
+  resumeSeq?: Prisma.SortOrder
+}
+
+
+
+export type StreamHandoffSelect<ExtArgs extends runtime.Types.Extensions.InternalArgs = runtime.Types.Extensions.DefaultArgs> = runtime.Types.Extensions.GetSelect<{
+  id?: boolean
+  streamId?: boolean
+  fromWorker?: boolean
+  toWorker?: boolean
+  status?: boolean
+  resumeSeq?: boolean
+  createdAt?: boolean
+  updatedAt?: boolean
+}, ExtArgs["result"]["streamHandoff"]>
+
+export type StreamHandoffSelectCreateManyAndReturn<ExtArgs extends runtime.Types.Extensions.InternalArgs = runtime.Types.Extensions.DefaultArgs> = runtime.Types.Extensions.GetSelect<{
+  id?: boolean
+  streamId?: boolean
+  fromWorker?: boolean
+  toWorker?: boolean
+  status?: boolean
+  resumeSeq?: boolean
+  createdAt?: boolean
+  updatedAt?: boolean
+}, ExtArgs["result"]["streamHandoff"]>
+
+export type StreamHandoffSelectUpdateManyAndReturn<ExtArgs extends runtime.Types.Extensions.InternalArgs = runtime.Types.Extensions.DefaultArgs> = runtime.Types.Extensions.GetSelect<{
+  id?: boolean
+  streamId?: boolean
+  fromWorker?: boolean
+  toWorker?: boolean
+  status?: boolean
+  resumeSeq?: boolean
+  createdAt?: boolean
+  updatedAt?: boolean
+}, ExtArgs["result"]["streamHandoff"]>
+
+export type StreamHandoffSelectScalar = {
+  id?: boolean
+  streamId?: boolean
+  fromWorker?: boolean
+  toWorker?: boolean
+  status?: boolean
+  resumeSeq?: boolean
+  createdAt?: boolean
+  updatedAt?: boolean
+}
+
+export type StreamHandoffOmit<ExtArgs extends runtime.Types.Extensions.InternalArgs = runtime.Types.Extensions.DefaultArgs> = runtime.Types.Extensions.GetOmit<"id" | "streamId" | "fromWorker" | "toWorker" | "status" | "resumeSeq" | "createdAt" | "updatedAt", ExtArgs["result"]["streamHandoff"]>
+
+export type $StreamHandoffPayload<ExtArgs extends runtime.Types.Extensions.InternalArgs = runtime.Types.Extensions.DefaultArgs> = {
+  name: "StreamHandoff"
+  objects: {}
+  scalars: runtime.Types.Extensions.GetPayloadResult<{
+    id: string
+    streamId: string
+    fromWorker: string
+    toWorker: string | null
+    status: string
+    resumeSeq: number | null
+    createdAt: Date
+    updatedAt: Date
+  }, ExtArgs["result"]["streamHandoff"]>
+  composites: {}
+}
+
+export type StreamHandoffGetPayload<S extends boolean | null | undefined | StreamHandoffDefaultArgs> = runtime.Types.Result.GetResult<Prisma.$StreamHandoffPayload, S>
+
+export type StreamHandoffCountArgs<ExtArgs extends runtime.Types.Extensions.InternalArgs = runtime.Types.Extensions.DefaultArgs> =
+  Omit<StreamHandoffFindManyArgs, 'select' | 'include' | 'distinct' | 'omit'> & {
+    select?: StreamHandoffCountAggregateInputType | true
+  }
+
+export interface StreamHandoffDelegate<ExtArgs extends runtime.Types.Extensions.InternalArgs = runtime.Types.Extensions.DefaultArgs, GlobalOmitOptions = {}> {
+  [K: symbol]: { types: Prisma.TypeMap<ExtArgs>['model']['StreamHandoff'], meta: { name: 'StreamHandoff' } }
+  /**
+   * Find zero or one StreamHandoff that matches the filter.
+   * @param {StreamHandoffFindUniqueArgs} args - Arguments to find a StreamHandoff
+   * @example
+   * // Get one StreamHandoff
+   * const streamHandoff = await prisma.streamHandoff.findUnique({
+   *   where: {
+   *     // ... provide filter here
+   *   }
+   * })
+   */
+  findUnique<T extends StreamHandoffFindUniqueArgs>(args: Prisma.SelectSubset<T, StreamHandoffFindUniqueArgs<ExtArgs>>): Prisma.Prisma__StreamHandoffClient<runtime.Types.Result.GetResult<Prisma.$StreamHandoffPayload<ExtArgs>, T, "findUnique", GlobalOmitOptions> | null, null, ExtArgs, GlobalOmitOptions>
+
+  /**
+   * Find one StreamHandoff that matches the filter or throw an error with `error.code='P2025'`
+   * if no matches were found.
+   * @param {StreamHandoffFindUniqueOrThrowArgs} args - Arguments to find a StreamHandoff
+   * @example
+   * // Get one StreamHandoff
+   * const streamHandoff = await prisma.streamHandoff.findUniqueOrThrow({
+   *   where: {
+   *     // ... provide filter here
+   *   }
+   * })
+   */
+  findUniqueOrThrow<T extends StreamHandoffFindUniqueOrThrowArgs>(args: Prisma.SelectSubset<T, StreamHandoffFindUniqueOrThrowArgs<ExtArgs>>): Prisma.Prisma__StreamHandoffClient<runtime.Types.Result.GetResult<Prisma.$StreamHandoffPayload<ExtArgs>, T, "findUniqueOrThrow", GlobalOmitOptions>, never, ExtArgs, GlobalOmitOptions>
+
+  /**
+   * Find the first StreamHandoff that matches the filter.
+   * Note, that providing `undefined` is treated as the value not being there.
+   * Read more here: https://pris.ly/d/null-undefined
+   * @param {StreamHandoffFindFirstArgs} args - Arguments to find a StreamHandoff
+   * @example
+   * // Get one StreamHandoff
+   * const streamHandoff = await prisma.streamHandoff.findFirst({
+   *   where: {
+   *     // ... provide filter here
+   *   }
+   * })
+   */
+  findFirst<T extends StreamHandoffFindFirstArgs>(args?: Prisma.SelectSubset<T, StreamHandoffFindFirstArgs<ExtArgs>>): Prisma.Prisma__StreamHandoffClient<runtime.Types.Result.GetResult<Prisma.$StreamHandoffPayload<ExtArgs>, T, "findFirst", GlobalOmitOptions> | null, null, ExtArgs, GlobalOmitOptions>
+
+  /**
+   * Find the first StreamHandoff that matches the filter or
+   * throw `PrismaKnownClientError` with `P2025` code if no matches were found.
+   * Note, that providing `undefined` is treated as the value not being there.
+   * Read more here: https://pris.ly/d/null-undefined
+   * @param {StreamHandoffFindFirstOrThrowArgs} args - Arguments to find a StreamHandoff
+   * @example
+   * // Get one StreamHandoff
+   * const streamHandoff = await prisma.streamHandoff.findFirstOrThrow({
+   *   where: {
+   *     // ... provide filter here
+   *   }
+   * })
+   */
+  findFirstOrThrow<T extends StreamHandoffFindFirstOrThrowArgs>(args?: Prisma.SelectSubset<T, StreamHandoffFindFirstOrThrowArgs<ExtArgs>>): Prisma.Prisma__StreamHandoffClient<runtime.Types.Result.GetResult<Prisma.$StreamHandoffPayload<ExtArgs>, T, "findFirstOrThrow", GlobalOmitOptions>, never, ExtArgs, GlobalOmitOptions>
+
+  /**
+   * Find zero or more StreamHandoffs that matches the filter.
+   * Note, that providing `undefined` is treated as the value not being there.
+   * Read more here: https://pris.ly/d/null-undefined
+   * @param {StreamHandoffFindManyArgs} args - Arguments to filter and select certain fields only.
+   * @example
+   * // Get all StreamHandoffs
+   * const streamHandoffs = await prisma.streamHandoff.findMany()
+   * 
+   * // Get first 10 StreamHandoffs
+   * const streamHandoffs = await prisma.streamHandoff.findMany({ take: 10 })
+   * 
+   * // Only select the `id`
+   * const streamHandoffWithIdOnly = await prisma.streamHandoff.findMany({ select: { id: true } })
+   * 
+   */
+  findMany<T extends StreamHandoffFindManyArgs>(args?: Prisma.SelectSubset<T, StreamHandoffFindManyArgs<ExtArgs>>): Prisma.PrismaPromise<runtime.Types.Result.GetResult<Prisma.$StreamHandoffPayload<ExtArgs>, T, "findMany", GlobalOmitOptions>>
+
+  /**
+   * Create a StreamHandoff.
+   * @param {StreamHandoffCreateArgs} args - Arguments to create a StreamHandoff.
+   * @example
+   * // Create one StreamHandoff
+   * const StreamHandoff = await prisma.streamHandoff.create({
+   *   data: {
+   *     // ... data to create a StreamHandoff
+   *   }
+   * })
+   * 
+   */
+  create<T extends StreamHandoffCreateArgs>(args: Prisma.SelectSubset<T, StreamHandoffCreateArgs<ExtArgs>>): Prisma.Prisma__StreamHandoffClient<runtime.Types.Result.GetResult<Prisma.$StreamHandoffPayload<ExtArgs>, T, "create", GlobalOmitOptions>, never, ExtArgs, GlobalOmitOptions>
+
+  /**
+   * Create many StreamHandoffs.
+   * @param {StreamHandoffCreateManyArgs} args - Arguments to create many StreamHandoffs.
+   * @example
+   * // Create many StreamHandoffs
+   * const streamHandoff = await prisma.streamHandoff.createMany({
+   *   data: [
+   *     // ... provide data here
+   *   ]
+   * })
+   *     
+   */
+  createMany<T extends StreamHandoffCreateManyArgs>(args?: Prisma.SelectSubset<T, StreamHandoffCreateManyArgs<ExtArgs>>): Prisma.PrismaPromise<Prisma.BatchPayload>
+
+  /**
+   * Create many StreamHandoffs and returns the data saved in the database.
+   * @param {StreamHandoffCreateManyAndReturnArgs} args - Arguments to create many StreamHandoffs.
+   * @example
+   * // Create many StreamHandoffs
+   * const streamHandoff = await prisma.streamHandoff.createManyAndReturn({
+   *   data: [
+   *     // ... provide data here
+   *   ]
+   * })
+   * 
+   * // Create many StreamHandoffs and only return the `id`
+   * const streamHandoffWithIdOnly = await prisma.streamHandoff.createManyAndReturn({
+   *   select: { id: true },
+   *   data: [
+   *     // ... provide data here
+   *   ]
+   * })
+   * Note, that providing `undefined` is treated as the value not being there.
+   * Read more here: https://pris.ly/d/null-undefined
+   * 
+   */
+  createManyAndReturn<T extends StreamHandoffCreateManyAndReturnArgs>(args?: Prisma.SelectSubset<T, StreamHandoffCreateManyAndReturnArgs<ExtArgs>>): Prisma.PrismaPromise<runtime.Types.Result.GetResult<Prisma.$StreamHandoffPayload<ExtArgs>, T, "createManyAndReturn", GlobalOmitOptions>>
+
+  /**
+   * Delete a StreamHandoff.
+   * @param {StreamHandoffDeleteArgs} args - Arguments to delete one StreamHandoff.
+   * @example
+   * // Delete one StreamHandoff
+   * const StreamHandoff = await prisma.streamHandoff.delete({
+   *   where: {
+   *     // ... filter to delete one StreamHandoff
+   *   }
+   * })
+   * 
+   */
+  delete<T extends StreamHandoffDeleteArgs>(args: Prisma.SelectSubset<T, StreamHandoffDeleteArgs<ExtArgs>>): Prisma.Prisma__StreamHandoffClient<runtime.Types.Result.GetResult<Prisma.$StreamHandoffPayload<ExtArgs>, T, "delete", GlobalOmitOptions>, never, ExtArgs, GlobalOmitOptions>
+
+  /**
+   * Update one StreamHandoff.
+   * @param {StreamHandoffUpdateArgs} args - Arguments to update one StreamHandoff.
+   * @example
+   * // Update one StreamHandoff
+   * const streamHandoff = await prisma.streamHandoff.update({
+   *   where: {
+   *     // ... provide filter here
+   *   },
+   *   data: {
+   *     // ... provide data here
+   *   }
+   * })
+   * 
+   */
+  update<T extends StreamHandoffUpdateArgs>(args: Prisma.SelectSubset<T, StreamHandoffUpdateArgs<ExtArgs>>): Prisma.Prisma__StreamHandoffClient<runtime.Types.Result.GetResult<Prisma.$StreamHandoffPayload<ExtArgs>, T, "update", GlobalOmitOptions>, never, ExtArgs, GlobalOmitOptions>
+
+  /**
+   * Delete zero or more StreamHandoffs.
+   * @param {StreamHandoffDeleteManyArgs} args - Arguments to filter StreamHandoffs to delete.
+   * @example
+   * // Delete a few StreamHandoffs
+   * const { count } = await prisma.streamHandoff.deleteMany({
+   *   where: {
+   *     // ... provide filter here
+   *   }
+   * })
+   * 
+   */
+  deleteMany<T extends StreamHandoffDeleteManyArgs>(args?: Prisma.SelectSubset<T, StreamHandoffDeleteManyArgs<ExtArgs>>): Prisma.PrismaPromise<Prisma.BatchPayload>
+
+  /**
+   * Update zero or more StreamHandoffs.
+   * Note, that providing `undefined` is treated as the value not being there.
+   * Read more here: https://pris.ly/d/null-undefined
+   * @param {StreamHandoffUpdateManyArgs} args - Arguments to update one or more rows.
+   * @example
+   * // Update many StreamHandoffs
+   * const streamHandoff = await prisma.streamHandoff.updateMany({
+   *   where: {
+   *     // ... provide filter here
+   *   },
+   *   data: {
+   *     // ... provide data here
+   *   }
+   * })
+   * 
+   */
+  updateMany<T extends StreamHandoffUpdateManyArgs>(args: Prisma.SelectSubset<T, StreamHandoffUpdateManyArgs<ExtArgs>>): Prisma.PrismaPromise<Prisma.BatchPayload>
+
+  /**
+   * Update zero or more StreamHandoffs and returns the data updated in the database.
+   * @param {StreamHandoffUpdateManyAndReturnArgs} args - Arguments to update many StreamHandoffs.
+   * @example
+   * // Update many StreamHandoffs
+   * const streamHandoff = await prisma.streamHandoff.updateManyAndReturn({
+   *   where: {
+   *     // ... provide filter here
+   *   },
+   *   data: [
+   *     // ... provide data here
+   *   ]
+   * })
+   * 
+   * // Update zero or more StreamHandoffs and only return the `id`
+   * const streamHandoffWithIdOnly = await prisma.streamHandoff.updateManyAndReturn({
+   *   select: { id: true },
+   *   where: {
+   *     // ... provide filter here
+   *   },
+   *   data: [
+   *     // ... provide data here
+   *   ]
+   * })
+   * Note, that providing `undefined` is treated as the value not being there.
+   * Read more here: https://pris.ly/d/null-undefined
+   * 
+   */
+  updateManyAndReturn<T extends StreamHandoffUpdateManyAndReturnArgs>(args: Prisma.SelectSubset<T, StreamHandoffUpdateManyAndReturnArgs<ExtArgs>>): Prisma.PrismaPromise<runtime.Types.Result.GetResult<Prisma.$StreamHandoffPayload<ExtArgs>, T, "updateManyAndReturn", GlobalOmitOptions>>
+
+  /**
+   * Create or update one StreamHandoff.
+   * @param {StreamHandoffUpsertArgs} args - Arguments to update or create a StreamHandoff.
+   * @example
+   * // Update or create a StreamHandoff
+   * const streamHandoff = await prisma.streamHandoff.upsert({
+   *   create: {
+   *     // ... data to create a StreamHandoff
+   *   },
+   *   update: {
+   *     // ... in case it already exists, update
+   *   },
+   *   where: {
+   *     // ... the filter for the StreamHandoff we want to update
+   *   }
+   * })
+   */
+  upsert<T extends StreamHandoffUpsertArgs>(args: Prisma.SelectSubset<T, StreamHandoffUpsertArgs<ExtArgs>>): Prisma.Prisma__StreamHandoffClient<runtime.Types.Result.GetResult<Prisma.$StreamHandoffPayload<ExtArgs>, T, "upsert", GlobalOmitOptions>, never, ExtArgs, GlobalOmitOptions>
+
+
+  /**
+   * Count the number of StreamHandoffs.
+   * Note, that providing `undefined` is treated as the value not being there.
+   * Read more here: https://pris.ly/d/null-undefined
+   * @param {StreamHandoffCountArgs} args - Arguments to filter StreamHandoffs to count.
+   * @example
+   * // Count the number of StreamHandoffs
+   * const count = await prisma.streamHandoff.count({
+   *   where: {
+   *     // ... the filter for the StreamHandoffs we want to count
+   *   }
+   * })
+  **/
+  count<T extends StreamHandoffCountArgs>(
+    args?: Prisma.Subset<T, StreamHandoffCountArgs>,
+  ): Prisma.PrismaPromise<
+    T extends runtime.Types.Utils.Record<'select', any>
+      ? T['select'] extends true
+        ? number
+        : Prisma.GetScalarType<T['select'], StreamHandoffCountAggregateOutputType>
+      : number
+  >
+
+  /**
+   * Allows you to perform aggregations operations on a StreamHandoff.
+   * Note, that providing `undefined` is treated as the value not being there.
+   * Read more here: https://pris.ly/d/null-undefined
+   * @param {StreamHandoffAggregateArgs} args - Select which aggregations you would like to apply and on what fields.
+   * @example
+   * // Ordered by age ascending
+   * // Where email contains prisma.io
+   * // Limited to the 10 users
+   * const aggregations = await prisma.user.aggregate({
+   *   _avg: {
+   *     age: true,
+   *   },
+   *   where: {
+   *     email: {
+   *       contains: "prisma.io",
+   *     },
+   *   },
+   *   orderBy: {
+   *     age: "asc",
+   *   },
+   *   take: 10,
+   * })
+  **/
+  aggregate<T extends StreamHandoffAggregateArgs>(args: Prisma.Subset<T, StreamHandoffAggregateArgs>): Prisma.PrismaPromise<GetStreamHandoffAggregateType<T>>
+
+  /**
+   * Group by StreamHandoff.
+   * Note, that providing `undefined` is treated as the value not being there.
+   * Read more here: https://pris.ly/d/null-undefined
+   * @param {StreamHandoffGroupByArgs} args - Group by arguments.
+   * @example
+   * // Group by city, order by createdAt, get count
+   * const result = await prisma.user.groupBy({
+   *   by: ['city', 'createdAt'],
+   *   orderBy: {
+   *     createdAt: true
+   *   },
+   *   _count: {
+   *     _all: true
+   *   },
+   * })
+   * 
+  **/
+  groupBy<
+    T extends StreamHandoffGroupByArgs,
+    HasSelectOrTake extends Prisma.Or<
+      Prisma.Extends<'skip', Prisma.Keys<T>>,
+      Prisma.Extends<'take', Prisma.Keys<T>>
+    >,
+    OrderByArg extends Prisma.True extends HasSelectOrTake
+      ? { orderBy: StreamHandoffGroupByArgs['orderBy'] }
+      : { orderBy?: StreamHandoffGroupByArgs['orderBy'] },
+    OrderFields extends Prisma.ExcludeUnderscoreKeys<Prisma.Keys<Prisma.MaybeTupleToUnion<T['orderBy']>>>,
+    ByFields extends Prisma.MaybeTupleToUnion<T['by']>,
+    ByValid extends Prisma.Has<ByFields, OrderFields>,
+    HavingFields extends Prisma.GetHavingFields<T['having']>,
+    HavingValid extends Prisma.Has<ByFields, HavingFields>,
+    ByEmpty extends T['by'] extends never[] ? Prisma.True : Prisma.False,
+    InputErrors extends ByEmpty extends Prisma.True
+    ? `Error: "by" must not be empty.`
+    : HavingValid extends Prisma.False
+    ? {
+        [P in HavingFields]: P extends ByFields
+          ? never
+          : P extends string
+          ? `Error: Field "${P}" used in "having" needs to be provided in "by".`
+          : [
+              Error,
+              'Field ',
+              P,
+              ` in "having" needs to be provided in "by"`,
+            ]
+      }[HavingFields]
+    : 'take' extends Prisma.Keys<T>
+    ? 'orderBy' extends Prisma.Keys<T>
+      ? ByValid extends Prisma.True
+        ? {}
+        : {
+            [P in OrderFields]: P extends ByFields
+              ? never
+              : `Error: Field "${P}" in "orderBy" needs to be provided in "by"`
+          }[OrderFields]
+      : 'Error: If you provide "take", you also need to provide "orderBy"'
+    : 'skip' extends Prisma.Keys<T>
+    ? 'orderBy' extends Prisma.Keys<T>
+      ? ByValid extends Prisma.True
+        ? {}
+        : {
+            [P in OrderFields]: P extends ByFields
+              ? never
+              : `Error: Field "${P}" in "orderBy" needs to be provided in "by"`
+          }[OrderFields]
+      : 'Error: If you provide "skip", you also need to provide "orderBy"'
+    : ByValid extends Prisma.True
+    ? {}
+    : {
+        [P in OrderFields]: P extends ByFields
+          ? never
+          : `Error: Field "${P}" in "orderBy" needs to be provided in "by"`
+      }[OrderFields]
+  >(args: Prisma.SubsetIntersection<T, StreamHandoffGroupByArgs, OrderByArg> & InputErrors): {} extends InputErrors ? GetStreamHandoffGroupByPayload<T> : Prisma.PrismaPromise<InputErrors>
+/**
+ * Fields of the StreamHandoff model
+ */
+readonly fields: StreamHandoffFieldRefs;
+}
+
+/**
+ * The delegate class that acts as a "Promise-like" for StreamHandoff.
+ * Why is this prefixed with `Prisma__`?
+ * Because we want to prevent naming conflicts as mentioned in
+ * https://github.com/prisma/prisma-client-js/issues/707
+ */
+export interface Prisma__StreamHandoffClient<T, Null = never, ExtArgs extends runtime.Types.Extensions.InternalArgs = runtime.Types.Extensions.DefaultArgs, GlobalOmitOptions = {}> extends Prisma.PrismaPromise<T> {
+  readonly [Symbol.toStringTag]: "PrismaPromise"
+  /**
+   * Attaches callbacks for the resolution and/or rejection of the Promise.
+   * @param onfulfilled The callback to execute when the Promise is resolved.
+   * @param onrejected The callback to execute when the Promise is rejected.
+   * @returns A Promise for the completion of which ever callback is executed.
+   */
+  then<TResult1 = T, TResult2 = never>(onfulfilled?: ((value: T) => TResult1 | PromiseLike<TResult1>) | undefined | null, onrejected?: ((reason: any) => TResult2 | PromiseLike<TResult2>) | undefined | null): runtime.Types.Utils.JsPromise<TResult1 | TResult2>
+  /**
+   * Attaches a callback for only the rejection of the Promise.
+   * @param onrejected The callback to execute when the Promise is rejected.
+   * @returns A Promise for the completion of the callback.
+   */
+  catch<TResult = never>(onrejected?: ((reason: any) => TResult | PromiseLike<TResult>) | undefined | null): runtime.Types.Utils.JsPromise<T | TResult>
+  /**
+   * Attaches a callback that is invoked when the Promise is settled (fulfilled or rejected). The
+   * resolved value cannot be modified from the callback.
+   * @param onfinally The callback to execute when the Promise is settled (fulfilled or rejected).
+   * @returns A Promise for the completion of the callback.
+   */
+  finally(onfinally?: (() => void) | undefined | null): runtime.Types.Utils.JsPromise<T>
+}
+
+
+
+
+/**
+ * Fields of the StreamHandoff model
+ */
+export interface StreamHandoffFieldRefs {
+  readonly id: Prisma.FieldRef<"StreamHandoff", 'String'>
+  readonly streamId: Prisma.FieldRef<"StreamHandoff", 'String'>
+  readonly fromWorker: Prisma.FieldRef<"StreamHandoff", 'String'>
+  readonly toWorker: Prisma.FieldRef<"StreamHandoff", 'String'>
+  readonly status: Prisma.FieldRef<"StreamHandoff", 'String'>
+  readonly resumeSeq: Prisma.FieldRef<"StreamHandoff", 'Int'>
+  readonly createdAt: Prisma.FieldRef<"StreamHandoff", 'DateTime'>
+  readonly updatedAt: Prisma.FieldRef<"StreamHandoff", 'DateTime'>
+}
+    
+
+// Custom InputTypes
+/**
+ * StreamHandoff findUnique
+ */
+export type StreamHandoffFindUniqueArgs<ExtArgs extends runtime.Types.Extensions.InternalArgs = runtime.Types.Extensions.DefaultArgs> = {
+  /**
+   * Select specific fields to fetch from the StreamHandoff
+   */
+  select?: Prisma.StreamHandoffSelect<ExtArgs> | null
+  /**
+   * Omit specific fields from the StreamHandoff
+   */
+  omit?: Prisma.StreamHandoffOmit<ExtArgs> | null
+  /**
+   * Filter, which StreamHandoff to fetch.
+   */
+  where: Prisma.StreamHandoffWhereUniqueInput
+}
+
+/**
+ * StreamHandoff findUniqueOrThrow
+ */
+export type StreamHandoffFindUniqueOrThrowArgs<ExtArgs extends runtime.Types.Extensions.InternalArgs = runtime.Types.Extensions.DefaultArgs> = {
+  /**
+   * Select specific fields to fetch from the StreamHandoff
+   */
+  select?: Prisma.StreamHandoffSelect<ExtArgs> | null
+  /**
+   * Omit specific fields from the StreamHandoff
+   */
+  omit?: Prisma.StreamHandoffOmit<ExtArgs> | null
+  /**
+   * Filter, which StreamHandoff to fetch.
+   */
+  where: Prisma.StreamHandoffWhereUniqueInput
+}
+
+/**
+ * StreamHandoff findFirst
+ */
+export type StreamHandoffFindFirstArgs<ExtArgs extends runtime.Types.Extensions.InternalArgs = runtime.Types.Extensions.DefaultArgs> = {
+  /**
+   * Select specific fields to fetch from the StreamHandoff
+   */
+  select?: Prisma.StreamHandoffSelect<ExtArgs> | null
+  /**
+   * Omit specific fields from the StreamHandoff
+   */
+  omit?: Prisma.StreamHandoffOmit<ExtArgs> | null
+  /**
+   * Filter, which StreamHandoff to fetch.
+   */
+  where?: Prisma.StreamHandoffWhereInput
+  /**
+   * {@link https://www.prisma.io/docs/concepts/components/prisma-client/sorting Sorting Docs}
+   * 
+   * Determine the order of StreamHandoffs to fetch.
+   */
+  orderBy?: Prisma.StreamHandoffOrderByWithRelationInput | Prisma.StreamHandoffOrderByWithRelationInput[]
+  /**
+   * {@link https://www.prisma.io/docs/concepts/components/prisma-client/pagination#cursor-based-pagination Cursor Docs}
+   * 
+   * Sets the position for searching for StreamHandoffs.
+   */
+  cursor?: Prisma.StreamHandoffWhereUniqueInput
+  /**
+   * {@link https://www.prisma.io/docs/concepts/components/prisma-client/pagination Pagination Docs}
+   * 
+   * Take `±n` StreamHandoffs from the position of the cursor.
+   */
+  take?: number
+  /**
+   * {@link https://www.prisma.io/docs/concepts/components/prisma-client/pagination Pagination Docs}
+   * 
+   * Skip the first `n` StreamHandoffs.
+   */
+  skip?: number
+  /**
+   * {@link https://www.prisma.io/docs/concepts/components/prisma-client/distinct Distinct Docs}
+   * 
+   * Filter by unique combinations of StreamHandoffs.
+   */
+  distinct?: Prisma.StreamHandoffScalarFieldEnum | Prisma.StreamHandoffScalarFieldEnum[]
+}
+
+/**
+ * StreamHandoff findFirstOrThrow
+ */
+export type StreamHandoffFindFirstOrThrowArgs<ExtArgs extends runtime.Types.Extensions.InternalArgs = runtime.Types.Extensions.DefaultArgs> = {
+  /**
+   * Select specific fields to fetch from the StreamHandoff
+   */
+  select?: Prisma.StreamHandoffSelect<ExtArgs> | null
+  /**
+   * Omit specific fields from the StreamHandoff
+   */
+  omit?: Prisma.StreamHandoffOmit<ExtArgs> | null
+  /**
+   * Filter, which StreamHandoff to fetch.
+   */
+  where?: Prisma.StreamHandoffWhereInput
+  /**
+   * {@link https://www.prisma.io/docs/concepts/components/prisma-client/sorting Sorting Docs}
+   * 
+   * Determine the order of StreamHandoffs to fetch.
+   */
+  orderBy?: Prisma.StreamHandoffOrderByWithRelationInput | Prisma.StreamHandoffOrderByWithRelationInput[]
+  /**
+   * {@link https://www.prisma.io/docs/concepts/components/prisma-client/pagination#cursor-based-pagination Cursor Docs}
+   * 
+   * Sets the position for searching for StreamHandoffs.
+   */
+  cursor?: Prisma.StreamHandoffWhereUniqueInput
+  /**
+   * {@link https://www.prisma.io/docs/concepts/components/prisma-client/pagination Pagination Docs}
+   * 
+   * Take `±n` StreamHandoffs from the position of the cursor.
+   */
+  take?: number
+  /**
+   * {@link https://www.prisma.io/docs/concepts/components/prisma-client/pagination Pagination Docs}
+   * 
+   * Skip the first `n` StreamHandoffs.
+   */
+  skip?: number
+  /**
+   * {@link https://www.prisma.io/docs/concepts/components/prisma-client/distinct Distinct Docs}
+   * 
+   * Filter by unique combinations of StreamHandoffs.
+   */
+  distinct?: Prisma.StreamHandoffScalarFieldEnum | Prisma.StreamHandoffScalarFieldEnum[]
+}
+
+/**
+ * StreamHandoff findMany
+ */
+export type StreamHandoffFindManyArgs<ExtArgs extends runtime.Types.Extensions.InternalArgs = runtime.Types.Extensions.DefaultArgs> = {
+  /**
+   * Select specific fields to fetch from the StreamHandoff
+   */
+  select?: Prisma.StreamHandoffSelect<ExtArgs> | null
+  /**
+   * Omit specific fields from the StreamHandoff
+   */
+  omit?: Prisma.StreamHandoffOmit<ExtArgs> | null
+  /**
+   * Filter, which StreamHandoffs to fetch.
+   */
+  where?: Prisma.StreamHandoffWhereInput
+  /**
+   * {@link https://www.prisma.io/docs/concepts/components/prisma-client/sorting Sorting Docs}
+   * 
+   * Determine the order of StreamHandoffs to fetch.
+   */
+  orderBy?: Prisma.StreamHandoffOrderByWithRelationInput | Prisma.StreamHandoffOrderByWithRelationInput[]
+  /**
+   * {@link https://www.prisma.io/docs/concepts/components/prisma-client/pagination#cursor-based-pagination Cursor Docs}
+   * 
+   * Sets the position for listing StreamHandoffs.
+   */
+  cursor?: Prisma.StreamHandoffWhereUniqueInput
+  /**
+   * {@link https://www.prisma.io/docs/concepts/components/prisma-client/pagination Pagination Docs}
+   * 
+   * Take `±n` StreamHandoffs from the position of the cursor.
+   */
+  take?: number
+  /**
+   * {@link https://www.prisma.io/docs/concepts/components/prisma-client/pagination Pagination Docs}
+   * 
+   * Skip the first `n` StreamHandoffs.
+   */
+  skip?: number
+  distinct?: Prisma.StreamHandoffScalarFieldEnum | Prisma.StreamHandoffScalarFieldEnum[]
+}
+
+/**
+ * StreamHandoff create
+ */
+export type StreamHandoffCreateArgs<ExtArgs extends runtime.Types.Extensions.InternalArgs = runtime.Types.Extensions.DefaultArgs> = {
+  /**
+   * Select specific fields to fetch from the StreamHandoff
+   */
+  select?: Prisma.StreamHandoffSelect<ExtArgs> | null
+  /**
+   * Omit specific fields from the StreamHandoff
+   */
+  omit?: Prisma.StreamHandoffOmit<ExtArgs> | null
+  /**
+   * The data needed to create a StreamHandoff.
+   */
+  data: Prisma.XOR<Prisma.StreamHandoffCreateInput, Prisma.StreamHandoffUncheckedCreateInput>
+}
+
+/**
+ * StreamHandoff createMany
+ */
+export type StreamHandoffCreateManyArgs<ExtArgs extends runtime.Types.Extensions.InternalArgs = runtime.Types.Extensions.DefaultArgs> = {
+  /**
+   * The data used to create many StreamHandoffs.
+   */
+  data: Prisma.StreamHandoffCreateManyInput | Prisma.StreamHandoffCreateManyInput[]
+  skipDuplicates?: boolean
+}
+
+/**
+ * StreamHandoff createManyAndReturn
+ */
+export type StreamHandoffCreateManyAndReturnArgs<ExtArgs extends runtime.Types.Extensions.InternalArgs = runtime.Types.Extensions.DefaultArgs> = {
+  /**
+   * Select specific fields to fetch from the StreamHandoff
+   */
+  select?: Prisma.StreamHandoffSelectCreateManyAndReturn<ExtArgs> | null
+  /**
+   * Omit specific fields from the StreamHandoff
+   */
+  omit?: Prisma.StreamHandoffOmit<ExtArgs> | null
+  /**
+   * The data used to create many StreamHandoffs.
+   */
+  data: Prisma.StreamHandoffCreateManyInput | Prisma.StreamHandoffCreateManyInput[]
+  skipDuplicates?: boolean
+}
+
+/**
+ * StreamHandoff update
+ */
+export type StreamHandoffUpdateArgs<ExtArgs extends runtime.Types.Extensions.InternalArgs = runtime.Types.Extensions.DefaultArgs> = {
+  /**
+   * Select specific fields to fetch from the StreamHandoff
+   */
+  select?: Prisma.StreamHandoffSelect<ExtArgs> | null
+  /**
+   * Omit specific fields from the StreamHandoff
+   */
+  omit?: Prisma.StreamHandoffOmit<ExtArgs> | null
+  /**
+   * The data needed to update a StreamHandoff.
+   */
+  data: Prisma.XOR<Prisma.StreamHandoffUpdateInput, Prisma.StreamHandoffUncheckedUpdateInput>
+  /**
+   * Choose, which StreamHandoff to update.
+   */
+  where: Prisma.StreamHandoffWhereUniqueInput
+}
+
+/**
+ * StreamHandoff updateMany
+ */
+export type StreamHandoffUpdateManyArgs<ExtArgs extends runtime.Types.Extensions.InternalArgs = runtime.Types.Extensions.DefaultArgs> = {
+  /**
+   * The data used to update StreamHandoffs.
+   */
+  data: Prisma.XOR<Prisma.StreamHandoffUpdateManyMutationInput, Prisma.StreamHandoffUncheckedUpdateManyInput>
+  /**
+   * Filter which StreamHandoffs to update
+   */
+  where?: Prisma.StreamHandoffWhereInput
+  /**
+   * Limit how many StreamHandoffs to update.
+   */
+  limit?: number
+}
+
+/**
+ * StreamHandoff updateManyAndReturn
+ */
+export type StreamHandoffUpdateManyAndReturnArgs<ExtArgs extends runtime.Types.Extensions.InternalArgs = runtime.Types.Extensions.DefaultArgs> = {
+  /**
+   * Select specific fields to fetch from the StreamHandoff
+   */
+  select?: Prisma.StreamHandoffSelectUpdateManyAndReturn<ExtArgs> | null
+  /**
+   * Omit specific fields from the StreamHandoff
+   */
+  omit?: Prisma.StreamHandoffOmit<ExtArgs> | null
+  /**
+   * The data used to update StreamHandoffs.
+   */
+  data: Prisma.XOR<Prisma.StreamHandoffUpdateManyMutationInput, Prisma.StreamHandoffUncheckedUpdateManyInput>
+  /**
+   * Filter which StreamHandoffs to update
+   */
+  where?: Prisma.StreamHandoffWhereInput
+  /**
+   * Limit how many StreamHandoffs to update.
+   */
+  limit?: number
+}
+
+/**
+ * StreamHandoff upsert
+ */
+export type StreamHandoffUpsertArgs<ExtArgs extends runtime.Types.Extensions.InternalArgs = runtime.Types.Extensions.DefaultArgs> = {
+  /**
+   * Select specific fields to fetch from the StreamHandoff
+   */
+  select?: Prisma.StreamHandoffSelect<ExtArgs> | null
+  /**
+   * Omit specific fields from the StreamHandoff
+   */
+  omit?: Prisma.StreamHandoffOmit<ExtArgs> | null
+  /**
+   * The filter to search for the StreamHandoff to update in case it exists.
+   */
+  where: Prisma.StreamHandoffWhereUniqueInput
+  /**
+   * In case the StreamHandoff found by the `where` argument doesn't exist, create a new StreamHandoff with this data.
+   */
+  create: Prisma.XOR<Prisma.StreamHandoffCreateInput, Prisma.StreamHandoffUncheckedCreateInput>
+  /**
+   * In case the StreamHandoff was found with the provided `where` argument, update it with this data.
+   */
+  update: Prisma.XOR<Prisma.StreamHandoffUpdateInput, Prisma.StreamHandoffUncheckedUpdateInput>
+}
+
+/**
+ * StreamHandoff delete
+ */
+export type StreamHandoffDeleteArgs<ExtArgs extends runtime.Types.Extensions.InternalArgs = runtime.Types.Extensions.DefaultArgs> = {
+  /**
+   * Select specific fields to fetch from the StreamHandoff
+   */
+  select?: Prisma.StreamHandoffSelect<ExtArgs> | null
+  /**
+   * Omit specific fields from the StreamHandoff
+   */
+  omit?: Prisma.StreamHandoffOmit<ExtArgs> | null
+  /**
+   * Filter which StreamHandoff to delete.
+   */
+  where: Prisma.StreamHandoffWhereUniqueInput
+}
+
+/**
+ * StreamHandoff deleteMany
+ */
+export type StreamHandoffDeleteManyArgs<ExtArgs extends runtime.Types.Extensions.InternalArgs = runtime.Types.Extensions.DefaultArgs> = {
+  /**
+   * Filter which StreamHandoffs to delete
+   */
+  where?: Prisma.StreamHandoffWhereInput
+  /**
+   * Limit how many StreamHandoffs to delete.
+   */
+  limit?: number
+}
+
+/**
+ * StreamHandoff without action
+ */
+export type StreamHandoffDefaultArgs<ExtArgs extends runtime.Types.Extensions.InternalArgs = runtime.Types.Extensions.DefaultArgs> = {
+  /**
+   * Select specific fields to fetch from the StreamHandoff
+   */
+  select?: Prisma.StreamHandoffSelect<ExtArgs> | null
+  /**
+   * Omit specific fields from the StreamHandoff
+   */
+  omit?: Prisma.StreamHandoffOmit<ExtArgs> | null
+}
@@ -0,0 +1,1166 @@
+
+/* !!! This is code generated by Prisma. Do not edit directly. !!! */
+/* eslint-disable */
+// biome-ignore-all lint: generated file
+// @ts-nocheck 
+/*
+ * This file exports the `StreamLease` model and its related types.
+ *
+ * 🟢 You can import this file directly.
+ */
+import type * as runtime from "@prisma/client/runtime/client"
+import type * as $Enums from "../enums.js"
+import type * as Prisma from "../internal/prismaNamespace.js"
+
+/**
+ * Model StreamLease
+ * 
+ */
+export type StreamLeaseModel = runtime.Types.Result.DefaultSelection<Prisma.$StreamLeasePayload>
+
+export type AggregateStreamLease = {
+  _count: StreamLeaseCountAggregateOutputType | null
+  _min: StreamLeaseMinAggregateOutputType | null
+  _max: StreamLeaseMaxAggregateOutputType | null
+}
+
+export type StreamLeaseMinAggregateOutputType = {
+  streamId: string | null
+  ownerId: string | null
+  acquiredAt: Date | null
+  heartbeatAt: Date | null
+  expiresAt: Date | null
+}
+
+export type StreamLeaseMaxAggregateOutputType = {
+  streamId: string | null
+  ownerId: string | null
+  acquiredAt: Date | null
+  heartbeatAt: Date | null
+  expiresAt: Date | null
+}
+
+export type StreamLeaseCountAggregateOutputType = {
+  streamId: number
+  ownerId: number
+  command: number
+  acquiredAt: number
+  heartbeatAt: number
+  expiresAt: number
+  _all: number
+}
+
+
+export type StreamLeaseMinAggregateInputType = {
+  streamId?: true
+  ownerId?: true
+  acquiredAt?: true
+  heartbeatAt?: true
+  expiresAt?: true
+}
+
+export type StreamLeaseMaxAggregateInputType = {
+  streamId?: true
+  ownerId?: true
+  acquiredAt?: true
+  heartbeatAt?: true
+  expiresAt?: true
+}
+
+export type StreamLeaseCountAggregateInputType = {
+  streamId?: true
+  ownerId?: true
+  command?: true
+  acquiredAt?: true
+  heartbeatAt?: true
+  expiresAt?: true
+  _all?: true
+}
+
+export type StreamLeaseAggregateArgs<ExtArgs extends runtime.Types.Extensions.InternalArgs = runtime.Types.Extensions.DefaultArgs> = {
+  /**
+   * Filter which StreamLease to aggregate.
+   */
+  where?: Prisma.StreamLeaseWhereInput
+  /**
+   * {@link https://www.prisma.io/docs/concepts/components/prisma-client/sorting Sorting Docs}
+   * 
+   * Determine the order of StreamLeases to fetch.
+   */
+  orderBy?: Prisma.StreamLeaseOrderByWithRelationInput | Prisma.StreamLeaseOrderByWithRelationInput[]
+  /**
+   * {@link https://www.prisma.io/docs/concepts/components/prisma-client/pagination#cursor-based-pagination Cursor Docs}
+   * 
+   * Sets the start position
+   */
+  cursor?: Prisma.StreamLeaseWhereUniqueInput
+  /**
+   * {@link https://www.prisma.io/docs/concepts/components/prisma-client/pagination Pagination Docs}
+   * 
+   * Take `±n` StreamLeases from the position of the cursor.
+   */
+  take?: number
+  /**
+   * {@link https://www.prisma.io/docs/concepts/components/prisma-client/pagination Pagination Docs}
+   * 
+   * Skip the first `n` StreamLeases.
+   */
+  skip?: number
+  /**
+   * {@link https://www.prisma.io/docs/concepts/components/prisma-client/aggregations Aggregation Docs}
+   * 
+   * Count returned StreamLeases
+  **/
+  _count?: true | StreamLeaseCountAggregateInputType
+  /**
+   * {@link https://www.prisma.io/docs/concepts/components/prisma-client/aggregations Aggregation Docs}
+   * 
+   * Select which fields to find the minimum value
+  **/
+  _min?: StreamLeaseMinAggregateInputType
+  /**
+   * {@link https://www.prisma.io/docs/concepts/components/prisma-client/aggregations Aggregation Docs}
+   * 
+   * Select which fields to find the maximum value
+  **/
+  _max?: StreamLeaseMaxAggregateInputType
+}
+
+export type GetStreamLeaseAggregateType<T extends StreamLeaseAggregateArgs> = {
+      [P in keyof T & keyof AggregateStreamLease]: P extends '_count' | 'count'
+    ? T[P] extends true
+      ? number
+      : Prisma.GetScalarType<T[P], AggregateStreamLease[P]>
+    : Prisma.GetScalarType<T[P], AggregateStreamLease[P]>
+}
+
+
+
+
+export type StreamLeaseGroupByArgs<ExtArgs extends runtime.Types.Extensions.InternalArgs = runtime.Types.Extensions.DefaultArgs> = {
+  where?: Prisma.StreamLeaseWhereInput
+  orderBy?: Prisma.StreamLeaseOrderByWithAggregationInput | Prisma.StreamLeaseOrderByWithAggregationInput[]
+  by: Prisma.StreamLeaseScalarFieldEnum[] | Prisma.StreamLeaseScalarFieldEnum
+  having?: Prisma.StreamLeaseScalarWhereWithAggregatesInput
+  take?: number
+  skip?: number
+  _count?: StreamLeaseCountAggregateInputType | true
+  _min?: StreamLeaseMinAggregateInputType
+  _max?: StreamLeaseMaxAggregateInputType
+}
+
+export type StreamLeaseGroupByOutputType = {
+  streamId: string
+  ownerId: string
+  command: runtime.JsonValue
+  acquiredAt: Date
+  heartbeatAt: Date
+  expiresAt: Date
+  _count: StreamLeaseCountAggregateOutputType | null
+  _min: StreamLeaseMinAggregateOutputType | null
+  _max: StreamLeaseMaxAggregateOutputType | null
+}
+
+type GetStreamLeaseGroupByPayload<T extends StreamLeaseGroupByArgs> = Prisma.PrismaPromise<
+  Array<
+    Prisma.PickEnumerable<StreamLeaseGroupByOutputType, T['by']> &
+      {
+        [P in ((keyof T) & (keyof StreamLeaseGroupByOutputType))]: P extends '_count'
+          ? T[P] extends boolean
+            ? number
+            : Prisma.GetScalarType<T[P], StreamLeaseGroupByOutputType[P]>
+          : Prisma.GetScalarType<T[P], StreamLeaseGroupByOutputType[P]>
+      }
+    >
+  >
+
+
+
+export type StreamLeaseWhereInput = {
+  AND?: Prisma.StreamLeaseWhereInput | Prisma.StreamLeaseWhereInput[]
+  OR?: Prisma.StreamLeaseWhereInput[]
+  NOT?: Prisma.StreamLeaseWhereInput | Prisma.StreamLeaseWhereInput[]
+  streamId?: Prisma.StringFilter<"StreamLease"> | string
+  ownerId?: Prisma.StringFilter<"StreamLease"> | string
+  command?: Prisma.JsonFilter<"StreamLease">
+  acquiredAt?: Prisma.DateTimeFilter<"StreamLease"> | Date | string
+  heartbeatAt?: Prisma.DateTimeFilter<"StreamLease"> | Date | string
+  expiresAt?: Prisma.DateTimeFilter<"StreamLease"> | Date | string
+}
+
+export type StreamLeaseOrderByWithRelationInput = {
+  streamId?: Prisma.SortOrder
+  ownerId?: Prisma.SortOrder
+  command?: Prisma.SortOrder
+  acquiredAt?: Prisma.SortOrder
+  heartbeatAt?: Prisma.SortOrder
+  expiresAt?: Prisma.SortOrder
+}
+
+export type StreamLeaseWhereUniqueInput = Prisma.AtLeast<{
+  streamId?: string
+  AND?: Prisma.StreamLeaseWhereInput | Prisma.StreamLeaseWhereInput[]
+  OR?: Prisma.StreamLeaseWhereInput[]
+  NOT?: Prisma.StreamLeaseWhereInput | Prisma.StreamLeaseWhereInput[]
+  ownerId?: Prisma.StringFilter<"StreamLease"> | string
+  command?: Prisma.JsonFilter<"StreamLease">
+  acquiredAt?: Prisma.DateTimeFilter<"StreamLease"> | Date | string
+  heartbeatAt?: Prisma.DateTimeFilter<"StreamLease"> | Date | string
+  expiresAt?: Prisma.DateTimeFilter<"StreamLease"> | Date | string
+}, "streamId">
+
+export type StreamLeaseOrderByWithAggregationInput = {
+  streamId?: Prisma.SortOrder
+  ownerId?: Prisma.SortOrder
+  command?: Prisma.SortOrder
+  acquiredAt?: Prisma.SortOrder
+  heartbeatAt?: Prisma.SortOrder
+  expiresAt?: Prisma.SortOrder
+  _count?: Prisma.StreamLeaseCountOrderByAggregateInput
+  _max?: Prisma.StreamLeaseMaxOrderByAggregateInput
+  _min?: Prisma.StreamLeaseMinOrderByAggregateInput
+}
+
+export type StreamLeaseScalarWhereWithAggregatesInput = {
+  AND?: Prisma.StreamLeaseScalarWhereWithAggregatesInput | Prisma.StreamLeaseScalarWhereWithAggregatesInput[]
+  OR?: Prisma.StreamLeaseScalarWhereWithAggregatesInput[]
+  NOT?: Prisma.StreamLeaseScalarWhereWithAggregatesInput | Prisma.StreamLeaseScalarWhereWithAggregatesInput[]
+  streamId?: Prisma.StringWithAggregatesFilter<"StreamLease"> | string
+  ownerId?: Prisma.StringWithAggregatesFilter<"StreamLease"> | string
+  command?: Prisma.JsonWithAggregatesFilter<"StreamLease">
+  acquiredAt?: Prisma.DateTimeWithAggregatesFilter<"StreamLease"> | Date | string
+  heartbeatAt?: Prisma.DateTimeWithAggregatesFilter<"StreamLease"> | Date | string
+  expiresAt?: Prisma.DateTimeWithAggregatesFilter<"StreamLease"> | Date | string
+}
+
+export type StreamLeaseCreateInput = {
+  streamId: string
+  ownerId: string
+  command: Prisma.JsonNullValueInput | runtime.InputJsonValue
+  acquiredAt?: Date | string
+  heartbeatAt?: Date | string
+  expiresAt: Date | string
+}
+
+export type StreamLeaseUncheckedCreateInput = {
+  streamId: string
+  ownerId: string
+  command: Prisma.JsonNullValueInput | runtime.InputJsonValue
+  acquiredAt?: Date | string
+  heartbeatAt?: Date | string
+  expiresAt: Date | string
+}
+
+export type StreamLeaseUpdateInput = {
+  streamId?: Prisma.StringFieldUpdateOperationsInput | string
+  ownerId?: Prisma.StringFieldUpdateOperationsInput | string
+  command?: Prisma.JsonNullValueInput | runtime.InputJsonValue
+  acquiredAt?: Prisma.DateTimeFieldUpdateOperationsInput | Date | string
+  heartbeatAt?: Prisma.DateTimeFieldUpdateOperationsInput | Date | string
+  expiresAt?: Prisma.DateTimeFieldUpdateOperationsInput | Date | string
+}
+
+export type StreamLeaseUncheckedUpdateInput = {
+  streamId?: Prisma.StringFieldUpdateOperationsInput | string
+  ownerId?: Prisma.StringFieldUpdateOperationsInput | string
+  command?: Prisma.JsonNullValueInput | runtime.InputJsonValue
+  acquiredAt?: Prisma.DateTimeFieldUpdateOperationsInput | Date | string
+  heartbeatAt?: Prisma.DateTimeFieldUpdateOperationsInput | Date | string
+  expiresAt?: Prisma.DateTimeFieldUpdateOperationsInput | Date | string
+}
+
+export type StreamLeaseCreateManyInput = {
+  streamId: string
+  ownerId: string
+  command: Prisma.JsonNullValueInput | runtime.InputJsonValue
+  acquiredAt?: Date | string
+  heartbeatAt?: Date | string
+  expiresAt: Date | string
+}
+
+export type StreamLeaseUpdateManyMutationInput = {
+  streamId?: Prisma.StringFieldUpdateOperationsInput | string
+  ownerId?: Prisma.StringFieldUpdateOperationsInput | string
+  command?: Prisma.JsonNullValueInput | runtime.InputJsonValue
+  acquiredAt?: Prisma.DateTimeFieldUpdateOperationsInput | Date | string
+  heartbeatAt?: Prisma.DateTimeFieldUpdateOperationsInput | Date | string
+  expiresAt?: Prisma.DateTimeFieldUpdateOperationsInput | Date | string
+}
+
+export type StreamLeaseUncheckedUpdateManyInput = {
+  streamId?: Prisma.StringFieldUpdateOperationsInput | string
+  ownerId?: Prisma.StringFieldUpdateOperationsInput | string
+  command?: Prisma.JsonNullValueInput | runtime.InputJsonValue
+  acquiredAt?: Prisma.DateTimeFieldUpdateOperationsInput | Date | string
+  heartbeatAt?: Prisma.DateTimeFieldUpdateOperationsInput | Date | string
+  expiresAt?: Prisma.DateTimeFieldUpdateOperationsInput | Date | string
+}
+
+export type StreamLeaseCountOrderByAggregateInput = {
+  streamId?: Prisma.SortOrder
+  ownerId?: Prisma.SortOrder
+  command?: Prisma.SortOrder
+  acquiredAt?: Prisma.SortOrder
+  heartbeatAt?: Prisma.SortOrder
+  expiresAt?: Prisma.SortOrder
+}
+
+export type StreamLeaseMaxOrderByAggregateInput = {
+  streamId?: Prisma.SortOrder
+  ownerId?: Prisma.SortOrder
+  acquiredAt?: Prisma.SortOrder
+  heartbeatAt?: Prisma.SortOrder
+  expiresAt?: Prisma.SortOrder
+}
+
+export type StreamLeaseMinOrderByAggregateInput = {
+  streamId?: Prisma.SortOrder
+  ownerId?: Prisma.SortOrder
+  acquiredAt?: Prisma.SortOrder
+  heartbeatAt?: Prisma.SortOrder
+  expiresAt?: Prisma.SortOrder
+}
+
+
+
+export type StreamLeaseSelect<ExtArgs extends runtime.Types.Extensions.InternalArgs = runtime.Types.Extensions.DefaultArgs> = runtime.Types.Extensions.GetSelect<{
+  streamId?: boolean
+  ownerId?: boolean
+  command?: boolean
+  acquiredAt?: boolean
+  heartbeatAt?: boolean
+  expiresAt?: boolean
+}, ExtArgs["result"]["streamLease"]>
+
+export type StreamLeaseSelectCreateManyAndReturn<ExtArgs extends runtime.Types.Extensions.InternalArgs = runtime.Types.Extensions.DefaultArgs> = runtime.Types.Extensions.GetSelect<{
+  streamId?: boolean
+  ownerId?: boolean
+  command?: boolean
+  acquiredAt?: boolean
+  heartbeatAt?: boolean
+  expiresAt?: boolean
+}, ExtArgs["result"]["streamLease"]>
+
+export type StreamLeaseSelectUpdateManyAndReturn<ExtArgs extends runtime.Types.Extensions.InternalArgs = runtime.Types.Extensions.DefaultArgs> = runtime.Types.Extensions.GetSelect<{
+  streamId?: boolean
+  ownerId?: boolean
+  command?: boolean
+  acquiredAt?: boolean
+  heartbeatAt?: boolean
+  expiresAt?: boolean
+}, ExtArgs["result"]["streamLease"]>
+
+export type StreamLeaseSelectScalar = {
+  streamId?: boolean
+  ownerId?: boolean
+  command?: boolean
+  acquiredAt?: boolean
+  heartbeatAt?: boolean
+  expiresAt?: boolean
+}
+
+export type StreamLeaseOmit<ExtArgs extends runtime.Types.Extensions.InternalArgs = runtime.Types.Extensions.DefaultArgs> = runtime.Types.Extensions.GetOmit<"streamId" | "ownerId" | "command" | "acquiredAt" | "heartbeatAt" | "expiresAt", ExtArgs["result"]["streamLease"]>
+
+export type $StreamLeasePayload<ExtArgs extends runtime.Types.Extensions.InternalArgs = runtime.Types.Extensions.DefaultArgs> = {
+  name: "StreamLease"
+  objects: {}
+  scalars: runtime.Types.Extensions.GetPayloadResult<{
+    streamId: string
+    ownerId: string
+    command: runtime.JsonValue
+    acquiredAt: Date
+    heartbeatAt: Date
+    expiresAt: Date
+  }, ExtArgs["result"]["streamLease"]>
+  composites: {}
+}
+
+export type StreamLeaseGetPayload<S extends boolean | null | undefined | StreamLeaseDefaultArgs> = runtime.Types.Result.GetResult<Prisma.$StreamLeasePayload, S>
+
+export type StreamLeaseCountArgs<ExtArgs extends runtime.Types.Extensions.InternalArgs = runtime.Types.Extensions.DefaultArgs> =
+  Omit<StreamLeaseFindManyArgs, 'select' | 'include' | 'distinct' | 'omit'> & {
+    select?: StreamLeaseCountAggregateInputType | true
+  }
+
+export interface StreamLeaseDelegate<ExtArgs extends runtime.Types.Extensions.InternalArgs = runtime.Types.Extensions.DefaultArgs, GlobalOmitOptions = {}> {
+  [K: symbol]: { types: Prisma.TypeMap<ExtArgs>['model']['StreamLease'], meta: { name: 'StreamLease' } }
+  /**
+   * Find zero or one StreamLease that matches the filter.
+   * @param {StreamLeaseFindUniqueArgs} args - Arguments to find a StreamLease
+   * @example
+   * // Get one StreamLease
+   * const streamLease = await prisma.streamLease.findUnique({
+   *   where: {
+   *     // ... provide filter here
+   *   }
+   * })
+   */
+  findUnique<T extends StreamLeaseFindUniqueArgs>(args: Prisma.SelectSubset<T, StreamLeaseFindUniqueArgs<ExtArgs>>): Prisma.Prisma__StreamLeaseClient<runtime.Types.Result.GetResult<Prisma.$StreamLeasePayload<ExtArgs>, T, "findUnique", GlobalOmitOptions> | null, null, ExtArgs, GlobalOmitOptions>
+
+  /**
+   * Find one StreamLease that matches the filter or throw an error with `error.code='P2025'`
+   * if no matches were found.
+   * @param {StreamLeaseFindUniqueOrThrowArgs} args - Arguments to find a StreamLease
+   * @example
+   * // Get one StreamLease
+   * const streamLease = await prisma.streamLease.findUniqueOrThrow({
+   *   where: {
+   *     // ... provide filter here
+   *   }
+   * })
+   */
+  findUniqueOrThrow<T extends StreamLeaseFindUniqueOrThrowArgs>(args: Prisma.SelectSubset<T, StreamLeaseFindUniqueOrThrowArgs<ExtArgs>>): Prisma.Prisma__StreamLeaseClient<runtime.Types.Result.GetResult<Prisma.$StreamLeasePayload<ExtArgs>, T, "findUniqueOrThrow", GlobalOmitOptions>, never, ExtArgs, GlobalOmitOptions>
+
+  /**
+   * Find the first StreamLease that matches the filter.
+   * Note, that providing `undefined` is treated as the value not being there.
+   * Read more here: https://pris.ly/d/null-undefined
+   * @param {StreamLeaseFindFirstArgs} args - Arguments to find a StreamLease
+   * @example
+   * // Get one StreamLease
+   * const streamLease = await prisma.streamLease.findFirst({
+   *   where: {
+   *     // ... provide filter here
+   *   }
+   * })
+   */
+  findFirst<T extends StreamLeaseFindFirstArgs>(args?: Prisma.SelectSubset<T, StreamLeaseFindFirstArgs<ExtArgs>>): Prisma.Prisma__StreamLeaseClient<runtime.Types.Result.GetResult<Prisma.$StreamLeasePayload<ExtArgs>, T, "findFirst", GlobalOmitOptions> | null, null, ExtArgs, GlobalOmitOptions>
+
+  /**
+   * Find the first StreamLease that matches the filter or
+   * throw `PrismaKnownClientError` with `P2025` code if no matches were found.
+   * Note, that providing `undefined` is treated as the value not being there.
+   * Read more here: https://pris.ly/d/null-undefined
+   * @param {StreamLeaseFindFirstOrThrowArgs} args - Arguments to find a StreamLease
+   * @example
+   * // Get one StreamLease
+   * const streamLease = await prisma.streamLease.findFirstOrThrow({
+   *   where: {
+   *     // ... provide filter here
+   *   }
+   * })
+   */
+  findFirstOrThrow<T extends StreamLeaseFindFirstOrThrowArgs>(args?: Prisma.SelectSubset<T, StreamLeaseFindFirstOrThrowArgs<ExtArgs>>): Prisma.Prisma__StreamLeaseClient<runtime.Types.Result.GetResult<Prisma.$StreamLeasePayload<ExtArgs>, T, "findFirstOrThrow", GlobalOmitOptions>, never, ExtArgs, GlobalOmitOptions>
+
+  /**
+   * Find zero or more StreamLeases that matches the filter.
+   * Note, that providing `undefined` is treated as the value not being there.
+   * Read more here: https://pris.ly/d/null-undefined
+   * @param {StreamLeaseFindManyArgs} args - Arguments to filter and select certain fields only.
+   * @example
+   * // Get all StreamLeases
+   * const streamLeases = await prisma.streamLease.findMany()
+   * 
+   * // Get first 10 StreamLeases
+   * const streamLeases = await prisma.streamLease.findMany({ take: 10 })
+   * 
+   * // Only select the `streamId`
+   * const streamLeaseWithStreamIdOnly = await prisma.streamLease.findMany({ select: { streamId: true } })
+   * 
+   */
+  findMany<T extends StreamLeaseFindManyArgs>(args?: Prisma.SelectSubset<T, StreamLeaseFindManyArgs<ExtArgs>>): Prisma.PrismaPromise<runtime.Types.Result.GetResult<Prisma.$StreamLeasePayload<ExtArgs>, T, "findMany", GlobalOmitOptions>>
+
+  /**
+   * Create a StreamLease.
+   * @param {StreamLeaseCreateArgs} args - Arguments to create a StreamLease.
+   * @example
+   * // Create one StreamLease
+   * const StreamLease = await prisma.streamLease.create({
+   *   data: {
+   *     // ... data to create a StreamLease
+   *   }
+   * })
+   * 
+   */
+  create<T extends StreamLeaseCreateArgs>(args: Prisma.SelectSubset<T, StreamLeaseCreateArgs<ExtArgs>>): Prisma.Prisma__StreamLeaseClient<runtime.Types.Result.GetResult<Prisma.$StreamLeasePayload<ExtArgs>, T, "create", GlobalOmitOptions>, never, ExtArgs, GlobalOmitOptions>
+
+  /**
+   * Create many StreamLeases.
+   * @param {StreamLeaseCreateManyArgs} args - Arguments to create many StreamLeases.
+   * @example
+   * // Create many StreamLeases
+   * const streamLease = await prisma.streamLease.createMany({
+   *   data: [
+   *     // ... provide data here
+   *   ]
+   * })
+   *     
+   */
+  createMany<T extends StreamLeaseCreateManyArgs>(args?: Prisma.SelectSubset<T, StreamLeaseCreateManyArgs<ExtArgs>>): Prisma.PrismaPromise<Prisma.BatchPayload>
+
+  /**
+   * Create many StreamLeases and returns the data saved in the database.
+   * @param {StreamLeaseCreateManyAndReturnArgs} args - Arguments to create many StreamLeases.
+   * @example
+   * // Create many StreamLeases
+   * const streamLease = await prisma.streamLease.createManyAndReturn({
+   *   data: [
+   *     // ... provide data here
+   *   ]
+   * })
+   * 
+   * // Create many StreamLeases and only return the `streamId`
+   * const streamLeaseWithStreamIdOnly = await prisma.streamLease.createManyAndReturn({
+   *   select: { streamId: true },
+   *   data: [
+   *     // ... provide data here
+   *   ]
+   * })
+   * Note, that providing `undefined` is treated as the value not being there.
+   * Read more here: https://pris.ly/d/null-undefined
+   * 
+   */
+  createManyAndReturn<T extends StreamLeaseCreateManyAndReturnArgs>(args?: Prisma.SelectSubset<T, StreamLeaseCreateManyAndReturnArgs<ExtArgs>>): Prisma.PrismaPromise<runtime.Types.Result.GetResult<Prisma.$StreamLeasePayload<ExtArgs>, T, "createManyAndReturn", GlobalOmitOptions>>
+
+  /**
+   * Delete a StreamLease.
+   * @param {StreamLeaseDeleteArgs} args - Arguments to delete one StreamLease.
+   * @example
+   * // Delete one StreamLease
+   * const StreamLease = await prisma.streamLease.delete({
+   *   where: {
+   *     // ... filter to delete one StreamLease
+   *   }
+   * })
+   * 
+   */
+  delete<T extends StreamLeaseDeleteArgs>(args: Prisma.SelectSubset<T, StreamLeaseDeleteArgs<ExtArgs>>): Prisma.Prisma__StreamLeaseClient<runtime.Types.Result.GetResult<Prisma.$StreamLeasePayload<ExtArgs>, T, "delete", GlobalOmitOptions>, never, ExtArgs, GlobalOmitOptions>
+
+  /**
+   * Update one StreamLease.
+   * @param {StreamLeaseUpdateArgs} args - Arguments to update one StreamLease.
+   * @example
+   * // Update one StreamLease
+   * const streamLease = await prisma.streamLease.update({
+   *   where: {
+   *     // ... provide filter here
+   *   },
+   *   data: {
+   *     // ... provide data here
+   *   }
+   * })
+   * 
+   */
+  update<T extends StreamLeaseUpdateArgs>(args: Prisma.SelectSubset<T, StreamLeaseUpdateArgs<ExtArgs>>): Prisma.Prisma__StreamLeaseClient<runtime.Types.Result.GetResult<Prisma.$StreamLeasePayload<ExtArgs>, T, "update", GlobalOmitOptions>, never, ExtArgs, GlobalOmitOptions>
+
+  /**
+   * Delete zero or more StreamLeases.
+   * @param {StreamLeaseDeleteManyArgs} args - Arguments to filter StreamLeases to delete.
+   * @example
+   * // Delete a few StreamLeases
+   * const { count } = await prisma.streamLease.deleteMany({
+   *   where: {
+   *     // ... provide filter here
+   *   }
+   * })
+   * 
+   */
+  deleteMany<T extends StreamLeaseDeleteManyArgs>(args?: Prisma.SelectSubset<T, StreamLeaseDeleteManyArgs<ExtArgs>>): Prisma.PrismaPromise<Prisma.BatchPayload>
+
+  /**
+   * Update zero or more StreamLeases.
+   * Note, that providing `undefined` is treated as the value not being there.
+   * Read more here: https://pris.ly/d/null-undefined
+   * @param {StreamLeaseUpdateManyArgs} args - Arguments to update one or more rows.
+   * @example
+   * // Update many StreamLeases
+   * const streamLease = await prisma.streamLease.updateMany({
+   *   where: {
+   *     // ... provide filter here
+   *   },
+   *   data: {
+   *     // ... provide data here
+   *   }
+   * })
+   * 
+   */
+  updateMany<T extends StreamLeaseUpdateManyArgs>(args: Prisma.SelectSubset<T, StreamLeaseUpdateManyArgs<ExtArgs>>): Prisma.PrismaPromise<Prisma.BatchPayload>
+
+  /**
+   * Update zero or more StreamLeases and returns the data updated in the database.
+   * @param {StreamLeaseUpdateManyAndReturnArgs} args - Arguments to update many StreamLeases.
+   * @example
+   * // Update many StreamLeases
+   * const streamLease = await prisma.streamLease.updateManyAndReturn({
+   *   where: {
+   *     // ... provide filter here
+   *   },
+   *   data: [
+   *     // ... provide data here
+   *   ]
+   * })
+   * 
+   * // Update zero or more StreamLeases and only return the `streamId`
+   * const streamLeaseWithStreamIdOnly = await prisma.streamLease.updateManyAndReturn({
+   *   select: { streamId: true },
+   *   where: {
+   *     // ... provide filter here
+   *   },
+   *   data: [
+   *     // ... provide data here
+   *   ]
+   * })
+   * Note, that providing `undefined` is treated as the value not being there.
+   * Read more here: https://pris.ly/d/null-undefined
+   * 
+   */
+  updateManyAndReturn<T extends StreamLeaseUpdateManyAndReturnArgs>(args: Prisma.SelectSubset<T, StreamLeaseUpdateManyAndReturnArgs<ExtArgs>>): Prisma.PrismaPromise<runtime.Types.Result.GetResult<Prisma.$StreamLeasePayload<ExtArgs>, T, "updateManyAndReturn", GlobalOmitOptions>>
+
+  /**
+   * Create or update one StreamLease.
+   * @param {StreamLeaseUpsertArgs} args - Arguments to update or create a StreamLease.
+   * @example
+   * // Update or create a StreamLease
+   * const streamLease = await prisma.streamLease.upsert({
+   *   create: {
+   *     // ... data to create a StreamLease
+   *   },
+   *   update: {
+   *     // ... in case it already exists, update
+   *   },
+   *   where: {
+   *     // ... the filter for the StreamLease we want to update
+   *   }
+   * })
+   */
+  upsert<T extends StreamLeaseUpsertArgs>(args: Prisma.SelectSubset<T, StreamLeaseUpsertArgs<ExtArgs>>): Prisma.Prisma__StreamLeaseClient<runtime.Types.Result.GetResult<Prisma.$StreamLeasePayload<ExtArgs>, T, "upsert", GlobalOmitOptions>, never, ExtArgs, GlobalOmitOptions>
+
+
+  /**
+   * Count the number of StreamLeases.
+   * Note, that providing `undefined` is treated as the value not being there.
+   * Read more here: https://pris.ly/d/null-undefined
+   * @param {StreamLeaseCountArgs} args - Arguments to filter StreamLeases to count.
+   * @example
+   * // Count the number of StreamLeases
+   * const count = await prisma.streamLease.count({
+   *   where: {
+   *     // ... the filter for the StreamLeases we want to count
+   *   }
+   * })
+  **/
+  count<T extends StreamLeaseCountArgs>(
+    args?: Prisma.Subset<T, StreamLeaseCountArgs>,
+  ): Prisma.PrismaPromise<
+    T extends runtime.Types.Utils.Record<'select', any>
+      ? T['select'] extends true
+        ? number
+        : Prisma.GetScalarType<T['select'], StreamLeaseCountAggregateOutputType>
+      : number
+  >
+
+  /**
+   * Allows you to perform aggregations operations on a StreamLease.
+   * Note, that providing `undefined` is treated as the value not being there.
+   * Read more here: https://pris.ly/d/null-undefined
+   * @param {StreamLeaseAggregateArgs} args - Select which aggregations you would like to apply and on what fields.
+   * @example
+   * // Ordered by age ascending
+   * // Where email contains prisma.io
+   * // Limited to the 10 users
+   * const aggregations = await prisma.user.aggregate({
+   *   _avg: {
+   *     age: true,
+   *   },
+   *   where: {
+   *     email: {
+   *       contains: "prisma.io",
+   *     },
+   *   },
+   *   orderBy: {
+   *     age: "asc",
+   *   },
+   *   take: 10,
+   * })
+  **/
+  aggregate<T extends StreamLeaseAggregateArgs>(args: Prisma.Subset<T, StreamLeaseAggregateArgs>): Prisma.PrismaPromise<GetStreamLeaseAggregateType<T>>
+
+  /**
+   * Group by StreamLease.
+   * Note, that providing `undefined` is treated as the value not being there.
+   * Read more here: https://pris.ly/d/null-undefined
+   * @param {StreamLeaseGroupByArgs} args - Group by arguments.
+   * @example
+   * // Group by city, order by createdAt, get count
+   * const result = await prisma.user.groupBy({
+   *   by: ['city', 'createdAt'],
+   *   orderBy: {
+   *     createdAt: true
+   *   },
+   *   _count: {
+   *     _all: true
+   *   },
+   * })
+   * 
+  **/
+  groupBy<
+    T extends StreamLeaseGroupByArgs,
+    HasSelectOrTake extends Prisma.Or<
+      Prisma.Extends<'skip', Prisma.Keys<T>>,
+      Prisma.Extends<'take', Prisma.Keys<T>>
+    >,
+    OrderByArg extends Prisma.True extends HasSelectOrTake
+      ? { orderBy: StreamLeaseGroupByArgs['orderBy'] }
+      : { orderBy?: StreamLeaseGroupByArgs['orderBy'] },
+    OrderFields extends Prisma.ExcludeUnderscoreKeys<Prisma.Keys<Prisma.MaybeTupleToUnion<T['orderBy']>>>,
+    ByFields extends Prisma.MaybeTupleToUnion<T['by']>,
+    ByValid extends Prisma.Has<ByFields, OrderFields>,
+    HavingFields extends Prisma.GetHavingFields<T['having']>,
+    HavingValid extends Prisma.Has<ByFields, HavingFields>,
+    ByEmpty extends T['by'] extends never[] ? Prisma.True : Prisma.False,
+    InputErrors extends ByEmpty extends Prisma.True
+    ? `Error: "by" must not be empty.`
+    : HavingValid extends Prisma.False
+    ? {
+        [P in HavingFields]: P extends ByFields
+          ? never
+          : P extends string
+          ? `Error: Field "${P}" used in "having" needs to be provided in "by".`
+          : [
+              Error,
+              'Field ',
+              P,
+              ` in "having" needs to be provided in "by"`,
+            ]
+      }[HavingFields]
+    : 'take' extends Prisma.Keys<T>
+    ? 'orderBy' extends Prisma.Keys<T>
+      ? ByValid extends Prisma.True
+        ? {}
+        : {
+            [P in OrderFields]: P extends ByFields
+              ? never
+              : `Error: Field "${P}" in "orderBy" needs to be provided in "by"`
+          }[OrderFields]
+      : 'Error: If you provide "take", you also need to provide "orderBy"'
+    : 'skip' extends Prisma.Keys<T>
+    ? 'orderBy' extends Prisma.Keys<T>
+      ? ByValid extends Prisma.True
+        ? {}
+        : {
+            [P in OrderFields]: P extends ByFields
+              ? never
+              : `Error: Field "${P}" in "orderBy" needs to be provided in "by"`
+          }[OrderFields]
+      : 'Error: If you provide "skip", you also need to provide "orderBy"'
+    : ByValid extends Prisma.True
+    ? {}
+    : {
+        [P in OrderFields]: P extends ByFields
+          ? never
+          : `Error: Field "${P}" in "orderBy" needs to be provided in "by"`
+      }[OrderFields]
+  >(args: Prisma.SubsetIntersection<T, StreamLeaseGroupByArgs, OrderByArg> & InputErrors): {} extends InputErrors ? GetStreamLeaseGroupByPayload<T> : Prisma.PrismaPromise<InputErrors>
+/**
+ * Fields of the StreamLease model
+ */
+readonly fields: StreamLeaseFieldRefs;
+}
+
+/**
+ * The delegate class that acts as a "Promise-like" for StreamLease.
+ * Why is this prefixed with `Prisma__`?
+ * Because we want to prevent naming conflicts as mentioned in
+ * https://github.com/prisma/prisma-client-js/issues/707
+ */
+export interface Prisma__StreamLeaseClient<T, Null = never, ExtArgs extends runtime.Types.Extensions.InternalArgs = runtime.Types.Extensions.DefaultArgs, GlobalOmitOptions = {}> extends Prisma.PrismaPromise<T> {
+  readonly [Symbol.toStringTag]: "PrismaPromise"
+  /**
+   * Attaches callbacks for the resolution and/or rejection of the Promise.
+   * @param onfulfilled The callback to execute when the Promise is resolved.
+   * @param onrejected The callback to execute when the Promise is rejected.
+   * @returns A Promise for the completion of which ever callback is executed.
+   */
+  then<TResult1 = T, TResult2 = never>(onfulfilled?: ((value: T) => TResult1 | PromiseLike<TResult1>) | undefined | null, onrejected?: ((reason: any) => TResult2 | PromiseLike<TResult2>) | undefined | null): runtime.Types.Utils.JsPromise<TResult1 | TResult2>
+  /**
+   * Attaches a callback for only the rejection of the Promise.
+   * @param onrejected The callback to execute when the Promise is rejected.
+   * @returns A Promise for the completion of the callback.
+   */
+  catch<TResult = never>(onrejected?: ((reason: any) => TResult | PromiseLike<TResult>) | undefined | null): runtime.Types.Utils.JsPromise<T | TResult>
+  /**
+   * Attaches a callback that is invoked when the Promise is settled (fulfilled or rejected). The
+   * resolved value cannot be modified from the callback.
+   * @param onfinally The callback to execute when the Promise is settled (fulfilled or rejected).
+   * @returns A Promise for the completion of the callback.
+   */
+  finally(onfinally?: (() => void) | undefined | null): runtime.Types.Utils.JsPromise<T>
+}
+
+
+
+
+/**
+ * Fields of the StreamLease model
+ */
+export interface StreamLeaseFieldRefs {
+  readonly streamId: Prisma.FieldRef<"StreamLease", 'String'>
+  readonly ownerId: Prisma.FieldRef<"StreamLease", 'String'>
+  readonly command: Prisma.FieldRef<"StreamLease", 'Json'>
+  readonly acquiredAt: Prisma.FieldRef<"StreamLease", 'DateTime'>
+  readonly heartbeatAt: Prisma.FieldRef<"StreamLease", 'DateTime'>
+  readonly expiresAt: Prisma.FieldRef<"StreamLease", 'DateTime'>
+}
+    
+
+// Custom InputTypes
+/**
+ * StreamLease findUnique
+ */
+export type StreamLeaseFindUniqueArgs<ExtArgs extends runtime.Types.Extensions.InternalArgs = runtime.Types.Extensions.DefaultArgs> = {
+  /**
+   * Select specific fields to fetch from the StreamLease
+   */
+  select?: Prisma.StreamLeaseSelect<ExtArgs> | null
+  /**
+   * Omit specific fields from the StreamLease
+   */
+  omit?: Prisma.StreamLeaseOmit<ExtArgs> | null
+  /**
+   * Filter, which StreamLease to fetch.
+   */
+  where: Prisma.StreamLeaseWhereUniqueInput
+}
+
+/**
+ * StreamLease findUniqueOrThrow
+ */
+export type StreamLeaseFindUniqueOrThrowArgs<ExtArgs extends runtime.Types.Extensions.InternalArgs = runtime.Types.Extensions.DefaultArgs> = {
+  /**
+   * Select specific fields to fetch from the StreamLease
+   */
+  select?: Prisma.StreamLeaseSelect<ExtArgs> | null
+  /**
+   * Omit specific fields from the StreamLease
+   */
+  omit?: Prisma.StreamLeaseOmit<ExtArgs> | null
+  /**
+   * Filter, which StreamLease to fetch.
+   */
+  where: Prisma.StreamLeaseWhereUniqueInput
+}
+
+/**
+ * StreamLease findFirst
+ */
+export type StreamLeaseFindFirstArgs<ExtArgs extends runtime.Types.Extensions.InternalArgs = runtime.Types.Extensions.DefaultArgs> = {
+  /**
+   * Select specific fields to fetch from the StreamLease
+   */
+  select?: Prisma.StreamLeaseSelect<ExtArgs> | null
+  /**
+   * Omit specific fields from the StreamLease
+   */
+  omit?: Prisma.StreamLeaseOmit<ExtArgs> | null
+  /**
+   * Filter, which StreamLease to fetch.
+   */
+  where?: Prisma.StreamLeaseWhereInput
+  /**
+   * {@link https://www.prisma.io/docs/concepts/components/prisma-client/sorting Sorting Docs}
+   * 
+   * Determine the order of StreamLeases to fetch.
+   */
+  orderBy?: Prisma.StreamLeaseOrderByWithRelationInput | Prisma.StreamLeaseOrderByWithRelationInput[]
+  /**
+   * {@link https://www.prisma.io/docs/concepts/components/prisma-client/pagination#cursor-based-pagination Cursor Docs}
+   * 
+   * Sets the position for searching for StreamLeases.
+   */
+  cursor?: Prisma.StreamLeaseWhereUniqueInput
+  /**
+   * {@link https://www.prisma.io/docs/concepts/components/prisma-client/pagination Pagination Docs}
+   * 
+   * Take `±n` StreamLeases from the position of the cursor.
+   */
+  take?: number
+  /**
+   * {@link https://www.prisma.io/docs/concepts/components/prisma-client/pagination Pagination Docs}
+   * 
+   * Skip the first `n` StreamLeases.
+   */
+  skip?: number
+  /**
+   * {@link https://www.prisma.io/docs/concepts/components/prisma-client/distinct Distinct Docs}
+   * 
+   * Filter by unique combinations of StreamLeases.
+   */
+  distinct?: Prisma.StreamLeaseScalarFieldEnum | Prisma.StreamLeaseScalarFieldEnum[]
+}
+
+/**
+ * StreamLease findFirstOrThrow
+ */
+export type StreamLeaseFindFirstOrThrowArgs<ExtArgs extends runtime.Types.Extensions.InternalArgs = runtime.Types.Extensions.DefaultArgs> = {
+  /**
+   * Select specific fields to fetch from the StreamLease
+   */
+  select?: Prisma.StreamLeaseSelect<ExtArgs> | null
+  /**
+   * Omit specific fields from the StreamLease
+   */
+  omit?: Prisma.StreamLeaseOmit<ExtArgs> | null
+  /**
+   * Filter, which StreamLease to fetch.
+   */
+  where?: Prisma.StreamLeaseWhereInput
+  /**
+   * {@link https://www.prisma.io/docs/concepts/components/prisma-client/sorting Sorting Docs}
+   * 
+   * Determine the order of StreamLeases to fetch.
+   */
+  orderBy?: Prisma.StreamLeaseOrderByWithRelationInput | Prisma.StreamLeaseOrderByWithRelationInput[]
+  /**
+   * {@link https://www.prisma.io/docs/concepts/components/prisma-client/pagination#cursor-based-pagination Cursor Docs}
+   * 
+   * Sets the position for searching for StreamLeases.
+   */
+  cursor?: Prisma.StreamLeaseWhereUniqueInput
+  /**
+   * {@link https://www.prisma.io/docs/concepts/components/prisma-client/pagination Pagination Docs}
+   * 
+   * Take `±n` StreamLeases from the position of the cursor.
+   */
+  take?: number
+  /**
+   * {@link https://www.prisma.io/docs/concepts/components/prisma-client/pagination Pagination Docs}
+   * 
+   * Skip the first `n` StreamLeases.
+   */
+  skip?: number
+  /**
+   * {@link https://www.prisma.io/docs/concepts/components/prisma-client/distinct Distinct Docs}
+   * 
+   * Filter by unique combinations of StreamLeases.
+   */
+  distinct?: Prisma.StreamLeaseScalarFieldEnum | Prisma.StreamLeaseScalarFieldEnum[]
+}
+
+/**
+ * StreamLease findMany
+ */
+export type StreamLeaseFindManyArgs<ExtArgs extends runtime.Types.Extensions.InternalArgs = runtime.Types.Extensions.DefaultArgs> = {
+  /**
+   * Select specific fields to fetch from the StreamLease
+   */
+  select?: Prisma.StreamLeaseSelect<ExtArgs> | null
+  /**
+   * Omit specific fields from the StreamLease
+   */
+  omit?: Prisma.StreamLeaseOmit<ExtArgs> | null
+  /**
+   * Filter, which StreamLeases to fetch.
+   */
+  where?: Prisma.StreamLeaseWhereInput
+  /**
+   * {@link https://www.prisma.io/docs/concepts/components/prisma-client/sorting Sorting Docs}
+   * 
+   * Determine the order of StreamLeases to fetch.
+   */
+  orderBy?: Prisma.StreamLeaseOrderByWithRelationInput | Prisma.StreamLeaseOrderByWithRelationInput[]
+  /**
+   * {@link https://www.prisma.io/docs/concepts/components/prisma-client/pagination#cursor-based-pagination Cursor Docs}
+   * 
+   * Sets the position for listing StreamLeases.
+   */
+  cursor?: Prisma.StreamLeaseWhereUniqueInput
+  /**
+   * {@link https://www.prisma.io/docs/concepts/components/prisma-client/pagination Pagination Docs}
+   * 
+   * Take `±n` StreamLeases from the position of the cursor.
+   */
+  take?: number
+  /**
+   * {@link https://www.prisma.io/docs/concepts/components/prisma-client/pagination Pagination Docs}
+   * 
+   * Skip the first `n` StreamLeases.
+   */
+  skip?: number
+  distinct?: Prisma.StreamLeaseScalarFieldEnum | Prisma.StreamLeaseScalarFieldEnum[]
+}
+
+/**
+ * StreamLease create
+ */
+export type StreamLeaseCreateArgs<ExtArgs extends runtime.Types.Extensions.InternalArgs = runtime.Types.Extensions.DefaultArgs> = {
+  /**
+   * Select specific fields to fetch from the StreamLease
+   */
+  select?: Prisma.StreamLeaseSelect<ExtArgs> | null
+  /**
+   * Omit specific fields from the StreamLease
+   */
+  omit?: Prisma.StreamLeaseOmit<ExtArgs> | null
+  /**
+   * The data needed to create a StreamLease.
+   */
+  data: Prisma.XOR<Prisma.StreamLeaseCreateInput, Prisma.StreamLeaseUncheckedCreateInput>
+}
+
+/**
+ * StreamLease createMany
+ */
+export type StreamLeaseCreateManyArgs<ExtArgs extends runtime.Types.Extensions.InternalArgs = runtime.Types.Extensions.DefaultArgs> = {
+  /**
+   * The data used to create many StreamLeases.
+   */
+  data: Prisma.StreamLeaseCreateManyInput | Prisma.StreamLeaseCreateManyInput[]
+  skipDuplicates?: boolean
+}
+
+/**
+ * StreamLease createManyAndReturn
+ */
+export type StreamLeaseCreateManyAndReturnArgs<ExtArgs extends runtime.Types.Extensions.InternalArgs = runtime.Types.Extensions.DefaultArgs> = {
+  /**
+   * Select specific fields to fetch from the StreamLease
+   */
+  select?: Prisma.StreamLeaseSelectCreateManyAndReturn<ExtArgs> | null
+  /**
+   * Omit specific fields from the StreamLease
+   */
+  omit?: Prisma.StreamLeaseOmit<ExtArgs> | null
+  /**
+   * The data used to create many StreamLeases.
+   */
+  data: Prisma.StreamLeaseCreateManyInput | Prisma.StreamLeaseCreateManyInput[]
+  skipDuplicates?: boolean
+}
+
+/**
+ * StreamLease update
+ */
+export type StreamLeaseUpdateArgs<ExtArgs extends runtime.Types.Extensions.InternalArgs = runtime.Types.Extensions.DefaultArgs> = {
+  /**
+   * Select specific fields to fetch from the StreamLease
+   */
+  select?: Prisma.StreamLeaseSelect<ExtArgs> | null
+  /**
+   * Omit specific fields from the StreamLease
+   */
+  omit?: Prisma.StreamLeaseOmit<ExtArgs> | null
+  /**
+   * The data needed to update a StreamLease.
+   */
+  data: Prisma.XOR<Prisma.StreamLeaseUpdateInput, Prisma.StreamLeaseUncheckedUpdateInput>
+  /**
+   * Choose, which StreamLease to update.
+   */
+  where: Prisma.StreamLeaseWhereUniqueInput
+}
+
+/**
+ * StreamLease updateMany
+ */
+export type StreamLeaseUpdateManyArgs<ExtArgs extends runtime.Types.Extensions.InternalArgs = runtime.Types.Extensions.DefaultArgs> = {
+  /**
+   * The data used to update StreamLeases.
+   */
+  data: Prisma.XOR<Prisma.StreamLeaseUpdateManyMutationInput, Prisma.StreamLeaseUncheckedUpdateManyInput>
+  /**
+   * Filter which StreamLeases to update
+   */
+  where?: Prisma.StreamLeaseWhereInput
+  /**
+   * Limit how many StreamLeases to update.
+   */
+  limit?: number
+}
+
+/**
+ * StreamLease updateManyAndReturn
+ */
+export type StreamLeaseUpdateManyAndReturnArgs<ExtArgs extends runtime.Types.Extensions.InternalArgs = runtime.Types.Extensions.DefaultArgs> = {
+  /**
+   * Select specific fields to fetch from the StreamLease
+   */
+  select?: Prisma.StreamLeaseSelectUpdateManyAndReturn<ExtArgs> | null
+  /**
+   * Omit specific fields from the StreamLease
+   */
+  omit?: Prisma.StreamLeaseOmit<ExtArgs> | null
+  /**
+   * The data used to update StreamLeases.
+   */
+  data: Prisma.XOR<Prisma.StreamLeaseUpdateManyMutationInput, Prisma.StreamLeaseUncheckedUpdateManyInput>
+  /**
+   * Filter which StreamLeases to update
+   */
+  where?: Prisma.StreamLeaseWhereInput
+  /**
+   * Limit how many StreamLeases to update.
+   */
+  limit?: number
+}
+
+/**
+ * StreamLease upsert
+ */
+export type StreamLeaseUpsertArgs<ExtArgs extends runtime.Types.Extensions.InternalArgs = runtime.Types.Extensions.DefaultArgs> = {
+  /**
+   * Select specific fields to fetch from the StreamLease
+   */
+  select?: Prisma.StreamLeaseSelect<ExtArgs> | null
+  /**
+   * Omit specific fields from the StreamLease
+   */
+  omit?: Prisma.StreamLeaseOmit<ExtArgs> | null
+  /**
+   * The filter to search for the StreamLease to update in case it exists.
+   */
+  where: Prisma.StreamLeaseWhereUniqueInput
+  /**
+   * In case the StreamLease found by the `where` argument doesn't exist, create a new StreamLease with this data.
+   */
+  create: Prisma.XOR<Prisma.StreamLeaseCreateInput, Prisma.StreamLeaseUncheckedCreateInput>
+  /**
+   * In case the StreamLease was found with the provided `where` argument, update it with this data.
+   */
+  update: Prisma.XOR<Prisma.StreamLeaseUpdateInput, Prisma.StreamLeaseUncheckedUpdateInput>
+}
+
+/**
+ * StreamLease delete
+ */
+export type StreamLeaseDeleteArgs<ExtArgs extends runtime.Types.Extensions.InternalArgs = runtime.Types.Extensions.DefaultArgs> = {
+  /**
+   * Select specific fields to fetch from the StreamLease
+   */
+  select?: Prisma.StreamLeaseSelect<ExtArgs> | null
+  /**
+   * Omit specific fields from the StreamLease
+   */
+  omit?: Prisma.StreamLeaseOmit<ExtArgs> | null
+  /**
+   * Filter which StreamLease to delete.
+   */
+  where: Prisma.StreamLeaseWhereUniqueInput
+}
+
+/**
+ * StreamLease deleteMany
+ */
+export type StreamLeaseDeleteManyArgs<ExtArgs extends runtime.Types.Extensions.InternalArgs = runtime.Types.Extensions.DefaultArgs> = {
+  /**
+   * Filter which StreamLeases to delete
+   */
+  where?: Prisma.StreamLeaseWhereInput
+  /**
+   * Limit how many StreamLeases to delete.
+   */
+  limit?: number
+}
+
+/**
+ * StreamLease without action
+ */
+export type StreamLeaseDefaultArgs<ExtArgs extends runtime.Types.Extensions.InternalArgs = runtime.Types.Extensions.DefaultArgs> = {
+  /**
+   * Select specific fields to fetch from the StreamLease
+   */
+  select?: Prisma.StreamLeaseSelect<ExtArgs> | null
+  /**
+   * Omit specific fields from the StreamLease
+   */
+  omit?: Prisma.StreamLeaseOmit<ExtArgs> | null
+}
@@ -53,6 +53,7 @@ export type StreamMetaMinAggregateOutputType = {
   videoRepId: string | null
   audioRepId: string | null
   basePath: string | null
+  vodManifestPath: string | null
   createdAt: Date | null
   updatedAt: Date | null
 }
@@ -68,6 +69,7 @@ export type StreamMetaMaxAggregateOutputType = {
   videoRepId: string | null
   audioRepId: string | null
   basePath: string | null
+  vodManifestPath: string | null
   createdAt: Date | null
   updatedAt: Date | null
 }
@@ -83,6 +85,8 @@ export type StreamMetaCountAggregateOutputType = {
   videoRepId: number
   audioRepId: number
   basePath: number
+  vodManifestPath: number
+  renditions: number
   createdAt: number
   updatedAt: number
   _all: number
@@ -116,6 +120,7 @@ export type StreamMetaMinAggregateInputType = {
   videoRepId?: true
   audioRepId?: true
   basePath?: true
+  vodManifestPath?: true
   createdAt?: true
   updatedAt?: true
 }
@@ -131,6 +136,7 @@ export type StreamMetaMaxAggregateInputType = {
   videoRepId?: true
   audioRepId?: true
   basePath?: true
+  vodManifestPath?: true
   createdAt?: true
   updatedAt?: true
 }
@@ -146,6 +152,8 @@ export type StreamMetaCountAggregateInputType = {
   videoRepId?: true
   audioRepId?: true
   basePath?: true
+  vodManifestPath?: true
+  renditions?: true
   createdAt?: true
   updatedAt?: true
   _all?: true
@@ -248,6 +256,8 @@ export type StreamMetaGroupByOutputType = {
   videoRepId: string
   audioRepId: string
   basePath: string | null
+  vodManifestPath: string | null
+  renditions: runtime.JsonValue | null
   createdAt: Date
   updatedAt: Date
   _count: StreamMetaCountAggregateOutputType | null
@@ -286,6 +296,8 @@ export type StreamMetaWhereInput = {
   videoRepId?: Prisma.StringFilter<"StreamMeta"> | string
   audioRepId?: Prisma.StringFilter<"StreamMeta"> | string
   basePath?: Prisma.StringNullableFilter<"StreamMeta"> | string | null
+  vodManifestPath?: Prisma.StringNullableFilter<"StreamMeta"> | string | null
+  renditions?: Prisma.JsonNullableFilter<"StreamMeta">
   createdAt?: Prisma.DateTimeFilter<"StreamMeta"> | Date | string
   updatedAt?: Prisma.DateTimeFilter<"StreamMeta"> | Date | string
   stream?: Prisma.XOR<Prisma.StreamScalarRelationFilter, Prisma.StreamWhereInput>
@@ -302,6 +314,8 @@ export type StreamMetaOrderByWithRelationInput = {
   videoRepId?: Prisma.SortOrder
   audioRepId?: Prisma.SortOrder
   basePath?: Prisma.SortOrderInput | Prisma.SortOrder
+  vodManifestPath?: Prisma.SortOrderInput | Prisma.SortOrder
+  renditions?: Prisma.SortOrderInput | Prisma.SortOrder
   createdAt?: Prisma.SortOrder
   updatedAt?: Prisma.SortOrder
   stream?: Prisma.StreamOrderByWithRelationInput
@@ -321,6 +335,8 @@ export type StreamMetaWhereUniqueInput = Prisma.AtLeast<{
   videoRepId?: Prisma.StringFilter<"StreamMeta"> | string
   audioRepId?: Prisma.StringFilter<"StreamMeta"> | string
   basePath?: Prisma.StringNullableFilter<"StreamMeta"> | string | null
+  vodManifestPath?: Prisma.StringNullableFilter<"StreamMeta"> | string | null
+  renditions?: Prisma.JsonNullableFilter<"StreamMeta">
   createdAt?: Prisma.DateTimeFilter<"StreamMeta"> | Date | string
   updatedAt?: Prisma.DateTimeFilter<"StreamMeta"> | Date | string
   stream?: Prisma.XOR<Prisma.StreamScalarRelationFilter, Prisma.StreamWhereInput>
@@ -337,6 +353,8 @@ export type StreamMetaOrderByWithAggregationInput = {
   videoRepId?: Prisma.SortOrder
   audioRepId?: Prisma.SortOrder
   basePath?: Prisma.SortOrderInput | Prisma.SortOrder
+  vodManifestPath?: Prisma.SortOrderInput | Prisma.SortOrder
+  renditions?: Prisma.SortOrderInput | Prisma.SortOrder
   createdAt?: Prisma.SortOrder
   updatedAt?: Prisma.SortOrder
   _count?: Prisma.StreamMetaCountOrderByAggregateInput
@@ -360,6 +378,8 @@ export type StreamMetaScalarWhereWithAggregatesInput = {
   videoRepId?: Prisma.StringWithAggregatesFilter<"StreamMeta"> | string
   audioRepId?: Prisma.StringWithAggregatesFilter<"StreamMeta"> | string
   basePath?: Prisma.StringNullableWithAggregatesFilter<"StreamMeta"> | string | null
+  vodManifestPath?: Prisma.StringNullableWithAggregatesFilter<"StreamMeta"> | string | null
+  renditions?: Prisma.JsonNullableWithAggregatesFilter<"StreamMeta">
   createdAt?: Prisma.DateTimeWithAggregatesFilter<"StreamMeta"> | Date | string
   updatedAt?: Prisma.DateTimeWithAggregatesFilter<"StreamMeta"> | Date | string
 }
@@ -374,6 +394,8 @@ export type StreamMetaCreateInput = {
   videoRepId?: string
   audioRepId?: string
   basePath?: string | null
+  vodManifestPath?: string | null
+  renditions?: Prisma.NullableJsonNullValueInput | runtime.InputJsonValue
   createdAt?: Date | string
   updatedAt?: Date | string
   stream: Prisma.StreamCreateNestedOneWithoutMetaInput
@@ -390,6 +412,8 @@ export type StreamMetaUncheckedCreateInput = {
   videoRepId?: string
   audioRepId?: string
   basePath?: string | null
+  vodManifestPath?: string | null
+  renditions?: Prisma.NullableJsonNullValueInput | runtime.InputJsonValue
   createdAt?: Date | string
   updatedAt?: Date | string
 }
@@ -404,6 +428,8 @@ export type StreamMetaUpdateInput = {
   videoRepId?: Prisma.StringFieldUpdateOperationsInput | string
   audioRepId?: Prisma.StringFieldUpdateOperationsInput | string
   basePath?: Prisma.NullableStringFieldUpdateOperationsInput | string | null
+  vodManifestPath?: Prisma.NullableStringFieldUpdateOperationsInput | string | null
+  renditions?: Prisma.NullableJsonNullValueInput | runtime.InputJsonValue
   createdAt?: Prisma.DateTimeFieldUpdateOperationsInput | Date | string
   updatedAt?: Prisma.DateTimeFieldUpdateOperationsInput | Date | string
   stream?: Prisma.StreamUpdateOneRequiredWithoutMetaNestedInput
@@ -420,6 +446,8 @@ export type StreamMetaUncheckedUpdateInput = {
   videoRepId?: Prisma.StringFieldUpdateOperationsInput | string
   audioRepId?: Prisma.StringFieldUpdateOperationsInput | string
   basePath?: Prisma.NullableStringFieldUpdateOperationsInput | string | null
+  vodManifestPath?: Prisma.NullableStringFieldUpdateOperationsInput | string | null
+  renditions?: Prisma.NullableJsonNullValueInput | runtime.InputJsonValue
   createdAt?: Prisma.DateTimeFieldUpdateOperationsInput | Date | string
   updatedAt?: Prisma.DateTimeFieldUpdateOperationsInput | Date | string
 }
@@ -435,6 +463,8 @@ export type StreamMetaCreateManyInput = {
   videoRepId?: string
   audioRepId?: string
   basePath?: string | null
+  vodManifestPath?: string | null
+  renditions?: Prisma.NullableJsonNullValueInput | runtime.InputJsonValue
   createdAt?: Date | string
   updatedAt?: Date | string
 }
@@ -449,6 +479,8 @@ export type StreamMetaUpdateManyMutationInput = {
   videoRepId?: Prisma.StringFieldUpdateOperationsInput | string
   audioRepId?: Prisma.StringFieldUpdateOperationsInput | string
   basePath?: Prisma.NullableStringFieldUpdateOperationsInput | string | null
+  vodManifestPath?: Prisma.NullableStringFieldUpdateOperationsInput | string | null
+  renditions?: Prisma.NullableJsonNullValueInput | runtime.InputJsonValue
   createdAt?: Prisma.DateTimeFieldUpdateOperationsInput | Date | string
   updatedAt?: Prisma.DateTimeFieldUpdateOperationsInput | Date | string
 }
@@ -464,6 +496,8 @@ export type StreamMetaUncheckedUpdateManyInput = {
   videoRepId?: Prisma.StringFieldUpdateOperationsInput | string
   audioRepId?: Prisma.StringFieldUpdateOperationsInput | string
   basePath?: Prisma.NullableStringFieldUpdateOperationsInput | string | null
+  vodManifestPath?: Prisma.NullableStringFieldUpdateOperationsInput | string | null
+  renditions?: Prisma.NullableJsonNullValueInput | runtime.InputJsonValue
   createdAt?: Prisma.DateTimeFieldUpdateOperationsInput | Date | string
   updatedAt?: Prisma.DateTimeFieldUpdateOperationsInput | Date | string
 }
@@ -484,6 +518,8 @@ export type StreamMetaCountOrderByAggregateInput = {
   videoRepId?: Prisma.SortOrder
   audioRepId?: Prisma.SortOrder
   basePath?: Prisma.SortOrder
+  vodManifestPath?: Prisma.SortOrder
+  renditions?: Prisma.SortOrder
   createdAt?: Prisma.SortOrder
   updatedAt?: Prisma.SortOrder
 }
@@ -507,6 +543,7 @@ export type StreamMetaMaxOrderByAggregateInput = {
   videoRepId?: Prisma.SortOrder
   audioRepId?: Prisma.SortOrder
   basePath?: Prisma.SortOrder
+  vodManifestPath?: Prisma.SortOrder
   createdAt?: Prisma.SortOrder
   updatedAt?: Prisma.SortOrder
 }
@@ -522,6 +559,7 @@ export type StreamMetaMinOrderByAggregateInput = {
   videoRepId?: Prisma.SortOrder
   audioRepId?: Prisma.SortOrder
   basePath?: Prisma.SortOrder
+  vodManifestPath?: Prisma.SortOrder
   createdAt?: Prisma.SortOrder
   updatedAt?: Prisma.SortOrder
 }
@@ -592,6 +630,8 @@ export type StreamMetaCreateWithoutStreamInput = {
   videoRepId?: string
   audioRepId?: string
   basePath?: string | null
+  vodManifestPath?: string | null
+  renditions?: Prisma.NullableJsonNullValueInput | runtime.InputJsonValue
   createdAt?: Date | string
   updatedAt?: Date | string
 }
@@ -606,6 +646,8 @@ export type StreamMetaUncheckedCreateWithoutStreamInput = {
   videoRepId?: string
   audioRepId?: string
   basePath?: string | null
+  vodManifestPath?: string | null
+  renditions?: Prisma.NullableJsonNullValueInput | runtime.InputJsonValue
   createdAt?: Date | string
   updatedAt?: Date | string
 }
@@ -636,6 +678,8 @@ export type StreamMetaUpdateWithoutStreamInput = {
   videoRepId?: Prisma.StringFieldUpdateOperationsInput | string
   audioRepId?: Prisma.StringFieldUpdateOperationsInput | string
   basePath?: Prisma.NullableStringFieldUpdateOperationsInput | string | null
+  vodManifestPath?: Prisma.NullableStringFieldUpdateOperationsInput | string | null
+  renditions?: Prisma.NullableJsonNullValueInput | runtime.InputJsonValue
   createdAt?: Prisma.DateTimeFieldUpdateOperationsInput | Date | string
   updatedAt?: Prisma.DateTimeFieldUpdateOperationsInput | Date | string
 }
@@ -650,6 +694,8 @@ export type StreamMetaUncheckedUpdateWithoutStreamInput = {
   videoRepId?: Prisma.StringFieldUpdateOperationsInput | string
   audioRepId?: Prisma.StringFieldUpdateOperationsInput | string
   basePath?: Prisma.NullableStringFieldUpdateOperationsInput | string | null
+  vodManifestPath?: Prisma.NullableStringFieldUpdateOperationsInput | string | null
+  renditions?: Prisma.NullableJsonNullValueInput | runtime.InputJsonValue
   createdAt?: Prisma.DateTimeFieldUpdateOperationsInput | Date | string
   updatedAt?: Prisma.DateTimeFieldUpdateOperationsInput | Date | string
 }
@@ -667,6 +713,8 @@ export type StreamMetaSelect<ExtArgs extends runtime.Types.Extensions.InternalAr
   videoRepId?: boolean
   audioRepId?: boolean
   basePath?: boolean
+  vodManifestPath?: boolean
+  renditions?: boolean
   createdAt?: boolean
   updatedAt?: boolean
   stream?: boolean | Prisma.StreamDefaultArgs<ExtArgs>
@@ -683,6 +731,8 @@ export type StreamMetaSelectCreateManyAndReturn<ExtArgs extends runtime.Types.Ex
   videoRepId?: boolean
   audioRepId?: boolean
   basePath?: boolean
+  vodManifestPath?: boolean
+  renditions?: boolean
   createdAt?: boolean
   updatedAt?: boolean
   stream?: boolean | Prisma.StreamDefaultArgs<ExtArgs>
@@ -699,6 +749,8 @@ export type StreamMetaSelectUpdateManyAndReturn<ExtArgs extends runtime.Types.Ex
   videoRepId?: boolean
   audioRepId?: boolean
   basePath?: boolean
+  vodManifestPath?: boolean
+  renditions?: boolean
   createdAt?: boolean
   updatedAt?: boolean
   stream?: boolean | Prisma.StreamDefaultArgs<ExtArgs>
@@ -715,11 +767,13 @@ export type StreamMetaSelectScalar = {
   videoRepId?: boolean
   audioRepId?: boolean
   basePath?: boolean
+  vodManifestPath?: boolean
+  renditions?: boolean
   createdAt?: boolean
   updatedAt?: boolean
 }
 
-export type StreamMetaOmit<ExtArgs extends runtime.Types.Extensions.InternalArgs = runtime.Types.Extensions.DefaultArgs> = runtime.Types.Extensions.GetOmit<"id" | "streamId" | "totalDuration" | "segmentCount" | "lastSegmentSeq" | "segmentDuration" | "timescale" | "videoRepId" | "audioRepId" | "basePath" | "createdAt" | "updatedAt", ExtArgs["result"]["streamMeta"]>
+export type StreamMetaOmit<ExtArgs extends runtime.Types.Extensions.InternalArgs = runtime.Types.Extensions.DefaultArgs> = runtime.Types.Extensions.GetOmit<"id" | "streamId" | "totalDuration" | "segmentCount" | "lastSegmentSeq" | "segmentDuration" | "timescale" | "videoRepId" | "audioRepId" | "basePath" | "vodManifestPath" | "renditions" | "createdAt" | "updatedAt", ExtArgs["result"]["streamMeta"]>
 export type StreamMetaInclude<ExtArgs extends runtime.Types.Extensions.InternalArgs = runtime.Types.Extensions.DefaultArgs> = {
   stream?: boolean | Prisma.StreamDefaultArgs<ExtArgs>
 }
@@ -746,6 +800,8 @@ export type $StreamMetaPayload<ExtArgs extends runtime.Types.Extensions.Internal
     videoRepId: string
     audioRepId: string
     basePath: string | null
+    vodManifestPath: string | null
+    renditions: runtime.JsonValue | null
     createdAt: Date
     updatedAt: Date
   }, ExtArgs["result"]["streamMeta"]>
@@ -1182,6 +1238,8 @@ export interface StreamMetaFieldRefs {
   readonly videoRepId: Prisma.FieldRef<"StreamMeta", 'String'>
   readonly audioRepId: Prisma.FieldRef<"StreamMeta", 'String'>
   readonly basePath: Prisma.FieldRef<"StreamMeta", 'String'>
+  readonly vodManifestPath: Prisma.FieldRef<"StreamMeta", 'String'>
+  readonly renditions: Prisma.FieldRef<"StreamMeta", 'Json'>
   readonly createdAt: Prisma.FieldRef<"StreamMeta", 'DateTime'>
   readonly updatedAt: Prisma.FieldRef<"StreamMeta", 'DateTime'>
 }
@@ -13,6 +13,8 @@ export enum KafkaTopic {
 
   STREAM_ON_PUBLISH = 'stream.on_publish',
   STREAM_ON_PUBLISH_DLQ = 'stream.on_publish.dlq',
+
+  STREAM_LIFECYCLE = 'stream.lifecycle',
 }
 
 export const topicsConfig: ITopicConfig[] = [
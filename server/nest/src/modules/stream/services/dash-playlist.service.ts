@@ -1,9 +1,19 @@
 import { Injectable, NotFoundException } from '@nestjs/common';
 import { ConfigService } from '@nestjs/config';
-import { Prisma } from 'src/generated/prisma/client';
+import { StreamMeta } from 'src/generated/prisma/client';
 import { PrismaService } from 'src/infrastructure/database/prisma/prisma.service';
 import { MinioService } from 'src/infrastructure/minio/minio.service';
 
+/** One representation the worker encodes, as stored in StreamMeta.renditions. */
+interface MetaRendition {
+  repId: string;
+  contentType: 'video' | 'audio';
+  bandwidth: number;
+  width?: number;
+  height?: number;
+  codecs: string;
+}
+
 @Injectable()
 export class DashPlaylistService {
   constructor(
@@ -31,13 +41,31 @@ export class DashPlaylistService {
       duration = (stream.endedAt.getTime() - stream.startedAt.getTime()) / 1000;
     }
 
+    if (stream.meta.vodManifestPath) {
+      return this.getRecordedManifest(stream.id, stream.meta.vodManifestPath);
+    }
+
     return this.generateStaticManifest(stream.id, stream.meta);
   }
 
-  private generateStaticManifest(
+  /**
+   * Static manifest written by the worker from the real segment timeline.
+   * Segment URLs in it are relative, so the CDN base is injected here.
+   */
+  private async getRecordedManifest(
     streamID: string,
-    meta: Prisma.StreamMetaCreateManyInput,
-  ): string {
+    manifestPath: string,
+  ): Promise<string> {
+    const manifest = await this.minio.getTextFile(manifestPath);
+    const cdnBase = `${this.config.get('CDN_URL')}/${streamID}`;
+
+    return manifest.replace(
+      /(\s*)<Period/,
+      `$1<BaseURL>${cdnBase}/</BaseURL>$1<Period`,
+    );
+  }
+
+  private generateStaticManifest(streamID: string, meta: StreamMeta): string {
     const timeScale = meta.timescale || 1000;
     const segDuration = meta.segmentDuration || 0;
     const segmentCount = meta.lastSegmentSeq || 0;
@@ -45,51 +73,88 @@ export class DashPlaylistService {
     const totalDurationSeconds = (segmentCount * segDuration) / timeScale;
     const mediaPresentationDuration = `PT${totalDurationSeconds.toFixed(3)}S`;
 
-    const videoRepId = meta.videoRepId || '0';
-    const audioRepId = meta.audioRepId || '1';
     const cdnBase = `${this.config.get('CDN_URL')}/${streamID}`;
 
     const repeat = segmentCount > 0 ? segmentCount - 1 : 0;
 
-    return `<?xml version="1.0" encoding="utf-8"?>
-<MPD xmlns="urn:mpeg:dash:schema:mpd:2011"
-     profiles="urn:mpeg:dash:profile:isoff-on-demand:2011"
-     type="static"
-     mediaPresentationDuration="${mediaPresentationDuration}"
-     minBufferTime="PT4S">
-  <BaseURL>${cdnBase}/</BaseURL>
-  <Period start="PT0S">
-    <AdaptationSet contentType="video" segmentAlignment="true">
-      <Representation id="${videoRepId}" mimeType="video/mp4" codecs="avc1.4d401f" bandwidth="2500000" width="1280" height="720">
+    const renditions = this.getRenditions(meta);
+    const representations = (contentType: MetaRendition['contentType']) =>
+      renditions
+        .filter((r) => r.contentType === contentType)
+        .map((r) => {
+          const size =
+            (r.width ? ` width="${r.width}"` : '') +
+            (r.height ? ` height="${r.height}"` : '');
+          const sampling =
+            contentType === 'audio' ? ' audioSamplingRate="48000"' : '';
+
+          return `      <Representation id="${r.repId}" mimeType="${contentType}/mp4" codecs="${r.codecs}" bandwidth="${r.bandwidth}"${size}${sampling}>
         <SegmentTemplate
           timescale="${timeScale}"
-          initialization="init-${videoRepId}.mp4"
-          media="chunk-${videoRepId}-$Number$.m4s"
+          initialization="init-${r.repId}.mp4"
+          media="chunk-${r.repId}-$Number$.m4s"
           startNumber="1">
           <SegmentTimeline>
             <S t="0" d="${segDuration}" r="${repeat}" />
           </SegmentTimeline>
         </SegmentTemplate>
-      </Representation>
-    </AdaptationSet>
+      </Representation>`;
+        })
+        .join('\n');
+
+    const adaptationSets = (['video', 'audio'] as const)
+      .filter((contentType) =>
+        renditions.some((r) => r.contentType === contentType),
+      )
+      .map(
+        (contentType) => `    <AdaptationSet contentType="${contentType}" segmentAlignment="true">
+${representations(contentType)}
+    </AdaptationSet>`,
+      )
+      .join('\n\n');
 
-    <AdaptationSet contentType="audio" segmentAlignment="true">
-      <Representation id="${audioRepId}" mimeType="audio/mp4" codecs="mp4a.40.2" bandwidth="128000">
-        <SegmentTemplate
-          timescale="${timeScale}"
-          initialization="init-${audioRepId}.mp4"
-          media="chunk-${audioRepId}-$Number$.m4s"
-          startNumber="1">
-          <SegmentTimeline>
-            <S t="0" d="${segDuration}" r="${repeat}" />
-          </SegmentTimeline>
-        </SegmentTemplate>
-      </Representation>
-    </AdaptationSet>
+    return `<?xml version="1.0" encoding="utf-8"?>
+<MPD xmlns="urn:mpeg:dash:schema:mpd:2011"
+     profiles="urn:mpeg:dash:profile:isoff-on-demand:2011"
+     type="static"
+     mediaPresentationDuration="${mediaPresentationDuration}"
+     minBufferTime="PT4S">
+  <BaseURL>${cdnBase}/</BaseURL>
+  <Period start="PT0S">
+${adaptationSets}
   </Period>
 </MPD>`;
   }
 
+  /**
+   * The ladder the worker stored for the stream. Streams recorded before it
+   * was stored only have their representation IDs.
+   */
+  private getRenditions(meta: StreamMeta): MetaRendition[] {
+    if (Array.isArray(meta.renditions) && meta.renditions.length > 0) {
+      return meta.renditions as unknown as MetaRendition[];
+    }
+
+    const video = (meta.videoRepId || '0').split(',').map(
+      (repId): MetaRendition => ({
+        repId,
+        contentType: 'video',
+        bandwidth: 2500000,
+        codecs: 'avc1.4d401f',
+      }),
+    );
+
+    return [
+      ...video,
+      {
+        repId: meta.audioRepId || '1',
+        contentType: 'audio',
+        bandwidth: 128000,
+        codecs: 'mp4a.40.2',
+      },
+    ];
+  }
+
   private formatISODuration(seconds: number): string {
     const hours = Math.floor(seconds / 3600);
     const minutes = Math.floor((seconds % 3600) / 60);